@@ -1,142 +1,104 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"crypto/tls"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
-	checkpoint_controller "sports/backend/srv/controllers/checkpoint"
-	result_controller "sports/backend/srv/controllers/result"
-	sportsmen_controller "sports/backend/srv/controllers/sportsmen"
+	"os"
+	"sports/backend/client/sdk"
+	"sports/backend/srv/certs"
 	"sports/backend/srv/utils"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
-type id struct {
-	ID string `json:"id"`
-}
-
 func main() {
 	addr := "https://backend:8000"
 	currentNum := uint32(1)
+	ctx := context.Background()
 
 	// Provide the default source to a deterministic state.
 	rand.Seed(time.Now().UnixNano())
 
-	// Disable cert verification to use self-signed certificates for internal service needs.
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-
-	// Create new checkpoint.
-	checkpointRequestBody := checkpoint_controller.NewCheckpointRequest{
-		Name: "corridor",
-	}
-
-	requestBody, err := json.Marshal(checkpointRequestBody)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	res, err := http.Post(
-		addr+"/checkpoints",
-		"application/json; charset=UTF-8",
-		bytes.NewReader(requestBody),
-	)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	data, _ := ioutil.ReadAll(res.Body)
-	res.Body.Close()
-
-	checkPointID := id{}
-	err = json.Unmarshal(data, &checkPointID)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// Generate new results and finished results.
-	for _ = range time.Tick(3 * time.Second) {
-		go func(){
-		currentNum++
-
-		// Add new sportsmen
-		newSportsmenRequest := sportsmen_controller.NewSportsmenRequest{
-			FirstName:   fmt.Sprintf("Name%s", strconv.Itoa(int(currentNum))),
-			LastName:    fmt.Sprintf("Lastname%s", strconv.Itoa(int(currentNum))),
-			StartNumber: uint32(rand.Intn(1000)),
-		}
-
-		requestBody, err := json.Marshal(newSportsmenRequest)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		res, err := http.Post(
-			addr+"/sportsmens",
-			"application/json; charset=UTF-8",
-			bytes.NewReader(requestBody),
-		)
+	// Trust the CA of the server certificate, e.g. the local CA the server generated, the system CAs otherwise.
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCert := os.Getenv("CA_CERT"); caCert != "" {
+		pool, err := certs.CertPool(caCert)
 		if err != nil {
 			log.Fatal(err)
 		}
+		tlsConfig.RootCAs = pool
+	}
 
-		data, _ := ioutil.ReadAll(res.Body)
-		res.Body.Close()
-		sportsmenID := id{}
-		err = json.Unmarshal(data, &sportsmenID)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		// Add new result
-		newResultRequest := result_controller.NewResultRequest{
-			SportsmenID:  sportsmenID.ID,
-			CheckpointID: checkPointID.ID,
-			Time:         utils.MakeTimestampInMilliseconds(),
-		}
-
-		requestBody, err = json.Marshal(newResultRequest)
-		if err != nil {
-			log.Fatal(err)
-		}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
 
-		res, err = http.Post(
-			addr+"/results",
-			"application/json; charset=UTF-8",
-			bytes.NewReader(requestBody),
-		)
+	// API key of the admin credential, e.g. the bootstrap key of the server configuration,
+	// read from the secret file when API_KEY_FILE is set.
+	apiKey := os.Getenv("API_KEY")
+	if apiKeyFile := os.Getenv("API_KEY_FILE"); apiKeyFile != "" {
+		b, err := ioutil.ReadFile(apiKeyFile)
 		if err != nil {
 			log.Fatal(err)
 		}
+		apiKey = strings.TrimSpace(string(b))
+	}
 
-		time.Sleep(4 * time.Second)
-
-		// Finish new result after a little time.
-		newFinishRequest := result_controller.FinishRequest{
-			SportsmenID:  sportsmenID.ID,
-			CheckpointID: checkPointID.ID,
-			Time:         utils.MakeTimestampInMilliseconds(),
-		}
+	client := sdk.New(addr,
+		sdk.WithAPIKey(apiKey),
+		sdk.WithHTTPClient(&http.Client{Transport: transport}),
+	)
 
-		requestBody, err = json.Marshal(newFinishRequest)
-		if err != nil {
-			log.Fatal(err)
-		}
+	// Create new checkpoint.
+	checkpoint, err := client.AddCheckpoint(ctx, sdk.NewCheckpoint{
+		Name: "corridor",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		res, err = http.Post(
-			addr+"/finish",
-			"application/json; charset=UTF-8",
-			bytes.NewBuffer(requestBody),
-		)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}()
+	// Generate new results and finished results.
+	for range time.Tick(3 * time.Second) {
+		go func() {
+			num := atomic.AddUint32(&currentNum, 1)
+
+			// Add new sportsmen
+			sportsmen, err := client.AddSportsmen(ctx, sdk.NewSportsmen{
+				FirstName:   fmt.Sprintf("Name%s", strconv.Itoa(int(num))),
+				LastName:    fmt.Sprintf("Lastname%s", strconv.Itoa(int(num))),
+				StartNumber: uint32(rand.Intn(1000)),
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			// Add new result
+			result, err := client.AddResult(ctx, sdk.NewResult{
+				SportsmenID:  sportsmen.ID,
+				CheckpointID: checkpoint.ID,
+				TimeStart:    utils.MakeTimestampInMilliseconds(),
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			time.Sleep(4 * time.Second)
+
+			// Finish new result after a little time.
+			_, err = client.FinishResult(ctx, result.ETag, sdk.Finish{
+				SportsmenID:  sportsmen.ID,
+				CheckpointID: checkpoint.ID,
+				TimeFinish:   utils.MakeTimestampInMilliseconds(),
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		}()
 	}
 }
-
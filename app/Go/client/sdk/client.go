@@ -0,0 +1,572 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Client calls the sports event timing API described by srv/openapi/openapi.yaml,
+// it is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	token      string
+}
+
+// Option configures the client.
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with the given HTTP client instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey authenticates the requests with the API key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithToken authenticates the requests with the bearer token, it takes precedence over the API key.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// New returns the client of the API at baseURL, e.g. https://backend:8000.
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// RequestOption sets the optional headers of a single request.
+type RequestOption func(*http.Request)
+
+// IdempotencyKey makes the mutating request safe to retry, the response of the first request with the key
+// is replayed to the retries.
+func IdempotencyKey(key string) RequestOption {
+	return func(r *http.Request) {
+		r.Header.Set("Idempotency-Key", key)
+	}
+}
+
+// IfMatch conditions the update on the entity version tagged with etag, "*" matches any version.
+func IfMatch(etag string) RequestOption {
+	return func(r *http.Request) {
+		r.Header.Set("If-Match", etag)
+	}
+}
+
+// Problem is the RFC 7807 problem document returned by the API on failure.
+type Problem struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Code   string            `json:"code"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s (%s)", p.Status, p.Title, p.Code)
+	}
+
+	return fmt.Sprintf("%d %s (%s): %s", p.Status, p.Title, p.Code, p.Detail)
+}
+
+// do sends the request with the JSON body and decodes the JSON response into out, the failed requests
+// are returned as *Problem. The response body is closed when do returns.
+func (c *Client) do(ctx context.Context, method, target string, body, out interface{}, options []RequestOption) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+target, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	for _, option := range options {
+		option(req)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		problem := &Problem{}
+		if err := json.Unmarshal(data, problem); err != nil || problem.Status == 0 {
+			// Not every failure is described by the API, e.g. the proxy errors.
+			problem = &Problem{
+				Title:  http.StatusText(res.StatusCode),
+				Status: res.StatusCode,
+				Detail: strings.TrimSpace(string(data)),
+			}
+		}
+		return res, problem
+	}
+
+	// The files are returned as they are, e.g. the exports.
+	if raw, ok := out.(*[]byte); ok {
+		*raw = data
+		return res, nil
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// AddCheckpoint adds the checkpoint.
+func (c *Client) AddCheckpoint(ctx context.Context, checkpoint NewCheckpoint, options ...RequestOption) (*Created, error) {
+	return c.create(ctx, "/checkpoints", checkpoint, options)
+}
+
+// GetCheckpoint returns the checkpoint.
+func (c *Client) GetCheckpoint(ctx context.Context, id string) (*Checkpoint, error) {
+	checkpoint := &Checkpoint{}
+	res, err := c.do(ctx, http.MethodGet, "/checkpoints/"+id, nil, checkpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint.ETag = res.Header.Get("ETag")
+	return checkpoint, nil
+}
+
+// AddSportsmen adds the sportsmen.
+func (c *Client) AddSportsmen(ctx context.Context, sportsmen NewSportsmen, options ...RequestOption) (*Created, error) {
+	return c.create(ctx, "/sportsmens", sportsmen, options)
+}
+
+// GetSportsmen returns the sportsmen.
+func (c *Client) GetSportsmen(ctx context.Context, id string) (*Sportsmen, error) {
+	sportsmen := &Sportsmen{}
+	res, err := c.do(ctx, http.MethodGet, "/sportsmens/"+id, nil, sportsmen, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sportsmen.ETag = res.Header.Get("ETag")
+	return sportsmen, nil
+}
+
+// AddResult starts the sportsmen at the checkpoint, the returned tag is the precondition of FinishResult.
+func (c *Client) AddResult(ctx context.Context, result NewResult, options ...RequestOption) (*Created, error) {
+	res, err := c.do(ctx, http.MethodPost, "/results", result, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Created{
+		ID:   path.Base(res.Header.Get("Location")),
+		ETag: res.Header.Get("ETag"),
+	}, nil
+}
+
+// GetResult returns the result.
+func (c *Client) GetResult(ctx context.Context, id string) (*Result, error) {
+	result := &Result{}
+	res, err := c.do(ctx, http.MethodGet, "/results/"+id, nil, result, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ETag = res.Header.Get("ETag")
+	return result, nil
+}
+
+// GetLastResults returns the ten latest results.
+func (c *Client) GetLastResults(ctx context.Context) ([]Result, error) {
+	results := []Result{}
+	_, err := c.do(ctx, http.MethodGet, "/results", nil, &results, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// FinishResult finishes the started result of the sportsmen at the checkpoint, etag is the tag of the
+// started result version. The tag of the finished version is returned.
+func (c *Client) FinishResult(ctx context.Context, etag string, finish Finish, options ...RequestOption) (string, error) {
+	res, err := c.do(ctx, http.MethodPost, "/finish", finish, nil, append([]RequestOption{IfMatch(etag)}, options...))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Header.Get("ETag"), nil
+}
+
+// AddRace holds the race on the circuit of the checkpoint.
+func (c *Client) AddRace(ctx context.Context, race NewRace, options ...RequestOption) (*Created, error) {
+	return c.create(ctx, "/races", race, options)
+}
+
+// GetRaces returns the races.
+func (c *Client) GetRaces(ctx context.Context) ([]Race, error) {
+	races := []Race{}
+	_, err := c.do(ctx, http.MethodGet, "/races", nil, &races, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return races, nil
+}
+
+// GetRace returns the race.
+func (c *Client) GetRace(ctx context.Context, id string) (*Race, error) {
+	race := &Race{}
+	res, err := c.do(ctx, http.MethodGet, "/races/"+id, nil, race, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	race.ETag = res.Header.Get("ETag")
+	return race, nil
+}
+
+// GetStandings returns the standings of the race, the most laps completed come first.
+func (c *Client) GetStandings(ctx context.Context, id string) ([]Standing, error) {
+	standings := []Standing{}
+	_, err := c.do(ctx, http.MethodGet, "/races/"+id+"/standings", nil, &standings, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// AddLap records the passing of the race checkpoint as the next lap of the started result.
+func (c *Client) AddLap(ctx context.Context, lap NewLap, options ...RequestOption) (*LapRecorded, error) {
+	recorded := &LapRecorded{}
+	res, err := c.do(ctx, http.MethodPost, "/laps", lap, recorded, options)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded.ETag = res.Header.Get("ETag")
+	return recorded, nil
+}
+
+// GetLaps returns the laps of the result, the first lap comes first.
+func (c *Client) GetLaps(ctx context.Context, resultID string) ([]Lap, error) {
+	laps := []Lap{}
+	_, err := c.do(ctx, http.MethodGet, "/results/"+resultID+"/laps", nil, &laps, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return laps, nil
+}
+
+// AddTeam creates the relay team with the members assigned to the legs in the given order.
+func (c *Client) AddTeam(ctx context.Context, team NewTeam, options ...RequestOption) (*Created, error) {
+	return c.create(ctx, "/teams", team, options)
+}
+
+// GetTeams returns the teams ordered by the name.
+func (c *Client) GetTeams(ctx context.Context) ([]Team, error) {
+	teams := []Team{}
+	_, err := c.do(ctx, http.MethodGet, "/teams", nil, &teams, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// GetTeam returns the team.
+func (c *Client) GetTeam(ctx context.Context, id string) (*Team, error) {
+	team := &Team{}
+	res, err := c.do(ctx, http.MethodGet, "/teams/"+id, nil, team, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	team.ETag = res.Header.Get("ETag")
+	return team, nil
+}
+
+// GetTeamResults returns the team result with the individual results of its legs.
+func (c *Client) GetTeamResults(ctx context.Context, id string) (*TeamResults, error) {
+	results := &TeamResults{}
+	_, err := c.do(ctx, http.MethodGet, "/teams/"+id+"/results", nil, results, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// GetTeamStandings returns the team rankings, the most legs finished come first.
+func (c *Client) GetTeamStandings(ctx context.Context) ([]TeamStanding, error) {
+	standings := []TeamStanding{}
+	_, err := c.do(ctx, http.MethodGet, "/teams/standings", nil, &standings, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// Handover finishes the leg of the sportsmen at the handover checkpoint and starts the next leg.
+func (c *Client) Handover(ctx context.Context, handover Handover, options ...RequestOption) (*HandedOver, error) {
+	handedOver := &HandedOver{}
+	res, err := c.do(ctx, http.MethodPost, "/handovers", handover, handedOver, options)
+	if err != nil {
+		return nil, err
+	}
+
+	handedOver.ETag = res.Header.Get("ETag")
+	return handedOver, nil
+}
+
+// ExportTeamStandings returns the team rankings as the CSV file.
+func (c *Client) ExportTeamStandings(ctx context.Context) ([]byte, error) {
+	var file []byte
+	_, err := c.do(ctx, http.MethodGet, "/exports/teams", nil, &file, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Sync applies the timing records collected by the device while offline.
+func (c *Client) Sync(ctx context.Context, records []Record, options ...RequestOption) (*SyncResult, error) {
+	result := &SyncResult{}
+	_, err := c.do(ctx, http.MethodPost, "/sync", syncRequest{Records: records}, result, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RegisterDevice registers the timekeeper device, the enrollment code is returned only once.
+func (c *Client) RegisterDevice(ctx context.Context, device NewDevice, options ...RequestOption) (*DeviceRegistered, error) {
+	registered := &DeviceRegistered{}
+	res, err := c.do(ctx, http.MethodPost, "/devices", device, registered, options)
+	if err != nil {
+		return nil, err
+	}
+
+	registered.ETag = res.Header.Get("ETag")
+	return registered, nil
+}
+
+// EnrollDevice exchanges the enrollment code for the API key of the device.
+func (c *Client) EnrollDevice(ctx context.Context, code string) (*DeviceEnrolled, error) {
+	enrolled := &DeviceEnrolled{}
+	_, err := c.do(ctx, http.MethodPost, "/devices/enroll", enrollRequest{EnrollmentCode: code}, enrolled, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return enrolled, nil
+}
+
+// GetDevices returns the registered devices.
+func (c *Client) GetDevices(ctx context.Context) ([]Device, error) {
+	devices := []Device{}
+	_, err := c.do(ctx, http.MethodGet, "/devices", nil, &devices, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// GetDevice returns the device.
+func (c *Client) GetDevice(ctx context.Context, id string) (*Device, error) {
+	device := &Device{}
+	res, err := c.do(ctx, http.MethodGet, "/devices/"+id, nil, device, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	device.ETag = res.Header.Get("ETag")
+	return device, nil
+}
+
+// RevokeDevice revokes the device version tagged with etag, the tag of the revoked version is returned.
+func (c *Client) RevokeDevice(ctx context.Context, id, etag string, options ...RequestOption) (string, error) {
+	res, err := c.do(ctx, http.MethodDelete, "/devices/"+id, nil, nil, append([]RequestOption{IfMatch(etag)}, options...))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Header.Get("ETag"), nil
+}
+
+// AddAnnouncement publishes the race control announcement.
+func (c *Client) AddAnnouncement(ctx context.Context, announcement NewAnnouncement, options ...RequestOption) (*Created, error) {
+	return c.create(ctx, "/announcements", announcement, options)
+}
+
+// GetAnnouncements returns the announcements neither retracted nor expired.
+func (c *Client) GetAnnouncements(ctx context.Context) ([]Announcement, error) {
+	announcements := []Announcement{}
+	_, err := c.do(ctx, http.MethodGet, "/announcements", nil, &announcements, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return announcements, nil
+}
+
+// GetAnnouncement returns the announcement.
+func (c *Client) GetAnnouncement(ctx context.Context, id string) (*Announcement, error) {
+	announcement := &Announcement{}
+	res, err := c.do(ctx, http.MethodGet, "/announcements/"+id, nil, announcement, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	announcement.ETag = res.Header.Get("ETag")
+	return announcement, nil
+}
+
+// RetractAnnouncement retracts the announcement version tagged with etag, the tag of the retracted version is returned.
+func (c *Client) RetractAnnouncement(ctx context.Context, id, etag string, options ...RequestOption) (string, error) {
+	res, err := c.do(ctx, http.MethodDelete, "/announcements/"+id, nil, nil, append([]RequestOption{IfMatch(etag)}, options...))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Header.Get("ETag"), nil
+}
+
+// AddWebhook subscribes the webhook to the events, the secret is not returned afterwards.
+func (c *Client) AddWebhook(ctx context.Context, webhook NewWebhook, options ...RequestOption) (*Created, error) {
+	return c.create(ctx, "/webhooks", webhook, options)
+}
+
+// GetWebhooks returns the webhooks not removed.
+func (c *Client) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	webhooks := []Webhook{}
+	_, err := c.do(ctx, http.MethodGet, "/webhooks", nil, &webhooks, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetWebhook returns the webhook.
+func (c *Client) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
+	webhook := &Webhook{}
+	res, err := c.do(ctx, http.MethodGet, "/webhooks/"+id, nil, webhook, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.ETag = res.Header.Get("ETag")
+	return webhook, nil
+}
+
+// RemoveWebhook removes the webhook version tagged with etag, the tag of the removed version is returned.
+func (c *Client) RemoveWebhook(ctx context.Context, id, etag string, options ...RequestOption) (string, error) {
+	res, err := c.do(ctx, http.MethodDelete, "/webhooks/"+id, nil, nil, append([]RequestOption{IfMatch(etag)}, options...))
+	if err != nil {
+		return "", err
+	}
+
+	return res.Header.Get("ETag"), nil
+}
+
+// GetWebhookDeliveries returns the delivery log of the webhook, the latest deliveries come first.
+func (c *Client) GetWebhookDeliveries(ctx context.Context, id string) ([]WebhookDelivery, error) {
+	deliveries := []WebhookDelivery{}
+	_, err := c.do(ctx, http.MethodGet, "/webhooks/"+id+"/deliveries", nil, &deliveries, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver sends the delivery of the webhook again with the fresh attempts.
+func (c *Client) Redeliver(ctx context.Context, id, deliveryID string, options ...RequestOption) error {
+	_, err := c.do(ctx, http.MethodPost, "/webhooks/"+id+"/deliveries/"+deliveryID+"/redeliver", nil, nil, options)
+	return err
+}
+
+// IssueToken exchanges the API key of the client for the short-lived bearer token.
+func (c *Client) IssueToken(ctx context.Context) (*Token, error) {
+	token := &Token{}
+	_, err := c.do(ctx, http.MethodPost, "/auth/token", nil, token, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// RefreshDashboard reloads the dashboard snapshot and sends it to the connected clients.
+func (c *Client) RefreshDashboard(ctx context.Context, options ...RequestOption) error {
+	_, err := c.do(ctx, http.MethodPost, "/dashboard/snapshot", nil, nil, options)
+	return err
+}
+
+// create sends the new entity and returns its ID.
+func (c *Client) create(ctx context.Context, target string, entity interface{}, options []RequestOption) (*Created, error) {
+	created := &Created{}
+	res, err := c.do(ctx, http.MethodPost, target, entity, created, options)
+	if err != nil {
+		return nil, err
+	}
+
+	created.ETag = res.Header.Get("ETag")
+	return created, nil
+}
@@ -0,0 +1,271 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/client/sdk"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/cmd/config"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/routes"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+	"time"
+)
+
+// problemOf returns the problem document the request has failed with.
+func problemOf(err error) *sdk.Problem {
+	problem := &sdk.Problem{}
+	Expect(errors.As(err, &problem)).To(BeTrue(), "%v", err)
+	return problem
+}
+
+var _ = Describe("API client", func() {
+	var (
+		db     *gorm.DB
+		client *sdk.Client
+	)
+
+	ctx := context.Background()
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	// Set up the dashboard Websocket API module
+	dashboard := &dashboard_controller.Dashboard{
+		ConnHub: make(map[string]*dashboard_controller.Connection),
+		Results: make(chan dashboard_controller.UnfinishedResultMessage),
+		Finish:  make(chan dashboard_controller.FinishedResultMessage),
+		Join:    make(chan *dashboard_controller.Connection),
+		Leave:   make(chan *dashboard_controller.Connection),
+		Teams:   make(chan dashboard_controller.TeamMessage),
+	}
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+	srv.Dashboard = dashboard
+	srv.Dispatcher = dispatcher.NewDispatcher()
+	srv.Dashboard.Subscribe(srv.Dispatcher)
+	srv.IdempotencyKeyTTL = time.Hour
+
+	go srv.Dashboard.Run(context.Background(), srv.DB)
+
+	// Serve the whole API the way the client sees it.
+	routes.InitializeRoutes(&srv)
+	api := httptest.NewServer(srv.Router)
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+
+		key := "sdk-" + uuid.Must(uuid.NewV4()).String()
+		Expect(auth.EnsureBootstrapCredential(*db, key)).To(Succeed())
+
+		client = sdk.New(api.URL, sdk.WithAPIKey(key))
+	})
+
+	AfterEach(func() {
+		db.Rollback()
+	})
+
+	Describe("Timing the sportsmen", func() {
+		var checkpointID, sportsmenID string
+
+		BeforeEach(func() {
+			created, err := client.AddCheckpoint(ctx, sdk.NewCheckpoint{Name: "Start"})
+			Expect(err).To(BeNil())
+			Expect(created.ETag).To(Equal(`"1"`))
+			checkpointID = created.ID
+
+			created, err = client.AddSportsmen(ctx, sdk.NewSportsmen{
+				StartNumber: 1,
+				FirstName:   "John",
+				LastName:    "Doe",
+			})
+			Expect(err).To(BeNil())
+			sportsmenID = created.ID
+		})
+
+		Specify("The result started and finished", func() {
+			started, err := client.AddResult(ctx, sdk.NewResult{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenID,
+				TimeStart:    utils.MakeTimestampInMilliseconds(),
+			})
+			Expect(err).To(BeNil())
+			Expect(started.ID).NotTo(BeEmpty())
+
+			result, err := client.GetResult(ctx, started.ID)
+			Expect(err).To(BeNil())
+			Expect(result.ETag).To(Equal(started.ETag))
+			Expect(result.TimeFinish).To(BeNil())
+
+			finishedTag, err := client.FinishResult(ctx, started.ETag, sdk.Finish{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenID,
+				TimeFinish:   utils.MakeTimestampInMilliseconds(),
+			})
+			Expect(err).To(BeNil())
+			Expect(finishedTag).NotTo(Equal(started.ETag))
+
+			result, err = client.GetResult(ctx, started.ID)
+			Expect(err).To(BeNil())
+			Expect(result.ETag).To(Equal(finishedTag))
+			Expect(result.TimeFinish).NotTo(BeNil())
+
+			results, err := client.GetLastResults(ctx)
+			Expect(err).To(BeNil())
+			Expect(results).To(ContainElement(WithTransform(func(r sdk.Result) string { return r.ID }, Equal(started.ID))))
+		})
+
+		Specify("The stale version not finished", func() {
+			_, err := client.AddResult(ctx, sdk.NewResult{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenID,
+				TimeStart:    utils.MakeTimestampInMilliseconds(),
+			})
+			Expect(err).To(BeNil())
+
+			_, err = client.FinishResult(ctx, `"7"`, sdk.Finish{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenID,
+				TimeFinish:   utils.MakeTimestampInMilliseconds(),
+			})
+			problem := problemOf(err)
+			Expect(problem.Status).To(Equal(http.StatusPreconditionFailed))
+			Expect(problem.Code).To(Equal("invalid_version"))
+		})
+	})
+
+	Describe("Timing the relay team", func() {
+		Specify("The legs handed over and the team exported", func() {
+			created, err := client.AddCheckpoint(ctx, sdk.NewCheckpoint{Name: "Stadium"})
+			Expect(err).To(BeNil())
+			checkpointID := created.ID
+
+			var sportsmenIDs []string
+			for _, startNumber := range []uint32{1, 2} {
+				created, err = client.AddSportsmen(ctx, sdk.NewSportsmen{
+					StartNumber: startNumber,
+					FirstName:   "John",
+					LastName:    "Doe",
+				})
+				Expect(err).To(BeNil())
+				sportsmenIDs = append(sportsmenIDs, created.ID)
+			}
+
+			created, err = client.AddTeam(ctx, sdk.NewTeam{
+				Name: "Harriers",
+				Legs: []sdk.NewLeg{
+					{SportsmenID: sportsmenIDs[0], CheckpointID: checkpointID},
+					{SportsmenID: sportsmenIDs[1], CheckpointID: checkpointID},
+				},
+			})
+			Expect(err).To(BeNil())
+			teamID := created.ID
+
+			timeStart := utils.MakeTimestampInMilliseconds()
+			_, err = client.AddResult(ctx, sdk.NewResult{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenIDs[0],
+				TimeStart:    timeStart,
+			})
+			Expect(err).To(BeNil())
+
+			handedOver, err := client.Handover(ctx, sdk.Handover{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenIDs[0],
+				Time:         timeStart + 60000,
+			})
+			Expect(err).To(BeNil())
+			Expect(handedOver.Leg).To(Equal(uint32(1)))
+			Expect(handedOver.NextResultID).NotTo(BeEmpty())
+			Expect(handedOver.Finished).To(BeFalse())
+
+			handedOver, err = client.Handover(ctx, sdk.Handover{
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenIDs[1],
+				Time:         timeStart + 150000,
+			})
+			Expect(err).To(BeNil())
+			Expect(handedOver.Finished).To(BeTrue())
+
+			results, err := client.GetTeamResults(ctx, teamID)
+			Expect(err).To(BeNil())
+			Expect(results.TotalTime).To(Equal(int64(150000)))
+			Expect(*results.TimeFinish).To(Equal(timeStart + 150000))
+			Expect(results.Results).To(HaveLen(2))
+			Expect(*results.Results[1].LegTime).To(Equal(int64(90000)))
+
+			standings, err := client.GetTeamStandings(ctx)
+			Expect(err).To(BeNil())
+			Expect(standings[0].TeamID).To(Equal(teamID))
+
+			file, err := client.ExportTeamStandings(ctx)
+			Expect(err).To(BeNil())
+			Expect(string(file)).To(HavePrefix("rank,team,"))
+			Expect(string(file)).To(ContainSubstring("1,Harriers,,2,2,150000,"))
+		})
+	})
+
+	Describe("Failed requests", func() {
+		Specify("The missing entity reported", func() {
+			_, err := client.GetCheckpoint(ctx, uuid.Must(uuid.NewV4()).String())
+			problem := problemOf(err)
+			Expect(problem.Status).To(Equal(http.StatusNotFound))
+			Expect(problem.Code).To(Equal("checkpoint_not_found"))
+		})
+
+		Specify("The invalid fields reported", func() {
+			_, err := client.AddCheckpoint(ctx, sdk.NewCheckpoint{})
+			problem := problemOf(err)
+			Expect(problem.Status).To(Equal(http.StatusUnprocessableEntity))
+			Expect(problem.Code).To(Equal("validation_failed"))
+			Expect(problem.Errors).To(HaveKey("name"))
+		})
+
+		Specify("The anonymous request rejected", func() {
+			_, err := sdk.New(api.URL).GetLastResults(ctx)
+			problem := problemOf(err)
+			Expect(problem.Status).To(Equal(http.StatusUnauthorized))
+			Expect(problem.Code).To(Equal("unauthenticated"))
+		})
+	})
+
+	Specify("The retried request replayed", func() {
+		key := uuid.Must(uuid.NewV4()).String()
+
+		first, err := client.AddCheckpoint(ctx, sdk.NewCheckpoint{Name: "Start"}, sdk.IdempotencyKey(key))
+		Expect(err).To(BeNil())
+
+		retried, err := client.AddCheckpoint(ctx, sdk.NewCheckpoint{Name: "Start"}, sdk.IdempotencyKey(key))
+		Expect(err).To(BeNil())
+		Expect(retried).To(Equal(first))
+	})
+})
@@ -0,0 +1,397 @@
+package sdk
+
+import "encoding/json"
+
+// Created is the entity created by the request, the tag is the precondition of its updates.
+type Created struct {
+	ID   string `json:"id"`
+	ETag string `json:"-"`
+}
+
+type NewCheckpoint struct {
+	Name  string `json:"name"`
+	Event string `json:"event,omitempty"`
+}
+
+type Checkpoint struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Event     string `json:"event"`
+	CreatedAt int64  `json:"created_at"`
+	Version   uint32 `json:"version"`
+	ETag      string `json:"-"`
+}
+
+type NewSportsmen struct {
+	StartNumber uint32 `json:"start_number"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Category    string `json:"category,omitempty"`
+}
+
+type Sportsmen struct {
+	ID          string `json:"id"`
+	StartNumber uint32 `json:"start_number"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Category    string `json:"category"`
+	Status      string `json:"status"`
+	CreatedAt   int64  `json:"created_at"`
+	Version     uint32 `json:"version"`
+	ETag        string `json:"-"`
+}
+
+// NewResult starts the sportsmen at the checkpoint, the time is the Unix time in milliseconds.
+type NewResult struct {
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	TimeStart    int64  `json:"time_start"`
+}
+
+// Finish finishes the started result, the time is the Unix time in milliseconds.
+type Finish struct {
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	TimeFinish   int64  `json:"time_finish"`
+}
+
+type Result struct {
+	ID             string  `json:"id"`
+	CheckpointID   string  `json:"checkpoint_id"`
+	SportsmenID    string  `json:"sportsmen_id"`
+	TimeStart      int64   `json:"time_start"`
+	TimeFinish     *int64  `json:"time_finish"`
+	DeviceID       *string `json:"device_id"`
+	FinishDeviceID *string `json:"finish_device_id"`
+	CreatedAt      int64   `json:"created_at"`
+	Version        uint32  `json:"version"`
+	ETag           string  `json:"-"`
+}
+
+// Formats of the races.
+const (
+	RaceLaps  = "laps"
+	RaceTimed = "timed"
+)
+
+// Rules of the timed races crediting the lap completed after the race clock has run out.
+const (
+	PartialLapCount   = "count"
+	PartialLapDiscard = "discard"
+	PartialLapProrate = "prorate"
+)
+
+// NewRace holds the race on the circuit of the checkpoint, zero target laps or time limit is not applied,
+// the time limit and the minimum lap time are in milliseconds. The timed race runs the race clock
+// from the start time for the time limit, the lap distance is in meters.
+type NewRace struct {
+	Name         string `json:"name"`
+	CheckpointID string `json:"checkpoint_id"`
+	Format       string `json:"format"`
+	TargetLaps   uint32 `json:"target_laps,omitempty"`
+	TimeLimit    int64  `json:"time_limit,omitempty"`
+	MinLapTime   int64  `json:"min_lap_time,omitempty"`
+	StartTime    int64  `json:"start_time,omitempty"`
+	LapDistance  int64  `json:"lap_distance,omitempty"`
+	PartialLap   string `json:"partial_lap,omitempty"`
+}
+
+type Race struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	CheckpointID string `json:"checkpoint_id"`
+	Format       string `json:"format"`
+	TargetLaps   uint32 `json:"target_laps"`
+	TimeLimit    int64  `json:"time_limit"`
+	MinLapTime   int64  `json:"min_lap_time"`
+	StartTime    int64  `json:"start_time"`
+	LapDistance  int64  `json:"lap_distance"`
+	PartialLap   string `json:"partial_lap"`
+	CreatedAt    int64  `json:"created_at"`
+	Version      uint32 `json:"version"`
+	ETag         string `json:"-"`
+}
+
+// NewLap is the passing of the race checkpoint, the time is the Unix time in milliseconds.
+type NewLap struct {
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	Time         int64  `json:"time"`
+}
+
+// LapRecorded is the recorded lap, finished tells the lap has completed the race for the sportsmen,
+// partial tells the lap was completed after the race clock of the timed race has run out.
+type LapRecorded struct {
+	ID       string `json:"id"`
+	Number   uint32 `json:"number"`
+	LapTime  int64  `json:"lap_time"`
+	Distance int64  `json:"distance"`
+	Partial  bool   `json:"partial"`
+	Finished bool   `json:"finished"`
+	ETag     string `json:"-"`
+}
+
+type Lap struct {
+	ID          string  `json:"id"`
+	RaceID      string  `json:"race_id"`
+	ResultID    string  `json:"result_id"`
+	SportsmenID string  `json:"sportsmen_id"`
+	Number      uint32  `json:"number"`
+	Time        int64   `json:"time"`
+	LapTime     int64   `json:"lap_time"`
+	Distance    int64   `json:"distance"`
+	Partial     bool    `json:"partial"`
+	DeviceID    *string `json:"device_id"`
+	CreatedAt   int64   `json:"created_at"`
+	Version     uint32  `json:"version"`
+}
+
+type Standing struct {
+	ResultID    string `json:"result_id"`
+	SportsmenID string `json:"sportsmen_id"`
+	Laps        uint32 `json:"laps"`
+	Distance    int64  `json:"distance"`
+	TimeStart   int64  `json:"time_start"`
+	LastPassing *int64 `json:"last_passing"`
+	TimeFinish  *int64 `json:"time_finish"`
+	RaceTime    int64  `json:"race_time"`
+}
+
+// NewTeam is the relay team, the members are assigned to the legs in the given order.
+type NewTeam struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category,omitempty"`
+	Legs     []NewLeg `json:"legs"`
+}
+
+// NewLeg assigns the sportsmen to the leg ending at the handover checkpoint.
+type NewLeg struct {
+	SportsmenID  string `json:"sportsmen_id"`
+	CheckpointID string `json:"checkpoint_id"`
+}
+
+type Team struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Legs      uint32 `json:"legs"`
+	CreatedAt int64  `json:"created_at"`
+	Version   uint32 `json:"version"`
+	ETag      string `json:"-"`
+}
+
+// Handover is the passing of the handover checkpoint by the sportsmen ending the leg,
+// the time is the Unix time in milliseconds.
+type Handover struct {
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	Time         int64  `json:"time"`
+}
+
+// HandedOver is the leg handed over, the next result is empty when the handover has finished the team.
+type HandedOver struct {
+	TeamID       string `json:"team_id"`
+	Leg          uint32 `json:"leg"`
+	ResultID     string `json:"result_id"`
+	NextResultID string `json:"next_result_id"`
+	Finished     bool   `json:"finished"`
+	ETag         string `json:"-"`
+}
+
+// TeamStanding is the place of the team in the rankings, the total time is the sum of the finished legs.
+type TeamStanding struct {
+	TeamID       string `json:"team_id"`
+	Name         string `json:"name"`
+	Category     string `json:"category"`
+	Legs         uint32 `json:"legs"`
+	LegsFinished uint32 `json:"legs_finished"`
+	TimeStart    *int64 `json:"time_start"`
+	TimeFinish   *int64 `json:"time_finish"`
+	TotalTime    int64  `json:"total_time"`
+}
+
+type LegResult struct {
+	TeamID       string  `json:"team_id"`
+	Number       uint32  `json:"number"`
+	SportsmenID  string  `json:"sportsmen_id"`
+	StartNumber  uint32  `json:"start_number"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	CheckpointID string  `json:"checkpoint_id"`
+	ResultID     *string `json:"result_id"`
+	TimeStart    *int64  `json:"time_start"`
+	TimeFinish   *int64  `json:"time_finish"`
+	LegTime      *int64  `json:"leg_time"`
+}
+
+// TeamResults is the team result with the individual results of its legs, the first leg comes first.
+type TeamResults struct {
+	TeamStanding
+	Results []LegResult `json:"results"`
+}
+
+// Types of the synchronized records.
+const (
+	RecordStart    = "start"
+	RecordFinish   = "finish"
+	RecordSplit    = "split"
+	RecordStatus   = "status"
+	RecordLap      = "lap"
+	RecordHandover = "handover"
+)
+
+// Outcomes of the synchronized records.
+const (
+	OutcomeApplied   = "applied"
+	OutcomeDuplicate = "duplicate"
+	OutcomeRejected  = "rejected"
+)
+
+// Record is the timing record generated by the device, the ID is generated by the device
+// so that the record is applied once however many times it is sent.
+type Record struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	Time         int64  `json:"time"`
+	Status       string `json:"status,omitempty"`
+}
+
+type syncRequest struct {
+	Records []Record `json:"records"`
+}
+
+type SyncResult struct {
+	Records    []RecordOutcome `json:"records"`
+	Applied    int             `json:"applied"`
+	Duplicates int             `json:"duplicates"`
+	Rejected   int             `json:"rejected"`
+}
+
+type RecordOutcome struct {
+	ID       string `json:"id"`
+	Outcome  string `json:"outcome"`
+	EntityID string `json:"entity_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+type NewDevice struct {
+	Name         string `json:"name"`
+	Event        string `json:"event,omitempty"`
+	CheckpointID string `json:"checkpoint_id"`
+}
+
+type DeviceRegistered struct {
+	ID                  string `json:"id"`
+	EnrollmentCode      string `json:"enrollment_code"`
+	EnrollmentExpiresAt int64  `json:"enrollment_expires_at"`
+	ETag                string `json:"-"`
+}
+
+type enrollRequest struct {
+	EnrollmentCode string `json:"enrollment_code"`
+}
+
+type DeviceEnrolled struct {
+	DeviceID     string `json:"device_id"`
+	Event        string `json:"event"`
+	CheckpointID string `json:"checkpoint_id"`
+	APIKey       string `json:"api_key"`
+}
+
+type Device struct {
+	ID                  string  `json:"id"`
+	Name                string  `json:"name"`
+	Event               string  `json:"event"`
+	CheckpointID        string  `json:"checkpoint_id"`
+	EnrollmentExpiresAt int64   `json:"enrollment_expires_at"`
+	EnrolledAt          *int64  `json:"enrolled_at"`
+	CredentialID        *string `json:"credential_id"`
+	RevokedAt           *int64  `json:"revoked_at"`
+	CreatedAt           int64   `json:"created_at"`
+	Version             uint32  `json:"version"`
+	ETag                string  `json:"-"`
+}
+
+// Severities of the announcements.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+type NewAnnouncement struct {
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+	Event     string `json:"event,omitempty"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+}
+
+type Announcement struct {
+	ID          string `json:"id"`
+	Message     string `json:"message"`
+	Severity    string `json:"severity"`
+	Event       string `json:"event"`
+	ExpiresAt   *int64 `json:"expires_at"`
+	RetractedAt *int64 `json:"retracted_at"`
+	CreatedAt   int64  `json:"created_at"`
+	Version     uint32 `json:"version"`
+	ETag        string `json:"-"`
+}
+
+// Event types of the webhooks.
+const (
+	EventResultCreated          = "result.created"
+	EventResultFinished         = "result.finished"
+	EventSportsmenStatusChanged = "sportsmen.status_changed"
+	EventAnnouncementCreated    = "announcement.created"
+	EventAnnouncementRetracted  = "announcement.retracted"
+)
+
+type NewWebhook struct {
+	URL        string        `json:"url"`
+	Secret     string        `json:"secret"`
+	EventTypes []string      `json:"event_types"`
+	Filter     WebhookFilter `json:"filter"`
+}
+
+type WebhookFilter struct {
+	CheckpointID string `json:"checkpoint_id,omitempty"`
+	Category     string `json:"category,omitempty"`
+}
+
+type Webhook struct {
+	ID           string   `json:"id"`
+	URL          string   `json:"url"`
+	EventTypes   []string `json:"event_types"`
+	CheckpointID *string  `json:"checkpoint_id"`
+	Category     string   `json:"category"`
+	RemovedAt    *int64   `json:"removed_at"`
+	CreatedAt    int64    `json:"created_at"`
+	Version      uint32   `json:"version"`
+	ETag         string   `json:"-"`
+}
+
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	MessageID      string          `json:"message_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       uint32          `json:"attempts"`
+	NextAttemptAt  int64           `json:"next_attempt_at"`
+	ResponseStatus int             `json:"response_status"`
+	LastError      string          `json:"last_error"`
+	DeliveredAt    *int64          `json:"delivered_at"`
+	CreatedAt      int64           `json:"created_at"`
+	Version        uint32          `json:"version"`
+}
+
+type Token struct {
+	Token     string `json:"token"`
+	TokenType string `json:"token_type"`
+	ExpiresAt int64  `json:"expires_at"`
+}
@@ -0,0 +1,808 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: announcement.proto
+
+package announcement
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type AnnouncementCreatedEvent struct {
+	AnnouncementID       string   `protobuf:"bytes,1,opt,name=AnnouncementID,proto3" json:"AnnouncementID,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=Message,proto3" json:"Message,omitempty"`
+	Severity             string   `protobuf:"bytes,3,opt,name=Severity,proto3" json:"Severity,omitempty"`
+	Event                string   `protobuf:"bytes,4,opt,name=Event,proto3" json:"Event,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,5,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnouncementCreatedEvent) Reset()         { *m = AnnouncementCreatedEvent{} }
+func (m *AnnouncementCreatedEvent) String() string { return proto.CompactTextString(m) }
+func (*AnnouncementCreatedEvent) ProtoMessage()    {}
+func (*AnnouncementCreatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0080e911b962c5cf, []int{0}
+}
+func (m *AnnouncementCreatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AnnouncementCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AnnouncementCreatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AnnouncementCreatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnouncementCreatedEvent.Merge(m, src)
+}
+func (m *AnnouncementCreatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *AnnouncementCreatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnouncementCreatedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnouncementCreatedEvent proto.InternalMessageInfo
+
+func (m *AnnouncementCreatedEvent) GetAnnouncementID() string {
+	if m != nil {
+		return m.AnnouncementID
+	}
+	return ""
+}
+
+func (m *AnnouncementCreatedEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *AnnouncementCreatedEvent) GetSeverity() string {
+	if m != nil {
+		return m.Severity
+	}
+	return ""
+}
+
+func (m *AnnouncementCreatedEvent) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *AnnouncementCreatedEvent) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *AnnouncementCreatedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type AnnouncementRetractedEvent struct {
+	AnnouncementID       string   `protobuf:"bytes,1,opt,name=AnnouncementID,proto3" json:"AnnouncementID,omitempty"`
+	RetractedAt          int64    `protobuf:"varint,2,opt,name=RetractedAt,proto3" json:"RetractedAt,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnouncementRetractedEvent) Reset()         { *m = AnnouncementRetractedEvent{} }
+func (m *AnnouncementRetractedEvent) String() string { return proto.CompactTextString(m) }
+func (*AnnouncementRetractedEvent) ProtoMessage()    {}
+func (*AnnouncementRetractedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0080e911b962c5cf, []int{1}
+}
+func (m *AnnouncementRetractedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AnnouncementRetractedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AnnouncementRetractedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AnnouncementRetractedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnouncementRetractedEvent.Merge(m, src)
+}
+func (m *AnnouncementRetractedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *AnnouncementRetractedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnouncementRetractedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnouncementRetractedEvent proto.InternalMessageInfo
+
+func (m *AnnouncementRetractedEvent) GetAnnouncementID() string {
+	if m != nil {
+		return m.AnnouncementID
+	}
+	return ""
+}
+
+func (m *AnnouncementRetractedEvent) GetRetractedAt() int64 {
+	if m != nil {
+		return m.RetractedAt
+	}
+	return 0
+}
+
+func (m *AnnouncementRetractedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*AnnouncementCreatedEvent)(nil), "announcement.AnnouncementCreatedEvent")
+	proto.RegisterType((*AnnouncementRetractedEvent)(nil), "announcement.AnnouncementRetractedEvent")
+}
+
+func init() { proto.RegisterFile("announcement.proto", fileDescriptor_0080e911b962c5cf) }
+
+var fileDescriptor_0080e911b962c5cf = []byte{
+	// 225 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4a, 0xcc, 0xcb, 0xcb,
+	0x2f, 0xcd, 0x4b, 0x4e, 0xcd, 0x4d, 0xcd, 0x2b, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2,
+	0x41, 0x16, 0x53, 0x3a, 0xce, 0xc8, 0x25, 0xe1, 0x88, 0x24, 0xe0, 0x5c, 0x94, 0x9a, 0x58, 0x92,
+	0x9a, 0xe2, 0x5a, 0x96, 0x9a, 0x57, 0x22, 0xa4, 0xc6, 0xc5, 0x87, 0x2c, 0xe7, 0xe9, 0x22, 0xc1,
+	0xa8, 0xc0, 0xa8, 0xc1, 0x19, 0x84, 0x26, 0x2a, 0x24, 0xc1, 0xc5, 0xee, 0x9b, 0x5a, 0x5c, 0x9c,
+	0x98, 0x9e, 0x2a, 0xc1, 0x04, 0x56, 0x00, 0xe3, 0x0a, 0x49, 0x71, 0x71, 0x04, 0xa7, 0x96, 0xa5,
+	0x16, 0x65, 0x96, 0x54, 0x4a, 0x30, 0x83, 0xa5, 0xe0, 0x7c, 0x21, 0x11, 0x2e, 0x56, 0xb0, 0x35,
+	0x12, 0x2c, 0x60, 0x09, 0x08, 0x47, 0x48, 0x86, 0x8b, 0xd3, 0xb5, 0xa2, 0x20, 0xb3, 0x28, 0xb5,
+	0xd8, 0xb1, 0x44, 0x82, 0x55, 0x81, 0x51, 0x83, 0x39, 0x08, 0x21, 0x20, 0x24, 0xc9, 0xc5, 0x1e,
+	0x96, 0x5a, 0x54, 0x9c, 0x99, 0x9f, 0x27, 0xf1, 0x1f, 0xe4, 0x16, 0xde, 0x20, 0x18, 0x5f, 0xa9,
+	0x91, 0x91, 0x4b, 0x0a, 0xd9, 0x5d, 0x41, 0xa9, 0x25, 0x45, 0x89, 0xc9, 0x24, 0xfb, 0x45, 0x81,
+	0x8b, 0x1b, 0xae, 0xd3, 0xb1, 0x04, 0xec, 0x1f, 0xe6, 0x20, 0x64, 0x21, 0x3c, 0x6e, 0x70, 0x12,
+	0x38, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x67, 0x3c, 0x96,
+	0x63, 0x48, 0x62, 0x03, 0x07, 0xba, 0x31, 0x60, 0x00, 0x48, 0x64, 0x11, 0x10, 0x8a, 0x01, 0x00,
+	0x00,
+}
+
+func (m *AnnouncementCreatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AnnouncementCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AnnouncementCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintAnnouncement(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.ExpiresAt != 0 {
+		i = encodeVarintAnnouncement(dAtA, i, uint64(m.ExpiresAt))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Event) > 0 {
+		i -= len(m.Event)
+		copy(dAtA[i:], m.Event)
+		i = encodeVarintAnnouncement(dAtA, i, uint64(len(m.Event)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Severity) > 0 {
+		i -= len(m.Severity)
+		copy(dAtA[i:], m.Severity)
+		i = encodeVarintAnnouncement(dAtA, i, uint64(len(m.Severity)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintAnnouncement(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AnnouncementID) > 0 {
+		i -= len(m.AnnouncementID)
+		copy(dAtA[i:], m.AnnouncementID)
+		i = encodeVarintAnnouncement(dAtA, i, uint64(len(m.AnnouncementID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AnnouncementRetractedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AnnouncementRetractedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AnnouncementRetractedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintAnnouncement(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.RetractedAt != 0 {
+		i = encodeVarintAnnouncement(dAtA, i, uint64(m.RetractedAt))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.AnnouncementID) > 0 {
+		i -= len(m.AnnouncementID)
+		copy(dAtA[i:], m.AnnouncementID)
+		i = encodeVarintAnnouncement(dAtA, i, uint64(len(m.AnnouncementID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAnnouncement(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAnnouncement(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AnnouncementCreatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AnnouncementID)
+	if l > 0 {
+		n += 1 + l + sovAnnouncement(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovAnnouncement(uint64(l))
+	}
+	l = len(m.Severity)
+	if l > 0 {
+		n += 1 + l + sovAnnouncement(uint64(l))
+	}
+	l = len(m.Event)
+	if l > 0 {
+		n += 1 + l + sovAnnouncement(uint64(l))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovAnnouncement(uint64(m.ExpiresAt))
+	}
+	if m.Version != 0 {
+		n += 2 + sovAnnouncement(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AnnouncementRetractedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AnnouncementID)
+	if l > 0 {
+		n += 1 + l + sovAnnouncement(uint64(l))
+	}
+	if m.RetractedAt != 0 {
+		n += 1 + sovAnnouncement(uint64(m.RetractedAt))
+	}
+	if m.Version != 0 {
+		n += 2 + sovAnnouncement(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovAnnouncement(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAnnouncement(x uint64) (n int) {
+	return sovAnnouncement(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AnnouncementCreatedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAnnouncement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AnnouncementCreatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AnnouncementCreatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AnnouncementID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AnnouncementID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Severity", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Severity = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Event = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpiresAt", wireType)
+			}
+			m.ExpiresAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpiresAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAnnouncement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AnnouncementRetractedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAnnouncement
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AnnouncementRetractedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AnnouncementRetractedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AnnouncementID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AnnouncementID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetractedAt", wireType)
+			}
+			m.RetractedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetractedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAnnouncement(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAnnouncement
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAnnouncement(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAnnouncement
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAnnouncement
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAnnouncement
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAnnouncement
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAnnouncement
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAnnouncement        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAnnouncement          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAnnouncement = fmt.Errorf("proto: unexpected end of group")
+)
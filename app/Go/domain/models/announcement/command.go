@@ -0,0 +1,101 @@
+package announcement
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/transaction"
+	"strings"
+)
+
+// Create a new announcement.
+func Create(db gorm.DB, pendingAnnouncement PendingAnnouncement) (*AnnouncementCreatedEvent, error) {
+	pendingAnnouncement.Message = strings.TrimSpace(pendingAnnouncement.Message)
+	pendingAnnouncement.Event = strings.TrimSpace(pendingAnnouncement.Event)
+
+	if err := validation.ValidateStruct(
+		&pendingAnnouncement,
+		validation.Field(&pendingAnnouncement.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingAnnouncement.Message, validation.Required),
+		validation.Field(&pendingAnnouncement.Severity, validation.Required, validation.In(SeverityInfo, SeverityWarning, SeverityCritical)),
+	); err != nil {
+		return nil, err
+	}
+
+	newAnnouncement := Announcement{
+		ID:        pendingAnnouncement.ID,
+		Message:   pendingAnnouncement.Message,
+		Severity:  pendingAnnouncement.Severity,
+		Event:     pendingAnnouncement.Event,
+		ExpiresAt: pendingAnnouncement.ExpiresAt,
+		Version:   1,
+	}
+
+	event := &AnnouncementCreatedEvent{
+		AnnouncementID: newAnnouncement.ID.String(),
+		Message:        newAnnouncement.Message,
+		Severity:       newAnnouncement.Severity,
+		Event:          newAnnouncement.Event,
+		Version:        newAnnouncement.Version,
+	}
+
+	if newAnnouncement.ExpiresAt != nil {
+		event.ExpiresAt = *newAnnouncement.ExpiresAt
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		if err := tx.Create(&Announcement{
+			ID:        newAnnouncement.ID,
+			Message:   newAnnouncement.Message,
+			Severity:  newAnnouncement.Severity,
+			Event:     newAnnouncement.Event,
+			ExpiresAt: newAnnouncement.ExpiresAt,
+			Version:   newAnnouncement.Version,
+		}).Error; err != nil {
+			return err
+		}
+
+		return outbox.AppendEvent(tx, TopicCreated, newAnnouncement.ID, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// Retract the announcement so that it is not shown anymore.
+func Retract(db gorm.DB, retractedAt int64, announcement Announcement) (*AnnouncementRetractedEvent, error) {
+	if announcement.RetractedAt != nil {
+		return nil, AlreadyRetracted{}
+	}
+
+	event := &AnnouncementRetractedEvent{
+		AnnouncementID: announcement.ID.String(),
+		RetractedAt:    retractedAt,
+		Version:        announcement.Version + 1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Announcement{}).
+			Where("id = ? AND version = ? AND retracted_at IS NULL",
+				announcement.ID,
+				announcement.Version,
+			).Updates(map[string]interface{}{"retracted_at": retractedAt, "version": announcement.Version + 1})
+		if result.Error != nil {
+			return fmt.Errorf("Error retracting the announcement: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return outbox.AppendEvent(tx, TopicRetracted, announcement.ID, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
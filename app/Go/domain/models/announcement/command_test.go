@@ -0,0 +1,157 @@
+package announcement_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing announcements", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	BeforeEach(func() {
+		db = conn.Begin()
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Creating a new announcement", func() {
+		var pendingAnnouncement announcement.PendingAnnouncement
+
+		BeforeEach(func() {
+			expiresAt := utils.MakeTimestampInMilliseconds() + 60000
+
+			pendingAnnouncement = announcement.PendingAnnouncement{
+				ID:        uuid.Must(uuid.NewV4()),
+				Message:   "Start delayed 10 minutes",
+				Severity:  announcement.SeverityWarning,
+				Event:     "City Marathon",
+				ExpiresAt: &expiresAt,
+			}
+		})
+
+		When("the announcement is created", func() {
+			Specify("the returned event", func() {
+				event, err := announcement.Create(*db, pendingAnnouncement)
+				Expect(err).To(BeNil())
+
+				Expect(event).To(Equal(&announcement.AnnouncementCreatedEvent{
+					AnnouncementID: pendingAnnouncement.ID.String(),
+					Message:        pendingAnnouncement.Message,
+					Severity:       pendingAnnouncement.Severity,
+					Event:          pendingAnnouncement.Event,
+					ExpiresAt:      *pendingAnnouncement.ExpiresAt,
+					Version:        1,
+				}))
+			})
+
+			Specify("the announcement is persisted in the database", func() {
+				_, err := announcement.Create(*db, pendingAnnouncement)
+				Expect(err).To(BeNil())
+
+				fetched := announcement.Announcement{}
+				err = db.Model(&fetched).Where("id = ?", pendingAnnouncement.ID).Take(&fetched).Error
+				Expect(err).To(BeNil())
+
+				Expect(fetched.ID).To(Equal(pendingAnnouncement.ID))
+				Expect(fetched.Message).To(Equal(pendingAnnouncement.Message))
+				Expect(fetched.Severity).To(Equal(pendingAnnouncement.Severity))
+				Expect(fetched.Event).To(Equal(pendingAnnouncement.Event))
+				Expect(fetched.ExpiresAt).To(Equal(pendingAnnouncement.ExpiresAt))
+				Expect(fetched.RetractedAt).To(BeNil())
+				Expect(fetched.Version).To(Equal(uint32(1)))
+			})
+		})
+
+		When("the severity is unknown", func() {
+			Specify("the error returned", func() {
+				pendingAnnouncement.Severity = "fatal"
+
+				_, err := announcement.Create(*db, pendingAnnouncement)
+				Expect(err).ToNot(BeNil())
+				Expect(err.Error()).To(Equal("severity: must be a valid value."))
+			})
+		})
+	})
+
+	Describe("Retracting an announcement", func() {
+		var fetched *announcement.Announcement
+
+		BeforeEach(func() {
+			pendingAnnouncement := announcement.PendingAnnouncement{
+				ID:       uuid.Must(uuid.NewV4()),
+				Message:  "Course shortened",
+				Severity: announcement.SeverityInfo,
+			}
+
+			_, err := announcement.Create(*db, pendingAnnouncement)
+			Expect(err).To(BeNil())
+
+			fetched, err = announcement.GetAnnouncement(*db, pendingAnnouncement.ID, nil)
+			Expect(err).To(BeNil())
+		})
+
+		When("the announcement is retracted", func() {
+			Specify("the returned event and the announcement is not active anymore", func() {
+				retractedAt := utils.MakeTimestampInMilliseconds()
+
+				event, err := announcement.Retract(*db, retractedAt, *fetched)
+				Expect(err).To(BeNil())
+				Expect(event).To(Equal(&announcement.AnnouncementRetractedEvent{
+					AnnouncementID: fetched.ID.String(),
+					RetractedAt:    retractedAt,
+					Version:        2,
+				}))
+
+				retracted, err := announcement.GetAnnouncement(*db, fetched.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(*retracted.RetractedAt).To(Equal(retractedAt))
+				Expect(retracted.IsActive(retractedAt)).To(BeFalse())
+
+				_, err = announcement.Retract(*db, retractedAt, *retracted)
+				Expect(errors.As(err, &announcement.AlreadyRetracted{})).To(BeTrue())
+			})
+		})
+
+		When("the announcement has been updated in the meantime", func() {
+			Specify("the state conflict returned", func() {
+				_, err := announcement.Retract(*db, utils.MakeTimestampInMilliseconds(), *fetched)
+				Expect(err).To(BeNil())
+
+				// Retract the stale copy.
+				_, err = announcement.Retract(*db, utils.MakeTimestampInMilliseconds(), *fetched)
+				Expect(errors.As(err, &domain_errors.StateConflict{})).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package announcement
+
+type (
+	// NotFound signifies an announcement is not found.
+	NotFound struct{}
+
+	// AlreadyRetracted signifies an announcement has been retracted already.
+	AlreadyRetracted struct{}
+)
+
+func (err NotFound) Error() string {
+	return "Announcement does not exist"
+}
+
+func (err AlreadyRetracted) Error() string {
+	return "Announcement has been retracted already"
+}
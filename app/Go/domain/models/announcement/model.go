@@ -0,0 +1,45 @@
+package announcement
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Announcement severities, the dashboard screens highlight them accordingly.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Outbox topics of the announcement events.
+const (
+	TopicCreated   = "announcement.created"
+	TopicRetracted = "announcement.retracted"
+)
+
+// Announcement represents a persistence model for the race control announcement.
+type Announcement struct {
+	ID          uuid.UUID `gorm:"primary_key" json:"id"`
+	Message     string    `gorm:"not null" json:"message"`
+	Severity    string    `gorm:"not null" json:"severity"`
+	Event       string    `gorm:"not null;default:''" json:"event"`
+	ExpiresAt   *int64    `json:"expires_at"`
+	RetractedAt *int64    `json:"retracted_at"`
+	CreatedAt   int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version     uint32    `gorm:"not null" json:"version"`
+}
+
+// PendingAnnouncement represents an announcement about to publish,
+// empty event targets all the events, nil expiration keeps it active till retracted.
+type PendingAnnouncement struct {
+	ID        uuid.UUID `gorm:"primary_key" json:"id"`
+	Message   string    `gorm:"not null" json:"message"`
+	Severity  string    `gorm:"not null" json:"severity"`
+	Event     string    `json:"event"`
+	ExpiresAt *int64    `json:"expires_at"`
+}
+
+// IsActive reports whether the announcement is neither retracted nor expired at the given time in milliseconds.
+func (a Announcement) IsActive(now int64) bool {
+	return a.RetractedAt == nil && (a.ExpiresAt == nil || *a.ExpiresAt > now)
+}
@@ -0,0 +1,41 @@
+package announcement
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+)
+
+// GetAnnouncement fetches an announcement.
+func GetAnnouncement(db gorm.DB, pk uuid.UUID, version *uint32) (*Announcement, error) {
+	var announcement Announcement
+
+	err := db.Model(&announcement).Where("id = ?", pk).Take(&announcement).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Announcement not found: %w", NotFound{})
+	} else if version != nil && announcement.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading announcement: %w", err)
+	}
+
+	return &announcement, nil
+}
+
+// GetActiveAnnouncements fetches the announcements neither retracted nor expired
+// at the given time in milliseconds, the oldest announcement comes first.
+func GetActiveAnnouncements(db gorm.DB, now int64) (*[]Announcement, error) {
+	var announcements []Announcement
+
+	err := db.Where("retracted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", now).
+		Order("created_at asc").
+		Find(&announcements).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &announcements, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading announcements: %w", err)
+	}
+
+	return &announcements, nil
+}
@@ -0,0 +1,86 @@
+package announcement_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Fetching announcements", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	BeforeEach(func() {
+		db = conn.Begin()
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Fetching an announcement", func() {
+		When("the announcement does not exist", func() {
+			Specify("the error returned", func() {
+				_, err := announcement.GetAnnouncement(*db, uuid.Must(uuid.NewV4()), nil)
+				Expect(errors.As(err, &announcement.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Fetching active announcements", func() {
+		var activeID uuid.UUID
+		var now int64
+
+		BeforeEach(func() {
+			now = utils.MakeTimestampInMilliseconds()
+			past := now - 1000
+			future := now + 60000
+
+			activeID = uuid.Must(uuid.NewV4())
+
+			samples := []announcement.Announcement{
+				{ID: activeID, Message: "Start delayed", Severity: announcement.SeverityWarning, ExpiresAt: &future, Version: 1},
+				{ID: uuid.Must(uuid.NewV4()), Message: "Expired", Severity: announcement.SeverityInfo, ExpiresAt: &past, Version: 1},
+				{ID: uuid.Must(uuid.NewV4()), Message: "Retracted", Severity: announcement.SeverityInfo, RetractedAt: &past, Version: 2},
+			}
+
+			for _, sample := range samples {
+				err := db.Create(&sample).Error
+				Expect(err).To(BeNil())
+			}
+		})
+
+		Specify("Only announcements neither expired nor retracted returned", func() {
+			fetched, err := announcement.GetActiveAnnouncements(*db, now)
+			Expect(err).To(BeNil())
+			Expect(len(*fetched)).To(Equal(1))
+			Expect((*fetched)[0].ID).To(Equal(activeID))
+		})
+	})
+})
@@ -25,6 +25,7 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type CheckpointCreatedEvent struct {
 	CheckpointID         string   `protobuf:"bytes,1,opt,name=CheckpointID,proto3" json:"CheckpointID,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Event                string   `protobuf:"bytes,3,opt,name=Event,proto3" json:"Event,omitempty"`
 	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -78,6 +79,13 @@ func (m *CheckpointCreatedEvent) GetName() string {
 	return ""
 }
 
+func (m *CheckpointCreatedEvent) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
 func (m *CheckpointCreatedEvent) GetVersion() uint32 {
 	if m != nil {
 		return m.Version
@@ -92,16 +100,17 @@ func init() {
 func init() { proto.RegisterFile("checkpoint.proto", fileDescriptor_9bab050ffa824783) }
 
 var fileDescriptor_9bab050ffa824783 = []byte{
-	// 138 bytes of a gzipped FileDescriptorProto
+	// 148 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x48, 0xce, 0x48, 0x4d,
 	0xce, 0x2e, 0xc8, 0xcf, 0xcc, 0x2b, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x42, 0x88,
-	0x28, 0x65, 0x73, 0x89, 0x39, 0xc3, 0x79, 0xce, 0x45, 0xa9, 0x89, 0x25, 0xa9, 0x29, 0xae, 0x65,
-	0xa9, 0x79, 0x25, 0x42, 0x4a, 0x5c, 0x3c, 0x08, 0x19, 0x4f, 0x17, 0x09, 0x46, 0x05, 0x46, 0x0d,
-	0xce, 0x20, 0x14, 0x31, 0x21, 0x21, 0x2e, 0x16, 0xbf, 0xc4, 0xdc, 0x54, 0x09, 0x26, 0xb0, 0x1c,
-	0x98, 0x2d, 0x24, 0xc9, 0xc5, 0x1e, 0x96, 0x5a, 0x54, 0x9c, 0x99, 0x9f, 0x27, 0xf1, 0x1f, 0xa4,
-	0x87, 0x37, 0x08, 0xc6, 0x77, 0x12, 0x38, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07,
-	0x8f, 0xe4, 0x18, 0x67, 0x3c, 0x96, 0x63, 0x48, 0x62, 0x03, 0xbb, 0xc8, 0x18, 0x10, 0x00, 0x00,
-	0xff, 0xff, 0xf3, 0xeb, 0x20, 0xa3, 0xa5, 0x00, 0x00, 0x00,
+	0x28, 0x35, 0x32, 0x72, 0x89, 0x39, 0xc3, 0xb9, 0xce, 0x45, 0xa9, 0x89, 0x25, 0xa9, 0x29, 0xae,
+	0x65, 0xa9, 0x79, 0x25, 0x42, 0x4a, 0x5c, 0x3c, 0x08, 0x19, 0x4f, 0x17, 0x09, 0x46, 0x05, 0x46,
+	0x0d, 0xce, 0x20, 0x14, 0x31, 0x21, 0x21, 0x2e, 0x16, 0xbf, 0xc4, 0xdc, 0x54, 0x09, 0x26, 0xb0,
+	0x1c, 0x98, 0x2d, 0x24, 0xc2, 0xc5, 0x0a, 0x36, 0x40, 0x82, 0x19, 0x2c, 0x08, 0xe1, 0x08, 0x49,
+	0x72, 0xb1, 0x87, 0xa5, 0x16, 0x15, 0x67, 0xe6, 0xe7, 0x49, 0xfc, 0x07, 0x99, 0xc4, 0x1b, 0x04,
+	0xe3, 0x3b, 0x09, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c,
+	0x33, 0x1e, 0xcb, 0x31, 0x24, 0xb1, 0x81, 0x1d, 0x6a, 0x0c, 0x18, 0x00, 0xa4, 0x48, 0xfa, 0x4e,
+	0xbc, 0x00, 0x00, 0x00,
 }
 
 func (m *CheckpointCreatedEvent) Marshal() (dAtA []byte, err error) {
@@ -135,6 +144,13 @@ func (m *CheckpointCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i--
 		dAtA[i] = 0xf8
 	}
+	if len(m.Event) > 0 {
+		i -= len(m.Event)
+		copy(dAtA[i:], m.Event)
+		i = encodeVarintCheckpoint(dAtA, i, uint64(len(m.Event)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -177,6 +193,10 @@ func (m *CheckpointCreatedEvent) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCheckpoint(uint64(l))
 	}
+	l = len(m.Event)
+	if l > 0 {
+		n += 1 + l + sovCheckpoint(uint64(l))
+	}
 	if m.Version != 0 {
 		n += 2 + sovCheckpoint(uint64(m.Version))
 	}
@@ -285,6 +305,38 @@ func (m *CheckpointCreatedEvent) Unmarshal(dAtA []byte) error {
 			}
 			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheckpoint
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheckpoint
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Event = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 255:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
@@ -4,6 +4,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 	"github.com/jinzhu/gorm"
+	"sports/backend/domain/transaction"
 	"strings"
 )
 
@@ -11,6 +12,7 @@ import (
 func Create(db gorm.DB, pendingCheckpoint PendingCheckpoint) (*CheckpointCreatedEvent, error) {
 	pendingCheckpoint.Name = strings.TrimSpace(pendingCheckpoint.Name)
 	pendingCheckpoint.Name = strings.Title(pendingCheckpoint.Name)
+	pendingCheckpoint.Event = strings.TrimSpace(pendingCheckpoint.Event)
 
 	if err := validation.ValidateStruct(
 		&pendingCheckpoint,
@@ -23,20 +25,26 @@ func Create(db gorm.DB, pendingCheckpoint PendingCheckpoint) (*CheckpointCreated
 	newCheckpoint := Checkpoint{
 		ID:      pendingCheckpoint.ID,
 		Name:    pendingCheckpoint.Name,
+		Event:   pendingCheckpoint.Event,
 		Version: 1,
 	}
 
-	if err := db.Create(&Checkpoint{
-		ID:      newCheckpoint.ID,
-		Name:    newCheckpoint.Name,
-		Version: newCheckpoint.Version,
-	}).Error; err != nil {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		return tx.Create(&Checkpoint{
+			ID:      newCheckpoint.ID,
+			Name:    newCheckpoint.Name,
+			Event:   newCheckpoint.Event,
+			Version: newCheckpoint.Version,
+		}).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return &CheckpointCreatedEvent{
 		CheckpointID: newCheckpoint.ID.String(),
 		Name:         newCheckpoint.Name,
+		Event:        newCheckpoint.Event,
 		Version:      newCheckpoint.Version,
 	}, nil
 }
@@ -48,8 +48,9 @@ var _ = Describe("Managing checkpoints", func() {
 
 		BeforeEach(func() {
 			pendingCheckpoint = checkpoint.PendingCheckpoint{
-				ID:   uuid.Must(uuid.NewV4()),
-				Name: "Corridor1",
+				ID:    uuid.Must(uuid.NewV4()),
+				Name:  "Corridor1",
+				Event: " City Marathon ",
 			}
 		})
 
@@ -61,6 +62,7 @@ var _ = Describe("Managing checkpoints", func() {
 				Expect(event).To(Equal(&checkpoint.CheckpointCreatedEvent{
 					CheckpointID: pendingCheckpoint.ID.String(),
 					Name:         pendingCheckpoint.Name,
+					Event:        "City Marathon",
 					Version:      1,
 				}))
 			})
@@ -75,6 +77,7 @@ var _ = Describe("Managing checkpoints", func() {
 
 				Expect(fetched.ID).To(Equal(pendingCheckpoint.ID))
 				Expect(fetched.Name).To(Equal(pendingCheckpoint.Name))
+				Expect(fetched.Event).To(Equal("City Marathon"))
 				Expect(fetched.Version).To(Equal(uint32(1)))
 			})
 		})
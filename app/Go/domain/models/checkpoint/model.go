@@ -8,12 +8,14 @@ import (
 type Checkpoint struct {
 	ID        uuid.UUID `gorm:"primary_key" json:"id"`
 	Name      string    `gorm:"not null" json:"name"`
+	Event     string    `gorm:"not null;default:''" json:"event"`
 	CreatedAt int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
 	Version   uint32    `gorm:"not null" json:"version"`
 }
 
 // PendingCheckpoint represents a checkpoint about to create.
 type PendingCheckpoint struct {
-	ID   uuid.UUID `gorm:"primary_key" json:"id"`
-	Name string    `gorm:"not null" json:"name"`
+	ID    uuid.UUID `gorm:"primary_key" json:"id"`
+	Name  string    `gorm:"not null" json:"name"`
+	Event string    `gorm:"not null;default:''" json:"event"`
 }
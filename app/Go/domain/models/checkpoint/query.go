@@ -23,7 +23,20 @@ func GetCheckpoint(db gorm.DB, pk uuid.UUID, version *uint32) (*Checkpoint, erro
 	return &Checkpoint{
 		ID:        checkpoint.ID,
 		Name:      checkpoint.Name,
+		Event:     checkpoint.Event,
 		CreatedAt: checkpoint.CreatedAt,
 		Version:   checkpoint.Version,
 	}, nil
 }
+
+// GetEvents fetches the events the checkpoints belong to, the checkpoints without event are skipped.
+func GetEvents(db gorm.DB) ([]string, error) {
+	var events []string
+
+	err := db.Model(&Checkpoint{}).Where("event <> ''").Order("event").Pluck("DISTINCT event", &events).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading checkpoint events: %w", err)
+	}
+
+	return events, nil
+}
@@ -73,4 +73,37 @@ var _ = Describe("Managing checkpoints", func() {
 			})
 		})
 	})
+
+	Describe("Fetching the events of the checkpoints", func() {
+		BeforeEach(func() {
+			for _, sample := range []checkpoint.Checkpoint{
+				{Name: "Start", Event: "Night Run"},
+				{Name: "Finish", Event: "Night Run"},
+				{Name: "Start", Event: "City Marathon"},
+				{Name: "Stadium"},
+			} {
+				sample.ID = uuid.Must(uuid.NewV4())
+				sample.Version = 1
+
+				err := db.Create(&sample).Error
+				Expect(err).To(BeNil())
+			}
+		})
+
+		Specify("every event returned once", func() {
+			events, err := checkpoint.GetEvents(*db)
+			Expect(err).To(BeNil())
+
+			Expect(events).To(ContainElements("City Marathon", "Night Run"))
+			Expect(events).NotTo(ContainElement(""))
+
+			nightRuns := 0
+			for _, event := range events {
+				if event == "Night Run" {
+					nightRuns++
+				}
+			}
+			Expect(nightRuns).To(Equal(1))
+		})
+	})
 })
@@ -0,0 +1,132 @@
+package credential
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/transaction"
+	"strings"
+)
+
+// Create a new credential.
+func Create(db gorm.DB, pendingCredential PendingCredential) (*CredentialCreatedEvent, error) {
+	pendingCredential.Name = strings.TrimSpace(pendingCredential.Name)
+
+	if err := validation.ValidateStruct(
+		&pendingCredential,
+		validation.Field(&pendingCredential.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingCredential.Name, validation.Required),
+		validation.Field(&pendingCredential.Role, validation.Required, validation.In(RoleAdmin, RoleTimekeeper, RoleViewer)),
+		validation.Field(&pendingCredential.CheckpointIDs, validation.By(checkpointsBinding(pendingCredential.Role))),
+		validation.Field(&pendingCredential.KeyHash, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	var checkpointIDs []string
+	for _, checkpointID := range pendingCredential.CheckpointIDs {
+		checkpointIDs = append(checkpointIDs, checkpointID.String())
+	}
+
+	newCredential := Credential{
+		ID:            pendingCredential.ID,
+		Name:          pendingCredential.Name,
+		Role:          pendingCredential.Role,
+		CheckpointIDs: checkpointIDs,
+		DeviceID:      pendingCredential.DeviceID,
+		KeyHash:       pendingCredential.KeyHash,
+		Version:       1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		for _, checkpointID := range pendingCredential.CheckpointIDs {
+			err := tx.Model(&checkpoint.Checkpoint{}).Where(
+				"id = ?",
+				checkpointID,
+			).Take(&checkpoint.Checkpoint{}).Error
+			if gorm.IsRecordNotFoundError(err) {
+				return checkpoint.NotFound{}
+			} else if err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&Credential{
+			ID:            newCredential.ID,
+			Name:          newCredential.Name,
+			Role:          newCredential.Role,
+			CheckpointIDs: newCredential.CheckpointIDs,
+			DeviceID:      newCredential.DeviceID,
+			KeyHash:       newCredential.KeyHash,
+			Version:       newCredential.Version,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := &CredentialCreatedEvent{
+		CredentialID:  newCredential.ID.String(),
+		Name:          newCredential.Name,
+		Role:          newCredential.Role,
+		CheckpointIDs: checkpointIDs,
+		Version:       newCredential.Version,
+	}
+
+	if newCredential.DeviceID != nil {
+		event.DeviceID = newCredential.DeviceID.String()
+	}
+
+	return event, nil
+}
+
+// Revoke the credential so that neither its API key nor the tokens issued for it are accepted.
+func Revoke(db gorm.DB, revokedAt int64, credential Credential) (*CredentialRevokedEvent, error) {
+	if credential.RevokedAt != nil {
+		return nil, AlreadyRevoked{}
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Credential{}).
+			Where("id = ? AND version = ? AND revoked_at IS NULL",
+				credential.ID,
+				credential.Version,
+			).Updates(map[string]interface{}{"revoked_at": revokedAt, "version": credential.Version + 1})
+		if result.Error != nil {
+			return fmt.Errorf("Error revoking the credential: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CredentialRevokedEvent{
+		CredentialID: credential.ID.String(),
+		RevokedAt:    revokedAt,
+		Version:      credential.Version + 1,
+	}, nil
+}
+
+// checkpointsBinding checks the timekeepers are bound to checkpoints and the other roles are not.
+func checkpointsBinding(role string) validation.RuleFunc {
+	return func(value interface{}) error {
+		checkpointIDs, _ := value.([]uuid.UUID)
+
+		if role == RoleTimekeeper && len(checkpointIDs) == 0 {
+			return errors.New("cannot be blank for the timekeeper")
+		} else if role != RoleTimekeeper && len(checkpointIDs) != 0 {
+			return errors.New("must be blank for the role")
+		}
+
+		return nil
+	}
+}
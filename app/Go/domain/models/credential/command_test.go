@@ -0,0 +1,191 @@
+package credential_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing credentials", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	BeforeEach(func() {
+		db = conn.Begin()
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Issuing a new credential", func() {
+		var pendingCheckpoint checkpoint.PendingCheckpoint
+		var pendingCredential credential.PendingCredential
+
+		BeforeEach(func() {
+			pendingCheckpoint = checkpoint.PendingCheckpoint{
+				ID:   uuid.Must(uuid.NewV4()),
+				Name: "Corridor",
+			}
+
+			_, err := checkpoint.Create(*db, pendingCheckpoint)
+			Expect(err).To(BeNil())
+
+			pendingCredential = credential.PendingCredential{
+				ID:            uuid.Must(uuid.NewV4()),
+				Name:          "Timekeeper 10 km",
+				Role:          credential.RoleTimekeeper,
+				CheckpointIDs: []uuid.UUID{pendingCheckpoint.ID},
+				KeyHash:       uuid.Must(uuid.NewV4()).String(),
+			}
+		})
+
+		When("the timekeeper credential is created", func() {
+			Specify("the returned event", func() {
+				event, err := credential.Create(*db, pendingCredential)
+				Expect(err).To(BeNil())
+
+				Expect(event).To(Equal(&credential.CredentialCreatedEvent{
+					CredentialID:  pendingCredential.ID.String(),
+					Name:          pendingCredential.Name,
+					Role:          pendingCredential.Role,
+					CheckpointIDs: []string{pendingCheckpoint.ID.String()},
+					Version:       1,
+				}))
+			})
+
+			Specify("the credential is persisted in the database", func() {
+				_, err := credential.Create(*db, pendingCredential)
+				Expect(err).To(BeNil())
+
+				fetched, err := credential.GetCredential(*db, pendingCredential.ID, nil)
+				Expect(err).To(BeNil())
+
+				Expect(fetched.Role).To(Equal(credential.RoleTimekeeper))
+				Expect([]string(fetched.CheckpointIDs)).To(Equal([]string{pendingCheckpoint.ID.String()}))
+				Expect(fetched.KeyHash).To(Equal(pendingCredential.KeyHash))
+				Expect(fetched.RevokedAt).To(BeNil())
+			})
+		})
+
+		When("the timekeeper is not bound to any checkpoint", func() {
+			Specify("the error returned", func() {
+				pendingCredential.CheckpointIDs = nil
+
+				_, err := credential.Create(*db, pendingCredential)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the admin is bound to a checkpoint", func() {
+			Specify("the error returned", func() {
+				pendingCredential.Role = credential.RoleAdmin
+
+				_, err := credential.Create(*db, pendingCredential)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the checkpoint does not exist", func() {
+			Specify("the error returned", func() {
+				pendingCredential.CheckpointIDs = []uuid.UUID{uuid.Must(uuid.NewV4())}
+
+				_, err := credential.Create(*db, pendingCredential)
+				Expect(errors.As(err, &checkpoint.NotFound{})).To(BeTrue())
+			})
+		})
+
+		When("the role is unknown", func() {
+			Specify("the error returned", func() {
+				pendingCredential.Role = "root"
+
+				_, err := credential.Create(*db, pendingCredential)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	Describe("Revoking the credential", func() {
+		var created *credential.Credential
+
+		BeforeEach(func() {
+			pendingCredential := credential.PendingCredential{
+				ID:      uuid.Must(uuid.NewV4()),
+				Name:    "Viewer",
+				Role:    credential.RoleViewer,
+				KeyHash: uuid.Must(uuid.NewV4()).String(),
+			}
+
+			_, err := credential.Create(*db, pendingCredential)
+			Expect(err).To(BeNil())
+
+			created, err = credential.GetCredential(*db, pendingCredential.ID, nil)
+			Expect(err).To(BeNil())
+		})
+
+		When("the credential is revoked", func() {
+			Specify("the revocation is persisted in the database", func() {
+				revokedAt := utils.MakeTimestampInMilliseconds()
+
+				event, err := credential.Revoke(*db, revokedAt, *created)
+				Expect(err).To(BeNil())
+				Expect(event.Version).To(Equal(created.Version + 1))
+
+				fetched, err := credential.GetCredential(*db, created.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(*fetched.RevokedAt).To(Equal(revokedAt))
+			})
+		})
+
+		When("the credential is revoked already", func() {
+			Specify("the error returned", func() {
+				_, err := credential.Revoke(*db, utils.MakeTimestampInMilliseconds(), *created)
+				Expect(err).To(BeNil())
+
+				fetched, err := credential.GetCredential(*db, created.ID, nil)
+				Expect(err).To(BeNil())
+
+				_, err = credential.Revoke(*db, utils.MakeTimestampInMilliseconds(), *fetched)
+				Expect(errors.As(err, &credential.AlreadyRevoked{})).To(BeTrue())
+			})
+		})
+
+		When("the credential version is stale", func() {
+			Specify("the error returned", func() {
+				_, err := credential.Revoke(*db, utils.MakeTimestampInMilliseconds(), *created)
+				Expect(err).To(BeNil())
+
+				_, err = credential.Revoke(*db, utils.MakeTimestampInMilliseconds(), *created)
+				Expect(errors.As(err, &domain_errors.StateConflict{})).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package credential_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCredential(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Credential Suite")
+}
@@ -0,0 +1,17 @@
+package credential
+
+type (
+	// NotFound signifies a credential is not found.
+	NotFound struct{}
+
+	// AlreadyRevoked signifies a credential has been revoked already.
+	AlreadyRevoked struct{}
+)
+
+func (err NotFound) Error() string {
+	return "Credential does not exist"
+}
+
+func (err AlreadyRevoked) Error() string {
+	return "Credential has been revoked already"
+}
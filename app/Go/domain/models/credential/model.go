@@ -0,0 +1,40 @@
+package credential
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+)
+
+// Roles of the API clients.
+const (
+	// RoleAdmin manages the event and may use any endpoint.
+	RoleAdmin = "admin"
+	// RoleTimekeeper submits the times of the checkpoints the credential is bound to.
+	RoleTimekeeper = "timekeeper"
+	// RoleViewer reads the results only.
+	RoleViewer = "viewer"
+)
+
+// Credential represents a persistence model for the API client credential,
+// only the hash of the API key is stored.
+type Credential struct {
+	ID            uuid.UUID      `gorm:"primary_key" json:"id"`
+	Name          string         `gorm:"not null" json:"name"`
+	Role          string         `gorm:"not null" json:"role"`
+	CheckpointIDs pq.StringArray `gorm:"type:text[]" json:"checkpoint_ids"`
+	DeviceID      *uuid.UUID     `gorm:"type:uuid" json:"device_id"`
+	KeyHash       string         `gorm:"not null;unique_index" json:"-"`
+	RevokedAt     *int64         `json:"revoked_at"`
+	CreatedAt     int64          `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version       uint32         `gorm:"not null" json:"version"`
+}
+
+// PendingCredential represents a credential about to issue, the device credentials are issued on enrollment.
+type PendingCredential struct {
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Role          string      `json:"role"`
+	CheckpointIDs []uuid.UUID `json:"checkpoint_ids"`
+	DeviceID      *uuid.UUID  `json:"device_id"`
+	KeyHash       string      `json:"key_hash"`
+}
@@ -0,0 +1,52 @@
+package credential
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+)
+
+// GetCredential fetches a credential.
+func GetCredential(db gorm.DB, pk uuid.UUID, version *uint32) (*Credential, error) {
+	var credential Credential
+
+	err := db.Model(&credential).Where("id = ?", pk).Take(&credential).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Credential not found: %w", NotFound{})
+	} else if version != nil && credential.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading credential: %w", err)
+	}
+
+	return &credential, nil
+}
+
+// GetCredentialByKeyHash fetches a credential by the hash of its API key.
+func GetCredentialByKeyHash(db gorm.DB, keyHash string) (*Credential, error) {
+	var credential Credential
+
+	err := db.Model(&credential).Where("key_hash = ?", keyHash).Take(&credential).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Credential not found: %w", NotFound{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading credential: %w", err)
+	}
+
+	return &credential, nil
+}
+
+// GetCredentials fetches all the credentials, the oldest credential comes first.
+func GetCredentials(db gorm.DB) (*[]Credential, error) {
+	var credentials []Credential
+
+	err := db.Order("created_at asc").Find(&credentials).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &credentials, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading credentials: %w", err)
+	}
+
+	return &credentials, nil
+}
@@ -0,0 +1,86 @@
+package credential_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/credential"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Fetching credentials", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	BeforeEach(func() {
+		db = conn.Begin()
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Fetching a credential by the API key hash", func() {
+		var pendingCredential credential.PendingCredential
+
+		BeforeEach(func() {
+			pendingCredential = credential.PendingCredential{
+				ID:      uuid.Must(uuid.NewV4()),
+				Name:    "Admin",
+				Role:    credential.RoleAdmin,
+				KeyHash: uuid.Must(uuid.NewV4()).String(),
+			}
+
+			_, err := credential.Create(*db, pendingCredential)
+			Expect(err).To(BeNil())
+		})
+
+		When("the hash matches", func() {
+			Specify("the credential returned", func() {
+				fetched, err := credential.GetCredentialByKeyHash(*db, pendingCredential.KeyHash)
+				Expect(err).To(BeNil())
+				Expect(fetched.ID).To(Equal(pendingCredential.ID))
+			})
+		})
+
+		When("the hash does not match", func() {
+			Specify("the error returned", func() {
+				_, err := credential.GetCredentialByKeyHash(*db, "unknown")
+				Expect(errors.As(err, &credential.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Fetching a credential", func() {
+		When("the credential does not exist", func() {
+			Specify("the error returned", func() {
+				_, err := credential.GetCredential(*db, uuid.Must(uuid.NewV4()), nil)
+				Expect(errors.As(err, &credential.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,176 @@
+package device
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/transaction"
+	"strings"
+)
+
+// Register a new device for the checkpoint.
+func Register(db gorm.DB, pendingDevice PendingDevice) (*DeviceRegisteredEvent, error) {
+	pendingDevice.Name = strings.TrimSpace(pendingDevice.Name)
+	pendingDevice.Event = strings.TrimSpace(pendingDevice.Event)
+
+	if err := validation.ValidateStruct(
+		&pendingDevice,
+		validation.Field(&pendingDevice.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingDevice.Name, validation.Required),
+		validation.Field(&pendingDevice.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingDevice.EnrollmentCodeHash, validation.Required),
+		validation.Field(&pendingDevice.EnrollmentExpiresAt, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	newDevice := Device{
+		ID:                  pendingDevice.ID,
+		Name:                pendingDevice.Name,
+		Event:               pendingDevice.Event,
+		CheckpointID:        pendingDevice.CheckpointID,
+		EnrollmentCodeHash:  pendingDevice.EnrollmentCodeHash,
+		EnrollmentExpiresAt: pendingDevice.EnrollmentExpiresAt,
+		Version:             1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		err := tx.Model(&checkpoint.Checkpoint{}).Where(
+			"id = ?",
+			pendingDevice.CheckpointID,
+		).Take(&checkpoint.Checkpoint{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return checkpoint.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		return tx.Create(&Device{
+			ID:                  newDevice.ID,
+			Name:                newDevice.Name,
+			Event:               newDevice.Event,
+			CheckpointID:        newDevice.CheckpointID,
+			EnrollmentCodeHash:  newDevice.EnrollmentCodeHash,
+			EnrollmentExpiresAt: newDevice.EnrollmentExpiresAt,
+			Version:             newDevice.Version,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceRegisteredEvent{
+		DeviceID:            newDevice.ID.String(),
+		Name:                newDevice.Name,
+		Event:               newDevice.Event,
+		CheckpointID:        newDevice.CheckpointID.String(),
+		EnrollmentExpiresAt: newDevice.EnrollmentExpiresAt,
+		Version:             newDevice.Version,
+	}, nil
+}
+
+// Enroll the device using up its enrollment code and issue the timekeeper credential
+// bound to the device checkpoint, the device authenticates with the API key of the given hash.
+func Enroll(db gorm.DB, enrolledAt int64, keyHash string, device Device) (*DeviceEnrolledEvent, error) {
+	if device.RevokedAt != nil {
+		return nil, AlreadyRevoked{}
+	} else if device.EnrolledAt != nil {
+		return nil, AlreadyEnrolled{}
+	} else if device.EnrollmentExpiresAt <= enrolledAt {
+		return nil, EnrollmentExpired{}
+	}
+
+	credentialID := uuid.Must(uuid.NewV4())
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		// Use up the code first so that the concurrent enrollments can't get the second credential.
+		result := tx.Model(&Device{}).
+			Where("id = ? AND version = ? AND enrolled_at IS NULL",
+				device.ID,
+				device.Version,
+			).Updates(map[string]interface{}{
+			"enrolled_at":   enrolledAt,
+			"credential_id": credentialID,
+			"version":       device.Version + 1,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("Error enrolling the device: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		_, err := credential.Create(tx, credential.PendingCredential{
+			ID:            credentialID,
+			Name:          device.Name,
+			Role:          credential.RoleTimekeeper,
+			CheckpointIDs: []uuid.UUID{device.CheckpointID},
+			DeviceID:      &device.ID,
+			KeyHash:       keyHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceEnrolledEvent{
+		DeviceID:     device.ID.String(),
+		CredentialID: credentialID.String(),
+		EnrolledAt:   enrolledAt,
+		Version:      device.Version + 1,
+	}, nil
+}
+
+// Revoke the device together with its credential, the unused enrollment code can't be used any more.
+func Revoke(db gorm.DB, revokedAt int64, device Device) (*DeviceRevokedEvent, error) {
+	if device.RevokedAt != nil {
+		return nil, AlreadyRevoked{}
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Device{}).
+			Where("id = ? AND version = ? AND revoked_at IS NULL",
+				device.ID,
+				device.Version,
+			).Updates(map[string]interface{}{"revoked_at": revokedAt, "version": device.Version + 1})
+		if result.Error != nil {
+			return fmt.Errorf("Error revoking the device: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		if device.CredentialID != nil {
+			deviceCredential, err := credential.GetCredential(tx, *device.CredentialID, nil)
+			if err != nil {
+				return err
+			}
+
+			if deviceCredential.RevokedAt == nil {
+				_, err = credential.Revoke(tx, revokedAt, *deviceCredential)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceRevokedEvent{
+		DeviceID:  device.ID.String(),
+		RevokedAt: revokedAt,
+		Version:   device.Version + 1,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package device_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/device"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing devices", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var pendingCheckpoint checkpoint.PendingCheckpoint
+	var pendingDevice device.PendingDevice
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingCheckpoint = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "10 km",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+
+		pendingDevice = device.PendingDevice{
+			ID:                  uuid.Must(uuid.NewV4()),
+			Name:                "Laptop 1",
+			Event:               "City Marathon",
+			CheckpointID:        pendingCheckpoint.ID,
+			EnrollmentCodeHash:  uuid.Must(uuid.NewV4()).String(),
+			EnrollmentExpiresAt: utils.MakeTimestampInMilliseconds() + 60000,
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Registering a new device", func() {
+		When("the device is registered", func() {
+			Specify("the returned event", func() {
+				event, err := device.Register(*db, pendingDevice)
+				Expect(err).To(BeNil())
+
+				Expect(event).To(Equal(&device.DeviceRegisteredEvent{
+					DeviceID:            pendingDevice.ID.String(),
+					Name:                pendingDevice.Name,
+					Event:               pendingDevice.Event,
+					CheckpointID:        pendingCheckpoint.ID.String(),
+					EnrollmentExpiresAt: pendingDevice.EnrollmentExpiresAt,
+					Version:             1,
+				}))
+			})
+		})
+
+		When("the checkpoint does not exist", func() {
+			Specify("the error returned", func() {
+				pendingDevice.CheckpointID = uuid.Must(uuid.NewV4())
+
+				_, err := device.Register(*db, pendingDevice)
+				Expect(errors.As(err, &checkpoint.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Enrolling the device", func() {
+		var registered *device.Device
+
+		BeforeEach(func() {
+			_, err := device.Register(*db, pendingDevice)
+			Expect(err).To(BeNil())
+
+			registered, err = device.GetDevice(*db, pendingDevice.ID, nil)
+			Expect(err).To(BeNil())
+		})
+
+		When("the device is enrolled", func() {
+			Specify("the timekeeper credential bound to the checkpoint is issued", func() {
+				keyHash := uuid.Must(uuid.NewV4()).String()
+
+				event, err := device.Enroll(*db, utils.MakeTimestampInMilliseconds(), keyHash, *registered)
+				Expect(err).To(BeNil())
+
+				issued, err := credential.GetCredentialByKeyHash(*db, keyHash)
+				Expect(err).To(BeNil())
+				Expect(issued.ID.String()).To(Equal(event.CredentialID))
+				Expect(issued.Role).To(Equal(credential.RoleTimekeeper))
+				Expect([]string(issued.CheckpointIDs)).To(Equal([]string{pendingCheckpoint.ID.String()}))
+				Expect(*issued.DeviceID).To(Equal(pendingDevice.ID))
+
+				enrolled, err := device.GetDevice(*db, pendingDevice.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(enrolled.EnrolledAt).ToNot(BeNil())
+				Expect(*enrolled.CredentialID).To(Equal(issued.ID))
+			})
+		})
+
+		When("the enrollment code is used again", func() {
+			Specify("the error returned", func() {
+				_, err := device.Enroll(*db, utils.MakeTimestampInMilliseconds(), uuid.Must(uuid.NewV4()).String(), *registered)
+				Expect(err).To(BeNil())
+
+				enrolled, err := device.GetDevice(*db, pendingDevice.ID, nil)
+				Expect(err).To(BeNil())
+
+				_, err = device.Enroll(*db, utils.MakeTimestampInMilliseconds(), uuid.Must(uuid.NewV4()).String(), *enrolled)
+				Expect(errors.As(err, &device.AlreadyEnrolled{})).To(BeTrue())
+
+				_, err = device.Enroll(*db, utils.MakeTimestampInMilliseconds(), uuid.Must(uuid.NewV4()).String(), *registered)
+				Expect(errors.As(err, &domain_errors.StateConflict{})).To(BeTrue())
+			})
+		})
+
+		When("the enrollment code has expired", func() {
+			Specify("the error returned", func() {
+				_, err := device.Enroll(*db, registered.EnrollmentExpiresAt, uuid.Must(uuid.NewV4()).String(), *registered)
+				Expect(errors.As(err, &device.EnrollmentExpired{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Revoking the device", func() {
+		When("the enrolled device is revoked", func() {
+			Specify("the device credential is revoked as well", func() {
+				_, err := device.Register(*db, pendingDevice)
+				Expect(err).To(BeNil())
+
+				registered, err := device.GetDevice(*db, pendingDevice.ID, nil)
+				Expect(err).To(BeNil())
+
+				event, err := device.Enroll(*db, utils.MakeTimestampInMilliseconds(), uuid.Must(uuid.NewV4()).String(), *registered)
+				Expect(err).To(BeNil())
+
+				enrolled, err := device.GetDevice(*db, pendingDevice.ID, nil)
+				Expect(err).To(BeNil())
+
+				_, err = device.Revoke(*db, utils.MakeTimestampInMilliseconds(), *enrolled)
+				Expect(err).To(BeNil())
+
+				issued, err := credential.GetCredential(*db, uuid.Must(uuid.FromString(event.CredentialID)), nil)
+				Expect(err).To(BeNil())
+				Expect(issued.RevokedAt).ToNot(BeNil())
+
+				revoked, err := device.GetDevice(*db, pendingDevice.ID, nil)
+				Expect(err).To(BeNil())
+
+				_, err = device.Revoke(*db, utils.MakeTimestampInMilliseconds(), *revoked)
+				Expect(errors.As(err, &device.AlreadyRevoked{})).To(BeTrue())
+			})
+		})
+	})
+})
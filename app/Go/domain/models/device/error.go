@@ -0,0 +1,31 @@
+package device
+
+type (
+	// NotFound signifies a device is not found.
+	NotFound struct{}
+
+	// AlreadyEnrolled signifies the enrollment code of a device has been used already.
+	AlreadyEnrolled struct{}
+
+	// EnrollmentExpired signifies the enrollment code of a device has expired.
+	EnrollmentExpired struct{}
+
+	// AlreadyRevoked signifies a device has been revoked already.
+	AlreadyRevoked struct{}
+)
+
+func (err NotFound) Error() string {
+	return "Device does not exist"
+}
+
+func (err AlreadyEnrolled) Error() string {
+	return "Device has been enrolled already"
+}
+
+func (err EnrollmentExpired) Error() string {
+	return "Device enrollment code has expired"
+}
+
+func (err AlreadyRevoked) Error() string {
+	return "Device has been revoked already"
+}
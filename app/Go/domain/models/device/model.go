@@ -0,0 +1,35 @@
+package device
+
+import (
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+// EnrollmentCodeTTL is the time the device may be enrolled in after the registration.
+const EnrollmentCodeTTL = 24 * time.Hour
+
+// Device represents a persistence model for the timekeeper device registered for the event checkpoint,
+// the device receives its credential by enrolling with the one-time code, only the hash of the code is stored.
+type Device struct {
+	ID                  uuid.UUID  `gorm:"primary_key" json:"id"`
+	Name                string     `gorm:"not null" json:"name"`
+	Event               string     `gorm:"not null;default:''" json:"event"`
+	CheckpointID        uuid.UUID  `gorm:"not null" json:"checkpoint_id"`
+	EnrollmentCodeHash  string     `gorm:"not null;unique_index" json:"-"`
+	EnrollmentExpiresAt int64      `gorm:"not null" json:"enrollment_expires_at"`
+	EnrolledAt          *int64     `json:"enrolled_at"`
+	CredentialID        *uuid.UUID `gorm:"type:uuid" json:"credential_id"`
+	RevokedAt           *int64     `json:"revoked_at"`
+	CreatedAt           int64      `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version             uint32     `gorm:"not null" json:"version"`
+}
+
+// PendingDevice represents a device about to register.
+type PendingDevice struct {
+	ID                  uuid.UUID `json:"id"`
+	Name                string    `json:"name"`
+	Event               string    `json:"event"`
+	CheckpointID        uuid.UUID `json:"checkpoint_id"`
+	EnrollmentCodeHash  string    `json:"enrollment_code_hash"`
+	EnrollmentExpiresAt int64     `json:"enrollment_expires_at"`
+}
@@ -0,0 +1,50 @@
+package device
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+)
+
+// GetDevice fetches a device.
+func GetDevice(db gorm.DB, pk uuid.UUID, version *uint32) (*Device, error) {
+	var device Device
+
+	err := db.Model(&device).Where("id = ?", pk).Take(&device).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Device not found: %w", NotFound{})
+	} else if version != nil && device.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading device: %w", err)
+	}
+
+	return &device, nil
+}
+
+// GetDeviceByEnrollmentCodeHash fetches a device by the hash of its enrollment code.
+func GetDeviceByEnrollmentCodeHash(db gorm.DB, codeHash string) (*Device, error) {
+	var device Device
+
+	err := db.Model(&device).Where("enrollment_code_hash = ?", codeHash).Take(&device).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Device not found: %w", NotFound{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading device: %w", err)
+	}
+
+	return &device, nil
+}
+
+// GetDevices fetches all the devices, the oldest device comes first.
+func GetDevices(db gorm.DB) (*[]Device, error) {
+	var devices []Device
+
+	err := db.Order("created_at asc").Find(&devices).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading devices: %w", err)
+	}
+
+	return &devices, nil
+}
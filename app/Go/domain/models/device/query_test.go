@@ -0,0 +1,96 @@
+package device_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/device"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Fetching devices", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	BeforeEach(func() {
+		db = conn.Begin()
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Fetching a device by the enrollment code hash", func() {
+		var pendingDevice device.PendingDevice
+
+		BeforeEach(func() {
+			pendingCheckpoint := checkpoint.PendingCheckpoint{
+				ID:   uuid.Must(uuid.NewV4()),
+				Name: "10 km",
+			}
+
+			_, err := checkpoint.Create(*db, pendingCheckpoint)
+			Expect(err).To(BeNil())
+
+			pendingDevice = device.PendingDevice{
+				ID:                  uuid.Must(uuid.NewV4()),
+				Name:                "Laptop 1",
+				CheckpointID:        pendingCheckpoint.ID,
+				EnrollmentCodeHash:  uuid.Must(uuid.NewV4()).String(),
+				EnrollmentExpiresAt: utils.MakeTimestampInMilliseconds() + 60000,
+			}
+
+			_, err = device.Register(*db, pendingDevice)
+			Expect(err).To(BeNil())
+		})
+
+		When("the hash matches", func() {
+			Specify("the device returned", func() {
+				fetched, err := device.GetDeviceByEnrollmentCodeHash(*db, pendingDevice.EnrollmentCodeHash)
+				Expect(err).To(BeNil())
+				Expect(fetched.ID).To(Equal(pendingDevice.ID))
+			})
+		})
+
+		When("the hash does not match", func() {
+			Specify("the error returned", func() {
+				_, err := device.GetDeviceByEnrollmentCodeHash(*db, "unknown")
+				Expect(errors.As(err, &device.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Fetching a device", func() {
+		When("the device does not exist", func() {
+			Specify("the error returned", func() {
+				_, err := device.GetDevice(*db, uuid.Must(uuid.NewV4()), nil)
+				Expect(errors.As(err, &device.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+})
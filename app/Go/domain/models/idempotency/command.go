@@ -0,0 +1,133 @@
+package idempotency
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/transaction"
+)
+
+// Maximum length of the key sent by the client.
+const MaxKeyLength = 255
+
+// Reserve the key for the request in progress, the concurrent request with the same key gets AlreadyExists.
+func Reserve(db gorm.DB, pendingKey PendingKey) (*KeyReservedEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingKey,
+		validation.Field(&pendingKey.Key, validation.Required, validation.Length(1, MaxKeyLength)),
+		validation.Field(&pendingKey.RequestHash, validation.Required),
+		validation.Field(&pendingKey.ExpiresAt, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	newKey := Key{
+		Scope:       pendingKey.Scope,
+		Key:         pendingKey.Key,
+		RequestHash: pendingKey.RequestHash,
+		ExpiresAt:   pendingKey.ExpiresAt,
+		Version:     1,
+	}
+
+	// The primary key rejects the key reserved by the concurrent request in between.
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		if err := tx.Create(&Key{
+			Scope:       newKey.Scope,
+			Key:         newKey.Key,
+			RequestHash: newKey.RequestHash,
+			ExpiresAt:   newKey.ExpiresAt,
+			Version:     newKey.Version,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyReservedEvent{
+		Scope:     newKey.Scope,
+		Key:       newKey.Key,
+		ExpiresAt: newKey.ExpiresAt,
+		Version:   newKey.Version,
+	}, nil
+}
+
+// Complete stores the response of the request so that the repeated requests get it replayed.
+func Complete(db gorm.DB, statusCode int, contentType, eTag string, response []byte, key Key) (*KeyCompletedEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Key{}).
+			Where("scope = ? AND key = ? AND version = ?",
+				key.Scope,
+				key.Key,
+				key.Version,
+			).Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"e_tag":        eTag,
+			"response":     response,
+			"version":      key.Version + 1,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("Error completing the idempotency key: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyCompletedEvent{
+		Scope:      key.Scope,
+		Key:        key.Key,
+		StatusCode: int32(statusCode),
+		Version:    key.Version + 1,
+	}, nil
+}
+
+// Release the key so that it may be used again, e.g. when the request has failed or the key has expired.
+func Release(db gorm.DB, key Key) (*KeyReleasedEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Where("scope = ? AND key = ? AND version = ?", key.Scope, key.Key, key.Version).Delete(&Key{})
+		if result.Error != nil {
+			return fmt.Errorf("Error releasing the idempotency key: %w", result.Error)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyReleasedEvent{
+		Scope: key.Scope,
+		Key:   key.Key,
+	}, nil
+}
+
+// DeleteExpired removes the keys expired at the given time in milliseconds, the number of removed keys is returned.
+func DeleteExpired(db gorm.DB, now int64) (int64, error) {
+	var deleted int64
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Where("expires_at <= ?", now).Delete(&Key{})
+		if result.Error != nil {
+			return fmt.Errorf("Error deleting expired idempotency keys: %w", result.Error)
+		}
+
+		deleted = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,130 @@
+package idempotency_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/idempotency"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing idempotency keys", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var pendingKey idempotency.PendingKey
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingKey = idempotency.PendingKey{
+			Scope:       uuid.Must(uuid.NewV4()).String(),
+			Key:         uuid.Must(uuid.NewV4()).String(),
+			RequestHash: "hash",
+			ExpiresAt:   utils.MakeTimestampInMilliseconds() + 60000,
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Reserving the key", func() {
+		When("the key is reserved", func() {
+			Specify("the key is in progress", func() {
+				_, err := idempotency.Reserve(*db, pendingKey)
+				Expect(err).To(BeNil())
+
+				fetched, err := idempotency.GetKey(*db, pendingKey.Scope, pendingKey.Key)
+				Expect(err).To(BeNil())
+				Expect(fetched.Completed()).To(BeFalse())
+				Expect(fetched.RequestHash).To(Equal(pendingKey.RequestHash))
+			})
+		})
+
+		When("the key is reserved already", func() {
+			Specify("the error returned", func() {
+				_, err := idempotency.Reserve(*db, pendingKey)
+				Expect(err).To(BeNil())
+
+				_, err = idempotency.Reserve(*db, pendingKey)
+				Expect(errors.As(err, &idempotency.AlreadyExists{})).To(BeTrue())
+			})
+		})
+
+		When("the same key is reserved in another scope", func() {
+			Specify("the key is reserved", func() {
+				_, err := idempotency.Reserve(*db, pendingKey)
+				Expect(err).To(BeNil())
+
+				pendingKey.Scope = uuid.Must(uuid.NewV4()).String()
+				_, err = idempotency.Reserve(*db, pendingKey)
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Completing the key", func() {
+		When("the response is stored", func() {
+			Specify("the response is persisted in the database", func() {
+				event, err := idempotency.Reserve(*db, pendingKey)
+				Expect(err).To(BeNil())
+
+				_, err = idempotency.Complete(*db, 200, "application/json", `"1"`, []byte(`{"id":"1"}`), idempotency.Key{
+					Scope:   event.Scope,
+					Key:     event.Key,
+					Version: event.Version,
+				})
+				Expect(err).To(BeNil())
+
+				fetched, err := idempotency.GetKey(*db, pendingKey.Scope, pendingKey.Key)
+				Expect(err).To(BeNil())
+				Expect(fetched.Completed()).To(BeTrue())
+				Expect(fetched.StatusCode).To(Equal(200))
+				Expect(fetched.ContentType).To(Equal("application/json"))
+				Expect(fetched.ETag).To(Equal(`"1"`))
+				Expect(fetched.Response).To(Equal([]byte(`{"id":"1"}`)))
+			})
+		})
+	})
+
+	Describe("Deleting expired keys", func() {
+		When("the key has expired", func() {
+			Specify("the key is deleted", func() {
+				_, err := idempotency.Reserve(*db, pendingKey)
+				Expect(err).To(BeNil())
+
+				deleted, err := idempotency.DeleteExpired(*db, pendingKey.ExpiresAt)
+				Expect(err).To(BeNil())
+				Expect(deleted).To(BeNumerically(">=", 1))
+
+				_, err = idempotency.GetKey(*db, pendingKey.Scope, pendingKey.Key)
+				Expect(errors.As(err, &idempotency.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package idempotency
+
+type (
+	// NotFound signifies a key is not found.
+	NotFound struct{}
+
+	// AlreadyExists signifies a key has been reserved already.
+	AlreadyExists struct{}
+
+	// RequestMismatch signifies a key has been used for another request.
+	RequestMismatch struct{}
+
+	// InProgress signifies the request of a key has not completed yet.
+	InProgress struct{}
+)
+
+func (err NotFound) Error() string {
+	return "Idempotency key does not exist"
+}
+
+func (err AlreadyExists) Error() string {
+	return "Idempotency key already exists"
+}
+
+func (err RequestMismatch) Error() string {
+	return "Idempotency key has been used for another request"
+}
+
+func (err InProgress) Error() string {
+	return "Request with the same idempotency key is in progress"
+}
@@ -0,0 +1,50 @@
+package idempotency
+
+// Key represents a persistence model for the Idempotency-Key of the mutating request, the key is scoped
+// by the credential which sent it, the response is stored once the request has completed.
+type Key struct {
+	Scope       string `gorm:"primary_key" json:"scope"`
+	Key         string `gorm:"primary_key" json:"key"`
+	RequestHash string `gorm:"not null" json:"request_hash"`
+	StatusCode  int    `gorm:"not null;default:0" json:"status_code"`
+	ContentType string `gorm:"not null;default:''" json:"content_type"`
+	ETag        string `gorm:"not null;default:''" json:"etag"`
+	Response    []byte `json:"response"`
+	ExpiresAt   int64  `gorm:"not null" json:"expires_at"`
+	CreatedAt   int64  `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version     uint32 `gorm:"not null" json:"version"`
+}
+
+// TableName keeps the table name telling apart from the other keys.
+func (Key) TableName() string {
+	return "idempotency_keys"
+}
+
+// PendingKey represents a key about to reserve for the request in progress.
+type PendingKey struct {
+	Scope       string `json:"scope"`
+	Key         string `json:"key"`
+	RequestHash string `json:"request_hash"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+// Completed reports whether the response of the request has been stored.
+func (k Key) Completed() bool {
+	return k.StatusCode != 0
+}
+
+// Expired reports whether the key may be reused at the given time in milliseconds.
+func (k Key) Expired(now int64) bool {
+	return k.ExpiresAt <= now
+}
+
+// Replayable checks the stored response may be replayed to the request of the given hash.
+func (k Key) Replayable(requestHash string) error {
+	if k.RequestHash != requestHash {
+		return RequestMismatch{}
+	} else if !k.Completed() {
+		return InProgress{}
+	}
+
+	return nil
+}
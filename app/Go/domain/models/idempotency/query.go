@@ -0,0 +1,20 @@
+package idempotency
+
+import (
+	"fmt"
+	"github.com/jinzhu/gorm"
+)
+
+// GetKey fetches the key sent by the client of the scope.
+func GetKey(db gorm.DB, scope, key string) (*Key, error) {
+	var found Key
+
+	err := db.Model(&found).Where("scope = ? AND key = ?", scope, key).Take(&found).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Idempotency key not found: %w", NotFound{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading idempotency key: %w", err)
+	}
+
+	return &found, nil
+}
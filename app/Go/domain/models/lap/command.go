@@ -0,0 +1,132 @@
+package lap
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/transaction"
+)
+
+// Record the passing of the race checkpoint as the next lap of the started result,
+// the result is finished when the lap completes the race or the race clock has run out.
+func Record(db gorm.DB, pendingLap PendingLap) (*LapRecordedEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingLap,
+		validation.Field(&pendingLap.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingLap.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingLap.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingLap.Time, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	var event *LapRecordedEvent
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		raceFetched := race.Race{}
+		err := tx.Where("checkpoint_id = ?", pendingLap.CheckpointID).Take(&raceFetched).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return race.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		resultFetched := result.Result{}
+		err = tx.Where(
+			"checkpoint_id = ? AND sportsmen_id = ?",
+			pendingLap.CheckpointID,
+			pendingLap.SportsmenID,
+		).Take(&resultFetched).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return result.NotFound{}
+		} else if err != nil {
+			return err
+		} else if resultFetched.TimeFinish != nil {
+			return result.AlreadyFinished{}
+		}
+
+		// The first lap runs from the start.
+		number := uint32(1)
+		previous := resultFetched.TimeStart
+
+		lastLap := Lap{}
+		err = tx.Where("result_id = ?", resultFetched.ID).Order("number desc").Take(&lastLap).Error
+		if err == nil {
+			number = lastLap.Number + 1
+			previous = lastLap.Time
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		lapTime := pendingLap.Time - previous
+		if lapTime <= 0 || lapTime < raceFetched.MinLapTime {
+			return TooShort{}
+		}
+
+		distance, partial := raceFetched.Credit(previous, pendingLap.Time)
+
+		newLap := Lap{
+			ID:          pendingLap.ID,
+			RaceID:      raceFetched.ID,
+			ResultID:    resultFetched.ID,
+			SportsmenID: resultFetched.SportsmenID,
+			Number:      number,
+			Time:        pendingLap.Time,
+			LapTime:     lapTime,
+			Distance:    distance,
+			Partial:     partial,
+			DeviceID:    pendingLap.DeviceID,
+			Version:     1,
+		}
+
+		// The unique index rejects the concurrent passing counted as the same lap.
+		if err := tx.Create(&newLap).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		event = &LapRecordedEvent{
+			LapID:       newLap.ID.String(),
+			RaceID:      newLap.RaceID.String(),
+			ResultID:    newLap.ResultID.String(),
+			SportsmenID: newLap.SportsmenID.String(),
+			Number:      newLap.Number,
+			Time:        newLap.Time,
+			LapTime:     newLap.LapTime,
+			Finished:    raceFetched.IsFinished(newLap.Number, newLap.Time, resultFetched.TimeStart),
+			Distance:    newLap.Distance,
+			Partial:     newLap.Partial,
+			Version:     newLap.Version,
+		}
+
+		if newLap.DeviceID != nil {
+			event.DeviceID = newLap.DeviceID.String()
+		}
+
+		if err := outbox.AppendEvent(tx, TopicRecorded, newLap.ID, event); err != nil {
+			return err
+		}
+
+		if !event.Finished {
+			return nil
+		}
+
+		_, err = result.AddFinishTimeByDevice(tx, newLap.Time, newLap.DeviceID, result.UnfinishedResult{
+			ID:           resultFetched.ID,
+			SportsmenID:  resultFetched.SportsmenID,
+			CheckpointID: resultFetched.CheckpointID,
+			TimeStart:    resultFetched.TimeStart,
+			Version:      resultFetched.Version,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
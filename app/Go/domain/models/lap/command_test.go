@@ -0,0 +1,314 @@
+package lap_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Recording laps", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	const (
+		timeStart  = int64(1600000000000)
+		minute     = int64(60 * 1000)
+		minLapTime = 2 * minute
+	)
+
+	var pendingRace race.PendingRace
+	var resultID uuid.UUID
+
+	// start creates the sportsmen and starts it at the race checkpoint.
+	start := func(startNumber uint32, time int64) (uuid.UUID, uuid.UUID) {
+		pendingSportsmen := sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			StartNumber: startNumber,
+		}
+
+		_, err := sportsmen.Create(*db, pendingSportsmen)
+		Expect(err).To(BeNil())
+
+		pendingResult := result.PendingResult{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: pendingRace.CheckpointID,
+			SportsmenID:  pendingSportsmen.ID,
+			TimeStart:    time,
+		}
+
+		_, err = result.Create(*db, pendingResult)
+		Expect(err).To(BeNil())
+
+		return pendingSportsmen.ID, pendingResult.ID
+	}
+
+	// pass records the passing of the race checkpoint.
+	pass := func(sportsmenID uuid.UUID, time int64) (*lap.LapRecordedEvent, error) {
+		return lap.Record(*db, lap.PendingLap{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: pendingRace.CheckpointID,
+			SportsmenID:  sportsmenID,
+			Time:         time,
+		})
+	}
+
+	var sportsmenID uuid.UUID
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingCheckpoint := checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Start/finish line",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+
+		pendingRace = race.PendingRace{
+			ID:           uuid.Must(uuid.NewV4()),
+			Name:         "Criterium",
+			CheckpointID: pendingCheckpoint.ID,
+			Format:       race.FormatLaps,
+			TargetLaps:   3,
+			TimeLimit:    60 * minute,
+			MinLapTime:   minLapTime,
+		}
+
+		_, err = race.Create(*db, pendingRace)
+		Expect(err).To(BeNil())
+
+		sportsmenID, resultID = start(101, timeStart)
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Passing the race checkpoint", func() {
+		When("the passings are recorded", func() {
+			Specify("the laps are numbered and timed from the previous passing", func() {
+				event, err := pass(sportsmenID, timeStart+5*minute)
+				Expect(err).To(BeNil())
+				Expect(event.Number).To(Equal(uint32(1)))
+				Expect(event.LapTime).To(Equal(5 * minute))
+				Expect(event.Finished).To(BeFalse())
+
+				event, err = pass(sportsmenID, timeStart+9*minute)
+				Expect(err).To(BeNil())
+				Expect(event.Number).To(Equal(uint32(2)))
+				Expect(event.LapTime).To(Equal(4 * minute))
+
+				laps, err := lap.GetLaps(*db, resultID)
+				Expect(err).To(BeNil())
+				Expect(*laps).To(HaveLen(2))
+				Expect((*laps)[0].RaceID).To(Equal(pendingRace.ID))
+				Expect((*laps)[1].Time).To(Equal(timeStart + 9*minute))
+			})
+		})
+
+		When("the passing comes sooner than the minimum lap time", func() {
+			Specify("the false read is rejected and not counted", func() {
+				_, err := pass(sportsmenID, timeStart+5*minute)
+				Expect(err).To(BeNil())
+
+				_, err = pass(sportsmenID, timeStart+5*minute+minLapTime-1)
+				Expect(errors.As(err, &lap.TooShort{})).To(BeTrue())
+
+				event, err := pass(sportsmenID, timeStart+5*minute+minLapTime)
+				Expect(err).To(BeNil())
+				Expect(event.Number).To(Equal(uint32(2)))
+			})
+		})
+
+		When("the target lap is completed", func() {
+			Specify("the result is finished and the later passings are rejected", func() {
+				for i := int64(1); i < 3; i++ {
+					_, err := pass(sportsmenID, timeStart+i*5*minute)
+					Expect(err).To(BeNil())
+				}
+
+				event, err := pass(sportsmenID, timeStart+15*minute)
+				Expect(err).To(BeNil())
+				Expect(event.Finished).To(BeTrue())
+
+				resultFetched, err := result.GetResult(*db, resultID, nil)
+				Expect(err).To(BeNil())
+				Expect(*resultFetched.TimeFinish).To(Equal(timeStart + 15*minute))
+
+				_, err = pass(sportsmenID, timeStart+20*minute)
+				Expect(errors.As(err, &result.AlreadyFinished{})).To(BeTrue())
+			})
+		})
+
+		When("the time limit has passed", func() {
+			Specify("the next passing finishes the result", func() {
+				event, err := pass(sportsmenID, timeStart+61*minute)
+				Expect(err).To(BeNil())
+				Expect(event.Number).To(Equal(uint32(1)))
+				Expect(event.Finished).To(BeTrue())
+			})
+		})
+
+		When("the checkpoint is not the race checkpoint", func() {
+			Specify("the error returned", func() {
+				_, err := lap.Record(*db, lap.PendingLap{
+					ID:           uuid.Must(uuid.NewV4()),
+					CheckpointID: uuid.Must(uuid.NewV4()),
+					SportsmenID:  sportsmenID,
+					Time:         timeStart + 5*minute,
+				})
+				Expect(errors.As(err, &race.NotFound{})).To(BeTrue())
+			})
+		})
+
+		When("the sportsmen has not started", func() {
+			Specify("the error returned", func() {
+				_, err := pass(uuid.Must(uuid.NewV4()), timeStart+5*minute)
+				Expect(errors.As(err, &result.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Passing the checkpoint of the timed race", func() {
+		BeforeEach(func() {
+			pendingCheckpoint := checkpoint.PendingCheckpoint{
+				ID:   uuid.Must(uuid.NewV4()),
+				Name: "Pit lane",
+			}
+
+			_, err := checkpoint.Create(*db, pendingCheckpoint)
+			Expect(err).To(BeNil())
+
+			pendingRace = race.PendingRace{
+				ID:           uuid.Must(uuid.NewV4()),
+				Name:         "6 hours",
+				CheckpointID: pendingCheckpoint.ID,
+				Format:       race.FormatTimed,
+				TimeLimit:    30 * minute,
+				MinLapTime:   minLapTime,
+				StartTime:    timeStart,
+				LapDistance:  1000,
+				PartialLap:   race.PartialLapProrate,
+			}
+
+			_, err = race.Create(*db, pendingRace)
+			Expect(err).To(BeNil())
+		})
+
+		Specify("the race clock finishes the results and the partial lap is credited by the rule", func() {
+			first, _ := start(201, timeStart+minute)
+			second, secondResultID := start(202, timeStart)
+
+			for _, passing := range []int64{10, 20} {
+				_, err := pass(first, timeStart+passing*minute)
+				Expect(err).To(BeNil())
+				_, err = pass(second, timeStart+passing*minute)
+				Expect(err).To(BeNil())
+			}
+
+			// The passing right when the race clock runs out completes the whole lap.
+			event, err := pass(first, timeStart+30*minute)
+			Expect(err).To(BeNil())
+			Expect(event.Finished).To(BeTrue())
+			Expect(event.Partial).To(BeFalse())
+			Expect(event.Distance).To(Equal(int64(1000)))
+
+			// Two thirds of the last lap are completed before the race clock runs out.
+			event, err = pass(second, timeStart+35*minute)
+			Expect(err).To(BeNil())
+			Expect(event.Finished).To(BeTrue())
+			Expect(event.Partial).To(BeTrue())
+			Expect(event.Distance).To(Equal(int64(666)))
+
+			resultFetched, err := result.GetResult(*db, secondResultID, nil)
+			Expect(err).To(BeNil())
+			Expect(*resultFetched.TimeFinish).To(Equal(timeStart + 35*minute))
+
+			standings, err := lap.GetStandings(*db, pendingRace.ID, 10)
+			Expect(err).To(BeNil())
+			Expect(*standings).To(HaveLen(2))
+
+			Expect((*standings)[0].SportsmenID).To(Equal(first))
+			Expect((*standings)[0].Laps).To(Equal(uint32(3)))
+			Expect((*standings)[0].Distance).To(Equal(int64(3000)))
+			Expect((*standings)[1].SportsmenID).To(Equal(second))
+			Expect((*standings)[1].Laps).To(Equal(uint32(2)))
+			Expect((*standings)[1].Distance).To(Equal(int64(2666)))
+			Expect((*standings)[1].RaceTime).To(Equal(20 * minute))
+			Expect(*(*standings)[1].LastPassing).To(Equal(timeStart + 35*minute))
+
+			standing, err := lap.GetStanding(*db, secondResultID)
+			Expect(err).To(BeNil())
+			Expect(standing.Distance).To(Equal(int64(2666)))
+		})
+
+		Specify("the result not raced on the circuit has no standing", func() {
+			standing, err := lap.GetStanding(*db, uuid.Must(uuid.NewV4()))
+			Expect(err).To(BeNil())
+			Expect(standing).To(BeNil())
+		})
+	})
+
+	Describe("Standings of the race", func() {
+		Specify("the most laps come first and the same laps are ranked by the race time", func() {
+			second, _ := start(102, timeStart)
+			third, _ := start(103, timeStart)
+
+			// The first sportsmen completes two laps, the second one lap and the third two slower laps.
+			_, err := pass(sportsmenID, timeStart+5*minute)
+			Expect(err).To(BeNil())
+			_, err = pass(sportsmenID, timeStart+10*minute)
+			Expect(err).To(BeNil())
+			_, err = pass(second, timeStart+6*minute)
+			Expect(err).To(BeNil())
+			_, err = pass(third, timeStart+6*minute)
+			Expect(err).To(BeNil())
+			_, err = pass(third, timeStart+12*minute)
+			Expect(err).To(BeNil())
+
+			standings, err := lap.GetStandings(*db, pendingRace.ID, 10)
+			Expect(err).To(BeNil())
+			Expect(*standings).To(HaveLen(3))
+
+			Expect((*standings)[0].SportsmenID).To(Equal(sportsmenID))
+			Expect((*standings)[0].Laps).To(Equal(uint32(2)))
+			Expect((*standings)[0].RaceTime).To(Equal(10 * minute))
+			Expect((*standings)[1].SportsmenID).To(Equal(third))
+			Expect((*standings)[2].SportsmenID).To(Equal(second))
+			Expect(*(*standings)[2].LastPassing).To(Equal(timeStart + 6*minute))
+		})
+	})
+})
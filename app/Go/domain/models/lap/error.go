@@ -0,0 +1,17 @@
+package lap
+
+type (
+	// AlreadyExists signifies the lap of the result has been recorded already, e.g. by the concurrent passing.
+	AlreadyExists struct{}
+
+	// TooShort signifies the passing comes sooner than the minimum lap time of the race allows, e.g. the false read.
+	TooShort struct{}
+)
+
+func (err AlreadyExists) Error() string {
+	return "Lap already exists"
+}
+
+func (err TooShort) Error() string {
+	return "Lap is shorter than the minimum lap time"
+}
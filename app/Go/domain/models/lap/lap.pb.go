@@ -0,0 +1,795 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lap.proto
+
+package lap
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type LapRecordedEvent struct {
+	LapID                string   `protobuf:"bytes,1,opt,name=LapID,proto3" json:"LapID,omitempty"`
+	RaceID               string   `protobuf:"bytes,2,opt,name=RaceID,proto3" json:"RaceID,omitempty"`
+	ResultID             string   `protobuf:"bytes,3,opt,name=ResultID,proto3" json:"ResultID,omitempty"`
+	SportsmenID          string   `protobuf:"bytes,4,opt,name=SportsmenID,proto3" json:"SportsmenID,omitempty"`
+	Number               uint32   `protobuf:"varint,5,opt,name=Number,proto3" json:"Number,omitempty"`
+	Time                 int64    `protobuf:"varint,6,opt,name=Time,proto3" json:"Time,omitempty"`
+	LapTime              int64    `protobuf:"varint,7,opt,name=LapTime,proto3" json:"LapTime,omitempty"`
+	Finished             bool     `protobuf:"varint,8,opt,name=Finished,proto3" json:"Finished,omitempty"`
+	DeviceID             string   `protobuf:"bytes,9,opt,name=DeviceID,proto3" json:"DeviceID,omitempty"`
+	Distance             int64    `protobuf:"varint,10,opt,name=Distance,proto3" json:"Distance,omitempty"`
+	Partial              bool     `protobuf:"varint,11,opt,name=Partial,proto3" json:"Partial,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LapRecordedEvent) Reset()         { *m = LapRecordedEvent{} }
+func (m *LapRecordedEvent) String() string { return proto.CompactTextString(m) }
+func (*LapRecordedEvent) ProtoMessage()    {}
+func (*LapRecordedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e4e3ea7555916423, []int{0}
+}
+func (m *LapRecordedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LapRecordedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LapRecordedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LapRecordedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LapRecordedEvent.Merge(m, src)
+}
+func (m *LapRecordedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LapRecordedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LapRecordedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LapRecordedEvent proto.InternalMessageInfo
+
+func (m *LapRecordedEvent) GetLapID() string {
+	if m != nil {
+		return m.LapID
+	}
+	return ""
+}
+
+func (m *LapRecordedEvent) GetRaceID() string {
+	if m != nil {
+		return m.RaceID
+	}
+	return ""
+}
+
+func (m *LapRecordedEvent) GetResultID() string {
+	if m != nil {
+		return m.ResultID
+	}
+	return ""
+}
+
+func (m *LapRecordedEvent) GetSportsmenID() string {
+	if m != nil {
+		return m.SportsmenID
+	}
+	return ""
+}
+
+func (m *LapRecordedEvent) GetNumber() uint32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *LapRecordedEvent) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *LapRecordedEvent) GetLapTime() int64 {
+	if m != nil {
+		return m.LapTime
+	}
+	return 0
+}
+
+func (m *LapRecordedEvent) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+func (m *LapRecordedEvent) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
+func (m *LapRecordedEvent) GetDistance() int64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+func (m *LapRecordedEvent) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
+func (m *LapRecordedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*LapRecordedEvent)(nil), "lap.LapRecordedEvent")
+}
+
+func init() { proto.RegisterFile("lap.proto", fileDescriptor_e4e3ea7555916423) }
+
+var fileDescriptor_e4e3ea7555916423 = []byte{
+	// 268 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0xd0, 0xcd, 0x4a, 0xc3, 0x40,
+	0x14, 0x05, 0x60, 0xa7, 0x69, 0xf3, 0x33, 0x45, 0x28, 0x83, 0xc8, 0xd5, 0x45, 0x08, 0xae, 0xb2,
+	0x72, 0xe3, 0x1b, 0x48, 0x14, 0x02, 0x41, 0x64, 0x14, 0xf7, 0xd3, 0xe4, 0x82, 0x03, 0xf9, 0x19,
+	0x26, 0xd3, 0x3e, 0x8b, 0x8f, 0xe4, 0x46, 0xf0, 0x11, 0x24, 0x3e, 0x88, 0x92, 0xdb, 0x26, 0x74,
+	0x97, 0xef, 0x1c, 0xb8, 0x39, 0x0c, 0x8f, 0x6a, 0x65, 0x6e, 0x8d, 0xed, 0x5c, 0x27, 0xbc, 0x5a,
+	0x99, 0x9b, 0xaf, 0x05, 0xdf, 0x14, 0xca, 0x48, 0x2c, 0x3b, 0x5b, 0x61, 0xf5, 0xb0, 0xc7, 0xd6,
+	0x89, 0x0b, 0xbe, 0x2a, 0x94, 0xc9, 0x33, 0x60, 0x09, 0x4b, 0x23, 0x79, 0x80, 0xb8, 0xe4, 0xbe,
+	0x54, 0x25, 0xe6, 0x19, 0x2c, 0x28, 0x3e, 0x4a, 0x5c, 0xf3, 0x50, 0x62, 0xbf, 0xab, 0x5d, 0x9e,
+	0x81, 0x47, 0xcd, 0x6c, 0x91, 0xf0, 0xf5, 0x8b, 0xe9, 0xac, 0xeb, 0x1b, 0x6c, 0xf3, 0x0c, 0x96,
+	0x54, 0x9f, 0x46, 0xe3, 0xd5, 0xa7, 0x5d, 0xb3, 0x45, 0x0b, 0xab, 0x84, 0xa5, 0xe7, 0xf2, 0x28,
+	0x21, 0xf8, 0xf2, 0x55, 0x37, 0x08, 0x7e, 0xc2, 0x52, 0x4f, 0xd2, 0xb7, 0x00, 0x1e, 0x14, 0xca,
+	0x50, 0x1c, 0x50, 0x3c, 0x71, 0xdc, 0xf0, 0xa8, 0x5b, 0xdd, 0xbf, 0x63, 0x05, 0x61, 0xc2, 0xd2,
+	0x50, 0xce, 0x1e, 0xbb, 0x0c, 0xf7, 0x9a, 0x96, 0x47, 0x87, 0x7d, 0x93, 0xa9, 0xd3, 0xbd, 0x53,
+	0x6d, 0x89, 0xc0, 0xe9, 0xe4, 0xec, 0xf1, 0x6f, 0xcf, 0xca, 0x3a, 0xad, 0x6a, 0x58, 0xd3, 0xc9,
+	0x89, 0xe2, 0x8a, 0x07, 0x6f, 0x68, 0x7b, 0xdd, 0xb5, 0xf0, 0xc7, 0x68, 0xf5, 0xe4, 0xfb, 0xcd,
+	0xe7, 0x10, 0xb3, 0xef, 0x21, 0x66, 0x3f, 0x43, 0xcc, 0x3e, 0x7e, 0xe3, 0xb3, 0xad, 0x4f, 0xaf,
+	0x7d, 0xf7, 0x3f, 0x00, 0xae, 0x8e, 0x1e, 0x3c, 0x7a, 0x01, 0x00, 0x00,
+}
+
+func (m *LapRecordedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LapRecordedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LapRecordedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintLap(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.Partial {
+		i--
+		if m.Partial {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.Distance != 0 {
+		i = encodeVarintLap(dAtA, i, uint64(m.Distance))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.DeviceID) > 0 {
+		i -= len(m.DeviceID)
+		copy(dAtA[i:], m.DeviceID)
+		i = encodeVarintLap(dAtA, i, uint64(len(m.DeviceID)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Finished {
+		i--
+		if m.Finished {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.LapTime != 0 {
+		i = encodeVarintLap(dAtA, i, uint64(m.LapTime))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Time != 0 {
+		i = encodeVarintLap(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Number != 0 {
+		i = encodeVarintLap(dAtA, i, uint64(m.Number))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.SportsmenID) > 0 {
+		i -= len(m.SportsmenID)
+		copy(dAtA[i:], m.SportsmenID)
+		i = encodeVarintLap(dAtA, i, uint64(len(m.SportsmenID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ResultID) > 0 {
+		i -= len(m.ResultID)
+		copy(dAtA[i:], m.ResultID)
+		i = encodeVarintLap(dAtA, i, uint64(len(m.ResultID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RaceID) > 0 {
+		i -= len(m.RaceID)
+		copy(dAtA[i:], m.RaceID)
+		i = encodeVarintLap(dAtA, i, uint64(len(m.RaceID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.LapID) > 0 {
+		i -= len(m.LapID)
+		copy(dAtA[i:], m.LapID)
+		i = encodeVarintLap(dAtA, i, uint64(len(m.LapID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintLap(dAtA []byte, offset int, v uint64) int {
+	offset -= sovLap(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *LapRecordedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LapID)
+	if l > 0 {
+		n += 1 + l + sovLap(uint64(l))
+	}
+	l = len(m.RaceID)
+	if l > 0 {
+		n += 1 + l + sovLap(uint64(l))
+	}
+	l = len(m.ResultID)
+	if l > 0 {
+		n += 1 + l + sovLap(uint64(l))
+	}
+	l = len(m.SportsmenID)
+	if l > 0 {
+		n += 1 + l + sovLap(uint64(l))
+	}
+	if m.Number != 0 {
+		n += 1 + sovLap(uint64(m.Number))
+	}
+	if m.Time != 0 {
+		n += 1 + sovLap(uint64(m.Time))
+	}
+	if m.LapTime != 0 {
+		n += 1 + sovLap(uint64(m.LapTime))
+	}
+	if m.Finished {
+		n += 2
+	}
+	l = len(m.DeviceID)
+	if l > 0 {
+		n += 1 + l + sovLap(uint64(l))
+	}
+	if m.Distance != 0 {
+		n += 1 + sovLap(uint64(m.Distance))
+	}
+	if m.Partial {
+		n += 2
+	}
+	if m.Version != 0 {
+		n += 2 + sovLap(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovLap(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozLap(x uint64) (n int) {
+	return sovLap(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *LapRecordedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLap
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LapRecordedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LapRecordedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LapID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LapID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RaceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RaceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResultID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SportsmenID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SportsmenID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
+			}
+			m.Number = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Number |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LapTime", wireType)
+			}
+			m.LapTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LapTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Finished = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLap
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLap
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Distance", wireType)
+			}
+			m.Distance = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Distance |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Partial", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Partial = bool(v != 0)
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLap(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLap
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipLap(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowLap
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowLap
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthLap
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupLap
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthLap
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthLap        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowLap          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupLap = fmt.Errorf("proto: unexpected end of group")
+)
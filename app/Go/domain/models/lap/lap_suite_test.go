@@ -0,0 +1,13 @@
+package lap_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLap(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Lap Suite")
+}
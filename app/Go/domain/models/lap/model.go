@@ -0,0 +1,51 @@
+package lap
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Outbox topics of the lap events.
+const (
+	TopicRecorded = "lap.recorded"
+)
+
+// Lap represents a persistence model for the passing of the race checkpoint, the lap time is counted
+// from the previous passing or from the start of the result for the first lap. The partial lap completed after
+// the race clock of the timed race has run out is not counted and is credited the distance by the race rule.
+type Lap struct {
+	ID          uuid.UUID  `gorm:"primary_key" json:"id"`
+	RaceID      uuid.UUID  `gorm:"not null;index" json:"race_id"`
+	ResultID    uuid.UUID  `gorm:"not null;unique_index:idx_lap_result_number" json:"result_id"`
+	SportsmenID uuid.UUID  `gorm:"not null" json:"sportsmen_id"`
+	Number      uint32     `gorm:"not null;unique_index:idx_lap_result_number" json:"number"`
+	Time        int64      `gorm:"not null" json:"time"`
+	LapTime     int64      `gorm:"not null" json:"lap_time"`
+	Distance    int64      `gorm:"not null;default:0" json:"distance"`
+	Partial     bool       `gorm:"not null;default:false" json:"partial"`
+	DeviceID    *uuid.UUID `gorm:"type:uuid" json:"device_id"`
+	CreatedAt   int64      `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version     uint32     `gorm:"not null" json:"version"`
+}
+
+// PendingLap represents a passing of the race checkpoint about to record.
+type PendingLap struct {
+	ID           uuid.UUID  `json:"id"`
+	CheckpointID uuid.UUID  `json:"checkpoint_id"`
+	SportsmenID  uuid.UUID  `json:"sportsmen_id"`
+	Time         int64      `json:"time"`
+	DeviceID     *uuid.UUID `json:"device_id"`
+}
+
+// Standing represents the place of the result in the race, the laps and the race time leave the partial lap out.
+// The race time runs from the start to the last counted passing and is zero before the first lap,
+// the distance in meters includes the credit of the partial lap.
+type Standing struct {
+	ResultID    uuid.UUID `json:"result_id"`
+	SportsmenID uuid.UUID `json:"sportsmen_id"`
+	Laps        uint32    `json:"laps"`
+	Distance    int64     `json:"distance"`
+	TimeStart   int64     `json:"time_start"`
+	LastPassing *int64    `json:"last_passing"`
+	TimeFinish  *int64    `json:"time_finish"`
+	RaceTime    int64     `json:"race_time"`
+}
@@ -0,0 +1,70 @@
+package lap
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+// GetLaps fetches the laps of the result, the first lap comes first.
+func GetLaps(db gorm.DB, resultID uuid.UUID) (*[]Lap, error) {
+	var laps []Lap
+
+	err := db.Where("result_id = ?", resultID).Order("number asc").Find(&laps).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading laps: %w", err)
+	}
+
+	return &laps, nil
+}
+
+// GetStandings fetches the standings of the race, the longest distance and the most laps completed come first
+// and the same laps are ranked by the race time.
+func GetStandings(db gorm.DB, raceID uuid.UUID, limit int) (*[]Standing, error) {
+	var standings []Standing
+
+	err := db.Raw(standingsQuery+`
+		WHERE races.id = ?
+		GROUP BY results.id
+		ORDER BY distance DESC, laps DESC, race_time ASC, results.time_start ASC
+		LIMIT ?`,
+		raceID,
+		limit,
+	).Scan(&standings).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &standings, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading standings: %w", err)
+	}
+
+	return &standings, nil
+}
+
+// GetStanding fetches the standing of the result in its race, nil is returned when the result is not raced on the circuit.
+func GetStanding(db gorm.DB, resultID uuid.UUID) (*Standing, error) {
+	var standings []Standing
+
+	err := db.Raw(standingsQuery+`
+		WHERE results.id = ?
+		GROUP BY results.id`,
+		resultID,
+	).Scan(&standings).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Error loading standing: %w", err)
+	} else if len(standings) == 0 {
+		return nil, nil
+	}
+
+	return &standings[0], nil
+}
+
+// standingsQuery selects the progress of the results of the races, the partial laps are credited the distance only.
+const standingsQuery = `
+		SELECT results.id AS result_id, results.sportsmen_id, results.time_start, results.time_finish,
+			COUNT(laps.id) FILTER (WHERE NOT laps.partial) AS laps,
+			COALESCE(SUM(laps.distance), 0) AS distance,
+			MAX(laps.time) AS last_passing,
+			COALESCE(MAX(laps.time) FILTER (WHERE NOT laps.partial) - results.time_start, 0) AS race_time
+		FROM races
+		JOIN results ON results.checkpoint_id = races.checkpoint_id
+		LEFT JOIN laps ON laps.result_id = results.id`
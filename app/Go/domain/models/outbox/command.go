@@ -0,0 +1,184 @@
+package outbox
+
+import (
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/transaction"
+)
+
+// Append the domain event to the outbox, it has to be called in the transaction of the state change
+// so that the event is published if and only if the change has committed.
+func Append(db gorm.DB, pendingMessage PendingMessage) (*MessageAppendedEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingMessage,
+		validation.Field(&pendingMessage.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingMessage.Topic, validation.Required),
+		validation.Field(&pendingMessage.AggregateID, validation.Required, is.UUID),
+		validation.Field(&pendingMessage.Payload, validation.NotNil),
+	); err != nil {
+		return nil, err
+	}
+
+	newMessage := Message{
+		ID:          pendingMessage.ID,
+		Topic:       pendingMessage.Topic,
+		AggregateID: pendingMessage.AggregateID,
+		Payload:     pendingMessage.Payload,
+		Version:     1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		return tx.Create(&newMessage).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageAppendedEvent{
+		MessageID:   newMessage.ID.String(),
+		Topic:       newMessage.Topic,
+		AggregateID: newMessage.AggregateID.String(),
+		Sequence:    newMessage.Sequence,
+		Version:     newMessage.Version,
+	}, nil
+}
+
+// Event is the protobuf encoded domain event.
+type Event interface {
+	Marshal() ([]byte, error)
+}
+
+// AppendEvent appends the domain event of the aggregate with the topic, see Append.
+func AppendEvent(db gorm.DB, topic string, aggregateID uuid.UUID, event Event) error {
+	payload, err := event.Marshal()
+	if err != nil {
+		return fmt.Errorf("Error encoding the %s event: %w", topic, err)
+	}
+
+	_, err = Append(db, PendingMessage{
+		ID:          uuid.Must(uuid.NewV4()),
+		Topic:       topic,
+		AggregateID: aggregateID,
+		Payload:     payload,
+	})
+	return err
+}
+
+// Deliver records the message has been delivered to the subscriber.
+func Deliver(db gorm.DB, subscriber string, deliveredAt int64, message Message) (*MessageDeliveredEvent, error) {
+	if err := validation.Validate(subscriber, validation.Required); err != nil {
+		return nil, validation.Errors{"subscriber": err}
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		if err := tx.Create(&Delivery{
+			MessageID:   message.ID,
+			Subscriber:  subscriber,
+			DeliveredAt: deliveredAt,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyDelivered{}
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageDeliveredEvent{
+		MessageID:   message.ID.String(),
+		Subscriber:  subscriber,
+		DeliveredAt: deliveredAt,
+	}, nil
+}
+
+// MarkPublished marks the message delivered to all the subscribers, it is not dispatched any more.
+func MarkPublished(db gorm.DB, publishedAt int64, message Message) (*MessagePublishedEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Message{}).
+			Where("id = ? AND version = ? AND published_at IS NULL",
+				message.ID,
+				message.Version,
+			).Updates(map[string]interface{}{"published_at": publishedAt, "version": message.Version + 1})
+		if result.Error != nil {
+			return fmt.Errorf("Error marking the outbox message published: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessagePublishedEvent{
+		MessageID:   message.ID.String(),
+		PublishedAt: publishedAt,
+		Version:     message.Version + 1,
+	}, nil
+}
+
+// MarkFailed records the failed dispatch attempt of the message, the message is retried later.
+func MarkFailed(db gorm.DB, reason string, message Message) (*MessageFailedEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Message{}).
+			Where("id = ? AND version = ? AND published_at IS NULL",
+				message.ID,
+				message.Version,
+			).Updates(map[string]interface{}{
+			"attempts":   message.Attempts + 1,
+			"last_error": reason,
+			"version":    message.Version + 1,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("Error marking the outbox message failed: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MessageFailedEvent{
+		MessageID: message.ID.String(),
+		Attempts:  message.Attempts + 1,
+		Error:     reason,
+		Version:   message.Version + 1,
+	}, nil
+}
+
+// DeletePublished removes the messages published before the given time in milliseconds together with
+// their deliveries, the number of removed messages is returned.
+func DeletePublished(db gorm.DB, before int64) (int64, error) {
+	var deleted int64
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		published := tx.Model(&Message{}).Select("id").Where("published_at < ?", before).SubQuery()
+
+		if err := tx.Where("message_id IN ?", published).Delete(&Delivery{}).Error; err != nil {
+			return fmt.Errorf("Error deleting outbox deliveries: %w", err)
+		}
+
+		result := tx.Where("published_at < ?", before).Delete(&Message{})
+		if result.Error != nil {
+			return fmt.Errorf("Error deleting published outbox messages: %w", result.Error)
+		}
+
+		deleted = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,203 @@
+package outbox_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing the outbox", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var topic string
+	var aggregateID uuid.UUID
+
+	// pending fetches the pending messages of the test topic.
+	pending := func() []outbox.Message {
+		messages, err := outbox.GetPendingMessages(*db, 1000, 10)
+		Expect(err).To(BeNil())
+
+		var ofTopic []outbox.Message
+		for _, message := range *messages {
+			if message.Topic == topic {
+				ofTopic = append(ofTopic, message)
+			}
+		}
+
+		return ofTopic
+	}
+
+	appendMessage := func(payload string) outbox.Message {
+		_, err := outbox.Append(*db, outbox.PendingMessage{
+			ID:          uuid.Must(uuid.NewV4()),
+			Topic:       topic,
+			AggregateID: aggregateID,
+			Payload:     []byte(payload),
+		})
+		Expect(err).To(BeNil())
+
+		messages := pending()
+		return messages[len(messages)-1]
+	}
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		topic = "test." + uuid.Must(uuid.NewV4()).String()
+		aggregateID = uuid.Must(uuid.NewV4())
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Appending the messages", func() {
+		When("the messages are appended", func() {
+			Specify("they are pending in the order appended", func() {
+				first := appendMessage("first")
+				second := appendMessage("second")
+
+				messages := pending()
+				Expect(messages).To(HaveLen(2))
+				Expect(messages[0].ID).To(Equal(first.ID))
+				Expect(messages[1].ID).To(Equal(second.ID))
+				Expect(first.Sequence).To(BeNumerically("<", second.Sequence))
+				Expect(string(messages[0].Payload)).To(Equal("first"))
+			})
+		})
+
+		When("the message has no topic", func() {
+			Specify("the validation error returned", func() {
+				_, err := outbox.Append(*db, outbox.PendingMessage{
+					ID:          uuid.Must(uuid.NewV4()),
+					AggregateID: aggregateID,
+					Payload:     []byte("payload"),
+				})
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	Describe("Dispatching the messages", func() {
+		When("the message is delivered to the subscriber", func() {
+			Specify("the subscriber is recorded once", func() {
+				message := appendMessage("payload")
+
+				_, err := outbox.Deliver(*db, "dashboard", utils.MakeTimestampInMilliseconds(), message)
+				Expect(err).To(BeNil())
+
+				subscribers, err := outbox.GetSubscribers(*db, message.ID)
+				Expect(err).To(BeNil())
+				Expect(subscribers).To(ConsistOf("dashboard"))
+
+				_, err = outbox.Deliver(*db, "dashboard", utils.MakeTimestampInMilliseconds(), message)
+				Expect(errors.As(err, &outbox.AlreadyDelivered{})).To(BeTrue())
+			})
+		})
+
+		When("the message is published", func() {
+			Specify("it is not pending any more", func() {
+				message := appendMessage("payload")
+
+				event, err := outbox.MarkPublished(*db, utils.MakeTimestampInMilliseconds(), message)
+				Expect(err).To(BeNil())
+				Expect(event.Version).To(Equal(message.Version + 1))
+				Expect(pending()).To(BeEmpty())
+
+				_, err = outbox.MarkPublished(*db, utils.MakeTimestampInMilliseconds(), message)
+				Expect(errors.As(err, &domain_errors.StateConflict{})).To(BeTrue())
+			})
+		})
+
+		When("the message fails", func() {
+			Specify("the attempt is recorded and the message is pending", func() {
+				message := appendMessage("payload")
+
+				event, err := outbox.MarkFailed(*db, "unavailable", message)
+				Expect(err).To(BeNil())
+				Expect(event.Attempts).To(Equal(uint32(1)))
+
+				messages := pending()
+				Expect(messages).To(HaveLen(1))
+				Expect(messages[0].Attempts).To(Equal(uint32(1)))
+				Expect(messages[0].LastError).To(Equal("unavailable"))
+			})
+		})
+
+		When("the message fails max attempts times", func() {
+			Specify("it is given up", func() {
+				message := appendMessage("payload")
+
+				for attempt := 0; attempt < 10; attempt++ {
+					_, err := outbox.MarkFailed(*db, "unavailable", message)
+					Expect(err).To(BeNil())
+					message = pending()[0]
+				}
+
+				_, err := outbox.MarkFailed(*db, "unavailable", message)
+				Expect(err).To(BeNil())
+				Expect(pending()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Deleting the published messages", func() {
+		When("the message has been published before the time", func() {
+			Specify("it is deleted with its deliveries", func() {
+				message := appendMessage("payload")
+
+				_, err := outbox.Deliver(*db, "dashboard", 1, message)
+				Expect(err).To(BeNil())
+
+				_, err = outbox.MarkPublished(*db, 1, message)
+				Expect(err).To(BeNil())
+
+				deleted, err := outbox.DeletePublished(*db, 2)
+				Expect(err).To(BeNil())
+				Expect(deleted).To(BeNumerically(">=", 1))
+
+				subscribers, err := outbox.GetSubscribers(*db, message.ID)
+				Expect(err).To(BeNil())
+				Expect(subscribers).To(BeEmpty())
+			})
+		})
+
+		When("the message is pending", func() {
+			Specify("it is kept", func() {
+				appendMessage("payload")
+
+				_, err := outbox.DeletePublished(*db, utils.MakeTimestampInMilliseconds())
+				Expect(err).To(BeNil())
+				Expect(pending()).To(HaveLen(1))
+			})
+		})
+	})
+})
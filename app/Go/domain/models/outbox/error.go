@@ -0,0 +1,10 @@
+package outbox
+
+type (
+	// AlreadyDelivered signifies a message has been delivered to the subscriber already.
+	AlreadyDelivered struct{}
+)
+
+func (err AlreadyDelivered) Error() string {
+	return "Message already delivered"
+}
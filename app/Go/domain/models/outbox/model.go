@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Message represents a persistence model for the domain event appended in the same transaction as the state change,
+// the dispatcher publishes it to the subscribers once the transaction has committed.
+type Message struct {
+	ID uuid.UUID `gorm:"primary_key" json:"id"`
+	// Sequence orders the messages the way they have been appended.
+	Sequence    int64     `gorm:"AUTO_INCREMENT;unique_index" json:"sequence"`
+	Topic       string    `gorm:"not null" json:"topic"`
+	AggregateID uuid.UUID `gorm:"not null;index" json:"aggregate_id"`
+	// Payload is the protobuf encoded domain event.
+	Payload     []byte `gorm:"not null" json:"payload"`
+	Attempts    uint32 `gorm:"not null;default:0" json:"attempts"`
+	LastError   string `gorm:"not null;default:''" json:"last_error"`
+	PublishedAt *int64 `gorm:"index" json:"published_at"`
+	CreatedAt   int64  `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version     uint32 `gorm:"not null" json:"version"`
+}
+
+// TableName keeps the table name telling apart from the other messages.
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Delivery represents a persistence model for the message delivered to the subscriber,
+// the message retried for the other subscribers is not delivered to the same subscriber again.
+type Delivery struct {
+	MessageID   uuid.UUID `gorm:"primary_key" json:"message_id"`
+	Subscriber  string    `gorm:"primary_key" json:"subscriber"`
+	DeliveredAt int64     `gorm:"not null" json:"delivered_at"`
+}
+
+// TableName keeps the table name telling apart from the other deliveries.
+func (Delivery) TableName() string {
+	return "outbox_deliveries"
+}
+
+// PendingMessage represents a domain event about to append to the outbox.
+type PendingMessage struct {
+	ID          uuid.UUID `json:"id"`
+	Topic       string    `json:"topic"`
+	AggregateID uuid.UUID `json:"aggregate_id"`
+	Payload     []byte    `json:"payload"`
+}
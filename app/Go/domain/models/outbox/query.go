@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+// GetPendingMessages fetches the oldest messages not published yet which have failed less than maxAttempts times.
+// The messages are locked until the end of the transaction, the ones locked by another dispatcher are skipped.
+func GetPendingMessages(db gorm.DB, limit int, maxAttempts uint32) (*[]Message, error) {
+	var messages []Message
+
+	err := db.Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").
+		Model(&Message{}).
+		Where("published_at IS NULL AND attempts < ?", maxAttempts).
+		Order("sequence").
+		Limit(limit).
+		Find(&messages).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading pending outbox messages: %w", err)
+	}
+
+	return &messages, nil
+}
+
+// GetSubscribers fetches the subscribers the message has been delivered to.
+func GetSubscribers(db gorm.DB, messageID uuid.UUID) ([]string, error) {
+	var subscribers []string
+
+	err := db.Model(&Delivery{}).Where("message_id = ?", messageID).Pluck("subscriber", &subscribers).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading outbox deliveries: %w", err)
+	}
+
+	return subscribers, nil
+}
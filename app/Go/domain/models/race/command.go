@@ -0,0 +1,123 @@
+package race
+
+import (
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/transaction"
+	"strings"
+)
+
+// Create a new race held at the checkpoint.
+func Create(db gorm.DB, pendingRace PendingRace) (*RaceCreatedEvent, error) {
+	pendingRace.Name = strings.TrimSpace(pendingRace.Name)
+
+	// The race without the target laps or the time limit never finishes, the timed race is finished by the race clock only.
+	targetLapsRules := []validation.Rule{validation.By(func(interface{}) error {
+		if pendingRace.TargetLaps == 0 && pendingRace.TimeLimit == 0 {
+			return errors.New("either target laps or time limit is required")
+		}
+		return nil
+	})}
+	timeLimitRules := []validation.Rule{validation.Min(int64(0))}
+	startTimeRules := []validation.Rule{}
+	partialLapRules := []validation.Rule{validation.In("")}
+
+	if pendingRace.Format == FormatTimed {
+		if pendingRace.PartialLap == "" {
+			pendingRace.PartialLap = PartialLapCount
+		}
+
+		targetLapsRules = []validation.Rule{validation.By(func(interface{}) error {
+			if pendingRace.TargetLaps != 0 {
+				return errors.New("must be blank for the timed race")
+			}
+			return nil
+		})}
+		timeLimitRules = []validation.Rule{validation.Required, validation.Min(int64(0))}
+		startTimeRules = []validation.Rule{validation.Required}
+		partialLapRules = []validation.Rule{validation.In(PartialLapCount, PartialLapDiscard, PartialLapProrate)}
+	}
+
+	if err := validation.ValidateStruct(
+		&pendingRace,
+		validation.Field(&pendingRace.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingRace.Name, validation.Required),
+		validation.Field(&pendingRace.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingRace.Format, validation.Required, validation.In(FormatLaps, FormatTimed)),
+		validation.Field(&pendingRace.TargetLaps, targetLapsRules...),
+		validation.Field(&pendingRace.TimeLimit, timeLimitRules...),
+		validation.Field(&pendingRace.MinLapTime, validation.Min(int64(0))),
+		validation.Field(&pendingRace.StartTime, startTimeRules...),
+		validation.Field(&pendingRace.LapDistance, validation.Min(int64(0))),
+		validation.Field(&pendingRace.PartialLap, partialLapRules...),
+	); err != nil {
+		return nil, err
+	}
+
+	newRace := Race{
+		ID:           pendingRace.ID,
+		Name:         pendingRace.Name,
+		CheckpointID: pendingRace.CheckpointID,
+		Format:       pendingRace.Format,
+		TargetLaps:   pendingRace.TargetLaps,
+		TimeLimit:    pendingRace.TimeLimit,
+		MinLapTime:   pendingRace.MinLapTime,
+		StartTime:    pendingRace.StartTime,
+		LapDistance:  pendingRace.LapDistance,
+		PartialLap:   pendingRace.PartialLap,
+		Version:      1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		err := tx.Model(&checkpoint.Checkpoint{}).Where(
+			"id = ?",
+			pendingRace.CheckpointID,
+		).Take(&checkpoint.Checkpoint{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return checkpoint.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		// The unique index rejects the second race at the checkpoint, the passings would not tell the races apart.
+		if err := tx.Create(&Race{
+			ID:           newRace.ID,
+			Name:         newRace.Name,
+			CheckpointID: newRace.CheckpointID,
+			Format:       newRace.Format,
+			TargetLaps:   newRace.TargetLaps,
+			TimeLimit:    newRace.TimeLimit,
+			MinLapTime:   newRace.MinLapTime,
+			StartTime:    newRace.StartTime,
+			LapDistance:  newRace.LapDistance,
+			PartialLap:   newRace.PartialLap,
+			Version:      newRace.Version,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RaceCreatedEvent{
+		RaceID:       newRace.ID.String(),
+		Name:         newRace.Name,
+		CheckpointID: newRace.CheckpointID.String(),
+		Format:       newRace.Format,
+		TargetLaps:   newRace.TargetLaps,
+		TimeLimit:    newRace.TimeLimit,
+		MinLapTime:   newRace.MinLapTime,
+		StartTime:    newRace.StartTime,
+		LapDistance:  newRace.LapDistance,
+		PartialLap:   newRace.PartialLap,
+		Version:      newRace.Version,
+	}, nil
+}
@@ -0,0 +1,243 @@
+package race_test
+
+import (
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/race"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing races", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var pendingRace race.PendingRace
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingCheckpoint := checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Start/finish line",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+
+		pendingRace = race.PendingRace{
+			ID:           uuid.Must(uuid.NewV4()),
+			Name:         "Criterium",
+			CheckpointID: pendingCheckpoint.ID,
+			Format:       race.FormatLaps,
+			TargetLaps:   20,
+			TimeLimit:    60 * 60 * 1000,
+			MinLapTime:   60 * 1000,
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Creating a race", func() {
+		When("the race is created", func() {
+			Specify("the returned event", func() {
+				event, err := race.Create(*db, pendingRace)
+				Expect(err).To(BeNil())
+
+				Expect(event).To(Equal(&race.RaceCreatedEvent{
+					RaceID:       pendingRace.ID.String(),
+					Name:         pendingRace.Name,
+					CheckpointID: pendingRace.CheckpointID.String(),
+					Format:       race.FormatLaps,
+					TargetLaps:   pendingRace.TargetLaps,
+					TimeLimit:    pendingRace.TimeLimit,
+					MinLapTime:   pendingRace.MinLapTime,
+					Version:      1,
+				}))
+			})
+
+			Specify("the race is found by the checkpoint", func() {
+				_, err := race.Create(*db, pendingRace)
+				Expect(err).To(BeNil())
+
+				raceFetched, err := race.GetRaceOfCheckpoint(*db, pendingRace.CheckpointID)
+				Expect(err).To(BeNil())
+				Expect(raceFetched.ID).To(Equal(pendingRace.ID))
+				Expect(raceFetched.TargetLaps).To(Equal(pendingRace.TargetLaps))
+				Expect(raceFetched.MinLapTime).To(Equal(pendingRace.MinLapTime))
+			})
+		})
+
+		When("the race is held at the checkpoint already", func() {
+			Specify("the error returned", func() {
+				_, err := race.Create(*db, pendingRace)
+				Expect(err).To(BeNil())
+
+				pendingRace.ID = uuid.Must(uuid.NewV4())
+				_, err = race.Create(*db, pendingRace)
+				Expect(errors.As(err, &race.AlreadyExists{})).To(BeTrue())
+			})
+		})
+
+		When("neither target laps nor time limit is given", func() {
+			Specify("the error returned", func() {
+				pendingRace.TargetLaps = 0
+				pendingRace.TimeLimit = 0
+
+				_, err := race.Create(*db, pendingRace)
+				Expect(errors.As(err, &validation.Errors{})).To(BeTrue())
+			})
+		})
+
+		When("the checkpoint does not exist", func() {
+			Specify("the error returned", func() {
+				pendingRace.CheckpointID = uuid.Must(uuid.NewV4())
+
+				_, err := race.Create(*db, pendingRace)
+				Expect(errors.As(err, &checkpoint.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Creating a timed race", func() {
+		BeforeEach(func() {
+			pendingRace.Format = race.FormatTimed
+			pendingRace.TargetLaps = 0
+			pendingRace.TimeLimit = 6 * 60 * 60 * 1000
+			pendingRace.StartTime = utils.MakeTimestampInMilliseconds()
+			pendingRace.LapDistance = 4200
+		})
+
+		When("the race is created", func() {
+			Specify("the partial lap rule defaults to count", func() {
+				event, err := race.Create(*db, pendingRace)
+				Expect(err).To(BeNil())
+				Expect(event.PartialLap).To(Equal(race.PartialLapCount))
+				Expect(event.StartTime).To(Equal(pendingRace.StartTime))
+				Expect(event.LapDistance).To(Equal(pendingRace.LapDistance))
+
+				raceFetched, err := race.GetRace(*db, pendingRace.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(raceFetched.Format).To(Equal(race.FormatTimed))
+				Expect(raceFetched.PartialLap).To(Equal(race.PartialLapCount))
+			})
+		})
+
+		When("the race clock is not defined", func() {
+			Specify("the error returned", func() {
+				pendingRace.StartTime = 0
+
+				_, err := race.Create(*db, pendingRace)
+				Expect(errors.As(err, &validation.Errors{})).To(BeTrue())
+			})
+		})
+
+		When("the target laps are given", func() {
+			Specify("the error returned", func() {
+				pendingRace.TargetLaps = 100
+
+				_, err := race.Create(*db, pendingRace)
+				Expect(errors.As(err, &validation.Errors{})).To(BeTrue())
+			})
+		})
+
+		When("the partial lap rule is unknown", func() {
+			Specify("the error returned", func() {
+				pendingRace.PartialLap = "round"
+
+				_, err := race.Create(*db, pendingRace)
+				Expect(errors.As(err, &validation.Errors{})).To(BeTrue())
+			})
+		})
+
+		When("the partial lap rule is given to the race of laps", func() {
+			Specify("the error returned", func() {
+				pendingRace.Format = race.FormatLaps
+				pendingRace.PartialLap = race.PartialLapDiscard
+
+				_, err := race.Create(*db, pendingRace)
+				Expect(errors.As(err, &validation.Errors{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Finishing the race", func() {
+		Specify("the target lap or the time limit finishes, whichever comes first", func() {
+			r := race.Race{TargetLaps: 3, TimeLimit: 1000}
+
+			Expect(r.IsFinished(2, 1099, 100)).To(BeFalse())
+			Expect(r.IsFinished(3, 600, 100)).To(BeTrue())
+			Expect(r.IsFinished(1, 1100, 100)).To(BeTrue())
+			Expect(race.Race{TimeLimit: 1000}.IsFinished(100, 1099, 100)).To(BeFalse())
+			Expect(race.Race{TargetLaps: 3}.IsFinished(2, 1000000, 100)).To(BeFalse())
+		})
+
+		Specify("the timed race finishes by the race clock whenever the sportsmen has started", func() {
+			r := race.Race{Format: race.FormatTimed, StartTime: 1000, TimeLimit: 5000}
+
+			Expect(r.EndsAt(3000)).To(Equal(int64(6000)))
+			Expect(r.IsFinished(100, 5999, 3000)).To(BeFalse())
+			Expect(r.IsFinished(1, 6000, 3000)).To(BeTrue())
+		})
+	})
+
+	Describe("Crediting the lap", func() {
+		r := race.Race{Format: race.FormatTimed, StartTime: 0, TimeLimit: 1000, LapDistance: 400}
+
+		Specify("the lap completed before the race clock has run out is credited fully", func() {
+			distance, partial := r.Credit(0, 1000)
+			Expect(distance).To(Equal(int64(400)))
+			Expect(partial).To(BeFalse())
+		})
+
+		Specify("the partial lap is credited by the rule", func() {
+			distance, partial := r.Credit(900, 1100)
+			Expect(distance).To(Equal(int64(400)))
+			Expect(partial).To(BeFalse())
+
+			r.PartialLap = race.PartialLapDiscard
+			distance, partial = r.Credit(900, 1100)
+			Expect(distance).To(Equal(int64(0)))
+			Expect(partial).To(BeTrue())
+
+			r.PartialLap = race.PartialLapProrate
+			distance, partial = r.Credit(900, 1100)
+			Expect(distance).To(Equal(int64(200)))
+			Expect(partial).To(BeTrue())
+		})
+
+		Specify("the laps of the race of laps are credited fully", func() {
+			distance, partial := race.Race{Format: race.FormatLaps, TimeLimit: 1000, LapDistance: 400}.Credit(900, 1100)
+			Expect(distance).To(Equal(int64(400)))
+			Expect(partial).To(BeFalse())
+		})
+	})
+})
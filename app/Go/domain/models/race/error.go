@@ -0,0 +1,17 @@
+package race
+
+type (
+	// AlreadyExists signifies the race is held at the checkpoint already.
+	AlreadyExists struct{}
+
+	// NotFound signifies a race is not found.
+	NotFound struct{}
+)
+
+func (err AlreadyExists) Error() string {
+	return "Race of the checkpoint already exists"
+}
+
+func (err NotFound) Error() string {
+	return "Race does not exist"
+}
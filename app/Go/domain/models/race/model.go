@@ -0,0 +1,95 @@
+package race
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Formats of the race.
+const (
+	// FormatLaps counts the passings of the checkpoint as laps, the sportsmen finishes on the target lap
+	// or on the first passing after the time limit, whichever comes first.
+	FormatLaps = "laps"
+	// FormatTimed runs the race clock from the start time for the time limit, the sportsmen covering the most laps
+	// wins and finishes on the first passing after the race clock has run out.
+	FormatTimed = "timed"
+)
+
+// Rules of the timed race crediting the lap completed after the race clock has run out.
+const (
+	// PartialLapCount credits the whole lap.
+	PartialLapCount = "count"
+	// PartialLapDiscard credits nothing, the lap is recorded as partial.
+	PartialLapDiscard = "discard"
+	// PartialLapProrate credits the distance in proportion to the lap time before the race clock has run out.
+	PartialLapProrate = "prorate"
+)
+
+// Race represents a persistence model for the race held on the circuit, the passings of its checkpoint
+// after the start are recorded as laps instead of being rejected as the second result.
+type Race struct {
+	ID           uuid.UUID `gorm:"primary_key" json:"id"`
+	Name         string    `gorm:"not null" json:"name"`
+	CheckpointID uuid.UUID `gorm:"not null;unique_index:idx_race_checkpoint" json:"checkpoint_id"`
+	Format       string    `gorm:"not null" json:"format"`
+	TargetLaps   uint32    `gorm:"not null;default:0" json:"target_laps"`
+	TimeLimit    int64     `gorm:"not null;default:0" json:"time_limit"`
+	MinLapTime   int64     `gorm:"not null;default:0" json:"min_lap_time"`
+	StartTime    int64     `gorm:"not null;default:0" json:"start_time"`
+	LapDistance  int64     `gorm:"not null;default:0" json:"lap_distance"`
+	PartialLap   string    `gorm:"not null;default:''" json:"partial_lap"`
+	CreatedAt    int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version      uint32    `gorm:"not null" json:"version"`
+}
+
+// PendingRace represents a race about to create, zero target laps or time limit is not applied.
+// The times are in milliseconds, the start time of the race clock is the Unix time and the lap distance is in meters.
+type PendingRace struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	CheckpointID uuid.UUID `json:"checkpoint_id"`
+	Format       string    `json:"format"`
+	TargetLaps   uint32    `json:"target_laps"`
+	TimeLimit    int64     `json:"time_limit"`
+	MinLapTime   int64     `json:"min_lap_time"`
+	StartTime    int64     `json:"start_time"`
+	LapDistance  int64     `json:"lap_distance"`
+	PartialLap   string    `json:"partial_lap"`
+}
+
+// EndsAt returns the time the race ends at for the sportsmen started at the given time, the timed race ends
+// by the race clock for everyone and the zero time is returned when the race has no time limit.
+func (r Race) EndsAt(timeStart int64) int64 {
+	switch {
+	case r.TimeLimit == 0:
+		return 0
+	case r.Format == FormatTimed:
+		return r.StartTime + r.TimeLimit
+	default:
+		return timeStart + r.TimeLimit
+	}
+}
+
+// IsFinished reports whether the sportsmen started at the given time finishes on the passing
+// completing the given number of laps.
+func (r Race) IsFinished(laps uint32, passing, timeStart int64) bool {
+	endsAt := r.EndsAt(timeStart)
+	return (r.TargetLaps > 0 && laps >= r.TargetLaps) || (endsAt > 0 && passing >= endsAt)
+}
+
+// Credit returns the distance credited for the lap from the previous passing and whether the lap is partial,
+// only the lap of the timed race completed after the race clock has run out is credited by the partial lap rule.
+func (r Race) Credit(previous, passing int64) (int64, bool) {
+	endsAt := r.EndsAt(0)
+	if r.Format != FormatTimed || passing <= endsAt {
+		return r.LapDistance, false
+	}
+
+	switch r.PartialLap {
+	case PartialLapDiscard:
+		return 0, true
+	case PartialLapProrate:
+		return r.LapDistance * (endsAt - previous) / (passing - previous), true
+	default:
+		return r.LapDistance, false
+	}
+}
@@ -0,0 +1,50 @@
+package race
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+)
+
+// GetRace fetches a race.
+func GetRace(db gorm.DB, pk uuid.UUID, version *uint32) (*Race, error) {
+	var race Race
+
+	err := db.Model(&race).Where("id = ?", pk).Take(&race).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Race not found: %w", NotFound{})
+	} else if version != nil && race.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading race: %w", err)
+	}
+
+	return &race, nil
+}
+
+// GetRaceOfCheckpoint fetches the race held at the checkpoint.
+func GetRaceOfCheckpoint(db gorm.DB, checkpointID uuid.UUID) (*Race, error) {
+	var race Race
+
+	err := db.Model(&race).Where("checkpoint_id = ?", checkpointID).Take(&race).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Race not found: %w", NotFound{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading race: %w", err)
+	}
+
+	return &race, nil
+}
+
+// GetRaces fetches the races, the earliest created comes first.
+func GetRaces(db gorm.DB) (*[]Race, error) {
+	var races []Race
+
+	err := db.Order("created_at asc, name asc").Find(&races).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading races: %w", err)
+	}
+
+	return &races, nil
+}
@@ -0,0 +1,748 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: race.proto
+
+package race
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type RaceCreatedEvent struct {
+	RaceID               string   `protobuf:"bytes,1,opt,name=RaceID,proto3" json:"RaceID,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	CheckpointID         string   `protobuf:"bytes,3,opt,name=CheckpointID,proto3" json:"CheckpointID,omitempty"`
+	Format               string   `protobuf:"bytes,4,opt,name=Format,proto3" json:"Format,omitempty"`
+	TargetLaps           uint32   `protobuf:"varint,5,opt,name=TargetLaps,proto3" json:"TargetLaps,omitempty"`
+	TimeLimit            int64    `protobuf:"varint,6,opt,name=TimeLimit,proto3" json:"TimeLimit,omitempty"`
+	MinLapTime           int64    `protobuf:"varint,7,opt,name=MinLapTime,proto3" json:"MinLapTime,omitempty"`
+	StartTime            int64    `protobuf:"varint,8,opt,name=StartTime,proto3" json:"StartTime,omitempty"`
+	LapDistance          int64    `protobuf:"varint,9,opt,name=LapDistance,proto3" json:"LapDistance,omitempty"`
+	PartialLap           string   `protobuf:"bytes,10,opt,name=PartialLap,proto3" json:"PartialLap,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaceCreatedEvent) Reset()         { *m = RaceCreatedEvent{} }
+func (m *RaceCreatedEvent) String() string { return proto.CompactTextString(m) }
+func (*RaceCreatedEvent) ProtoMessage()    {}
+func (*RaceCreatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3d1baf84a7ac6296, []int{0}
+}
+func (m *RaceCreatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RaceCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RaceCreatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RaceCreatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaceCreatedEvent.Merge(m, src)
+}
+func (m *RaceCreatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *RaceCreatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaceCreatedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaceCreatedEvent proto.InternalMessageInfo
+
+func (m *RaceCreatedEvent) GetRaceID() string {
+	if m != nil {
+		return m.RaceID
+	}
+	return ""
+}
+
+func (m *RaceCreatedEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RaceCreatedEvent) GetCheckpointID() string {
+	if m != nil {
+		return m.CheckpointID
+	}
+	return ""
+}
+
+func (m *RaceCreatedEvent) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *RaceCreatedEvent) GetTargetLaps() uint32 {
+	if m != nil {
+		return m.TargetLaps
+	}
+	return 0
+}
+
+func (m *RaceCreatedEvent) GetTimeLimit() int64 {
+	if m != nil {
+		return m.TimeLimit
+	}
+	return 0
+}
+
+func (m *RaceCreatedEvent) GetMinLapTime() int64 {
+	if m != nil {
+		return m.MinLapTime
+	}
+	return 0
+}
+
+func (m *RaceCreatedEvent) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RaceCreatedEvent) GetLapDistance() int64 {
+	if m != nil {
+		return m.LapDistance
+	}
+	return 0
+}
+
+func (m *RaceCreatedEvent) GetPartialLap() string {
+	if m != nil {
+		return m.PartialLap
+	}
+	return ""
+}
+
+func (m *RaceCreatedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*RaceCreatedEvent)(nil), "race.RaceCreatedEvent")
+}
+
+func init() { proto.RegisterFile("race.proto", fileDescriptor_3d1baf84a7ac6296) }
+
+var fileDescriptor_3d1baf84a7ac6296 = []byte{
+	// 267 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x4d, 0x4a, 0xc4, 0x40,
+	0x10, 0x85, 0xed, 0x99, 0x98, 0x31, 0xa5, 0xc2, 0xd0, 0x0b, 0x69, 0x41, 0x42, 0x98, 0x55, 0x56,
+	0x6e, 0xbc, 0x81, 0x13, 0x85, 0x81, 0x28, 0x12, 0x07, 0xf7, 0x65, 0x2c, 0xb4, 0xd1, 0xa4, 0x9b,
+	0x4e, 0xe1, 0x59, 0x3c, 0x92, 0x4b, 0xc1, 0x0b, 0x48, 0x3c, 0x88, 0xd2, 0x1d, 0x7f, 0x32, 0xbb,
+	0x7a, 0xdf, 0xe3, 0x3d, 0x1e, 0x05, 0xe0, 0xb0, 0xa6, 0x63, 0xeb, 0x0c, 0x1b, 0x19, 0xf9, 0x7b,
+	0xf1, 0x3e, 0x81, 0x79, 0x85, 0x35, 0x2d, 0x1d, 0x21, 0xd3, 0xdd, 0xd9, 0x33, 0xb5, 0x2c, 0x0f,
+	0x20, 0xf6, 0x6c, 0x55, 0x28, 0x91, 0x89, 0x3c, 0xa9, 0x7e, 0x94, 0x94, 0x10, 0x5d, 0x62, 0x43,
+	0x6a, 0x12, 0x68, 0xb8, 0xe5, 0x02, 0xf6, 0x96, 0x0f, 0x54, 0x3f, 0x5a, 0xa3, 0x5b, 0x5e, 0x15,
+	0x6a, 0x1a, 0xbc, 0x0d, 0xe6, 0xfb, 0xce, 0x8d, 0x6b, 0x90, 0x55, 0x34, 0xf4, 0x0d, 0x4a, 0xa6,
+	0x00, 0x6b, 0x74, 0xf7, 0xc4, 0x25, 0xda, 0x4e, 0x6d, 0x67, 0x22, 0xdf, 0xaf, 0x46, 0x44, 0x1e,
+	0x41, 0xb2, 0xd6, 0x0d, 0x95, 0xba, 0xd1, 0xac, 0xe2, 0x4c, 0xe4, 0xd3, 0xea, 0x1f, 0xf8, 0xf4,
+	0x85, 0x6e, 0x4b, 0xb4, 0x1e, 0xa9, 0x59, 0xb0, 0x47, 0xc4, 0xa7, 0xaf, 0x19, 0x1d, 0x07, 0x7b,
+	0x67, 0x48, 0xff, 0x01, 0x99, 0xc1, 0x6e, 0x89, 0xb6, 0xd0, 0x1d, 0x63, 0x5b, 0x93, 0x4a, 0x82,
+	0x3f, 0x46, 0xbe, 0xff, 0x0a, 0x1d, 0x6b, 0x7c, 0x2a, 0xd1, 0x2a, 0x08, 0xcb, 0x47, 0x44, 0x1e,
+	0xc2, 0xec, 0x86, 0x5c, 0xa7, 0x4d, 0xab, 0xbe, 0x44, 0xd8, 0xfe, 0xab, 0x4f, 0xe7, 0xaf, 0x7d,
+	0x2a, 0xde, 0xfa, 0x54, 0x7c, 0xf4, 0xa9, 0x78, 0xf9, 0x4c, 0xb7, 0x6e, 0xe3, 0xf0, 0xf4, 0x93,
+	0xef, 0x01, 0x00, 0x61, 0x6e, 0x4a, 0xe6, 0x82, 0x01, 0x00, 0x00,
+}
+
+func (m *RaceCreatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaceCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaceCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintRace(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if len(m.PartialLap) > 0 {
+		i -= len(m.PartialLap)
+		copy(dAtA[i:], m.PartialLap)
+		i = encodeVarintRace(dAtA, i, uint64(len(m.PartialLap)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.LapDistance != 0 {
+		i = encodeVarintRace(dAtA, i, uint64(m.LapDistance))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.StartTime != 0 {
+		i = encodeVarintRace(dAtA, i, uint64(m.StartTime))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.MinLapTime != 0 {
+		i = encodeVarintRace(dAtA, i, uint64(m.MinLapTime))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.TimeLimit != 0 {
+		i = encodeVarintRace(dAtA, i, uint64(m.TimeLimit))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TargetLaps != 0 {
+		i = encodeVarintRace(dAtA, i, uint64(m.TargetLaps))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Format) > 0 {
+		i -= len(m.Format)
+		copy(dAtA[i:], m.Format)
+		i = encodeVarintRace(dAtA, i, uint64(len(m.Format)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.CheckpointID) > 0 {
+		i -= len(m.CheckpointID)
+		copy(dAtA[i:], m.CheckpointID)
+		i = encodeVarintRace(dAtA, i, uint64(len(m.CheckpointID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintRace(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.RaceID) > 0 {
+		i -= len(m.RaceID)
+		copy(dAtA[i:], m.RaceID)
+		i = encodeVarintRace(dAtA, i, uint64(len(m.RaceID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRace(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRace(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RaceCreatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RaceID)
+	if l > 0 {
+		n += 1 + l + sovRace(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovRace(uint64(l))
+	}
+	l = len(m.CheckpointID)
+	if l > 0 {
+		n += 1 + l + sovRace(uint64(l))
+	}
+	l = len(m.Format)
+	if l > 0 {
+		n += 1 + l + sovRace(uint64(l))
+	}
+	if m.TargetLaps != 0 {
+		n += 1 + sovRace(uint64(m.TargetLaps))
+	}
+	if m.TimeLimit != 0 {
+		n += 1 + sovRace(uint64(m.TimeLimit))
+	}
+	if m.MinLapTime != 0 {
+		n += 1 + sovRace(uint64(m.MinLapTime))
+	}
+	if m.StartTime != 0 {
+		n += 1 + sovRace(uint64(m.StartTime))
+	}
+	if m.LapDistance != 0 {
+		n += 1 + sovRace(uint64(m.LapDistance))
+	}
+	l = len(m.PartialLap)
+	if l > 0 {
+		n += 1 + l + sovRace(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 2 + sovRace(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovRace(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRace(x uint64) (n int) {
+	return sovRace(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RaceCreatedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaceCreatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaceCreatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RaceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RaceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CheckpointID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Format = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetLaps", wireType)
+			}
+			m.TargetLaps = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetLaps |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeLimit", wireType)
+			}
+			m.TimeLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeLimit |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLapTime", wireType)
+			}
+			m.MinLapTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinLapTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			m.StartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LapDistance", wireType)
+			}
+			m.LapDistance = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LapDistance |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialLap", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRace
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PartialLap = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRace(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRace
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRace
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRace
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupRace
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthRace
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthRace        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRace          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupRace = fmt.Errorf("proto: unexpected end of group")
+)
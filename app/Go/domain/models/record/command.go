@@ -0,0 +1,72 @@
+package record
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/transaction"
+)
+
+// Create stores the applied record.
+func Create(db gorm.DB, pendingRecord PendingRecord) (*RecordAppliedEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingRecord,
+		validation.Field(&pendingRecord.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingRecord.Type, validation.Required, validation.In(TypeStart, TypeFinish, TypeSplit, TypeStatus, TypeLap, TypeHandover)),
+		validation.Field(&pendingRecord.CredentialID, validation.Required),
+		validation.Field(&pendingRecord.PayloadHash, validation.Required),
+		validation.Field(&pendingRecord.EntityID, validation.Required),
+		validation.Field(&pendingRecord.AppliedAt, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	newRecord := Record{
+		ID:           pendingRecord.ID,
+		Type:         pendingRecord.Type,
+		CredentialID: pendingRecord.CredentialID,
+		DeviceID:     pendingRecord.DeviceID,
+		PayloadHash:  pendingRecord.PayloadHash,
+		EntityID:     pendingRecord.EntityID,
+		AppliedAt:    pendingRecord.AppliedAt,
+		Version:      1,
+	}
+
+	// The record of the same id applied concurrently is rejected by the primary key.
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		if err := tx.Create(&Record{
+			ID:           newRecord.ID,
+			Type:         newRecord.Type,
+			CredentialID: newRecord.CredentialID,
+			DeviceID:     newRecord.DeviceID,
+			PayloadHash:  newRecord.PayloadHash,
+			EntityID:     newRecord.EntityID,
+			AppliedAt:    newRecord.AppliedAt,
+			Version:      newRecord.Version,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := &RecordAppliedEvent{
+		RecordID:     newRecord.ID.String(),
+		Type:         newRecord.Type,
+		CredentialID: newRecord.CredentialID.String(),
+		EntityID:     newRecord.EntityID.String(),
+		AppliedAt:    newRecord.AppliedAt,
+		Version:      newRecord.Version,
+	}
+
+	if newRecord.DeviceID != nil {
+		event.DeviceID = newRecord.DeviceID.String()
+	}
+
+	return event, nil
+}
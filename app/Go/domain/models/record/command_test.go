@@ -0,0 +1,89 @@
+package record_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/record"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing records", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var pendingRecord record.PendingRecord
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingRecord = record.PendingRecord{
+			ID:           uuid.Must(uuid.NewV4()),
+			Type:         record.TypeStart,
+			CredentialID: uuid.Must(uuid.NewV4()),
+			PayloadHash:  "hash",
+			EntityID:     uuid.Must(uuid.NewV4()),
+			AppliedAt:    utils.MakeTimestampInMilliseconds(),
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Storing the applied record", func() {
+		When("the record is stored", func() {
+			Specify("the record is persisted in the database", func() {
+				_, err := record.Create(*db, pendingRecord)
+				Expect(err).To(BeNil())
+
+				fetched, err := record.GetRecord(*db, pendingRecord.ID)
+				Expect(err).To(BeNil())
+				Expect(fetched.Type).To(Equal(record.TypeStart))
+				Expect(fetched.PayloadHash).To(Equal(pendingRecord.PayloadHash))
+				Expect(fetched.EntityID).To(Equal(pendingRecord.EntityID))
+			})
+		})
+
+		When("the record exists already", func() {
+			Specify("the error returned", func() {
+				_, err := record.Create(*db, pendingRecord)
+				Expect(err).To(BeNil())
+
+				_, err = record.Create(*db, pendingRecord)
+				Expect(errors.As(err, &record.AlreadyExists{})).To(BeTrue())
+			})
+		})
+
+		When("the record does not exist", func() {
+			Specify("the error returned", func() {
+				_, err := record.GetRecord(*db, uuid.Must(uuid.NewV4()))
+				Expect(errors.As(err, &record.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,24 @@
+package record
+
+type (
+	// NotFound signifies a record is not found.
+	NotFound struct{}
+
+	// AlreadyExists signifies a record with the same ID has been applied already.
+	AlreadyExists struct{}
+
+	// Reused signifies a record ID has been used for a record with another payload.
+	Reused struct{}
+)
+
+func (err NotFound) Error() string {
+	return "Record does not exist"
+}
+
+func (err AlreadyExists) Error() string {
+	return "Record already exists"
+}
+
+func (err Reused) Error() string {
+	return "Record ID has been used for another record"
+}
@@ -0,0 +1,42 @@
+package record
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Types of the timing records synchronized by the devices.
+const (
+	TypeStart    = "start"
+	TypeFinish   = "finish"
+	TypeSplit    = "split"
+	TypeStatus   = "status"
+	TypeLap      = "lap"
+	TypeHandover = "handover"
+)
+
+// Record represents a persistence model for the timing record applied by the batch sync,
+// the client generated ID makes the sync safe to retry, the payload hash detects the ID reuse.
+type Record struct {
+	ID           uuid.UUID  `gorm:"primary_key" json:"id"`
+	Type         string     `gorm:"not null" json:"type"`
+	CredentialID uuid.UUID  `gorm:"not null" json:"credential_id"`
+	DeviceID     *uuid.UUID `gorm:"type:uuid" json:"device_id"`
+	PayloadHash  string     `gorm:"not null" json:"payload_hash"`
+	EntityID     uuid.UUID  `gorm:"not null" json:"entity_id"`
+	AppliedAt    int64      `gorm:"not null" json:"applied_at"`
+	CreatedAt    int64      `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version      uint32     `gorm:"not null" json:"version"`
+}
+
+// PendingRecord represents an applied timing record about to store,
+// the entity is the result, the split, the lap or the sportsmen the record changed,
+// the handover changes the result of the leg handed over.
+type PendingRecord struct {
+	ID           uuid.UUID  `json:"id"`
+	Type         string     `json:"type"`
+	CredentialID uuid.UUID  `json:"credential_id"`
+	DeviceID     *uuid.UUID `json:"device_id"`
+	PayloadHash  string     `json:"payload_hash"`
+	EntityID     uuid.UUID  `json:"entity_id"`
+	AppliedAt    int64      `json:"applied_at"`
+}
@@ -0,0 +1,21 @@
+package record
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+// GetRecord fetches an applied record.
+func GetRecord(db gorm.DB, pk uuid.UUID) (*Record, error) {
+	var record Record
+
+	err := db.Model(&record).Where("id = ?", pk).Take(&record).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Record not found: %w", NotFound{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading record: %w", err)
+	}
+
+	return &record, nil
+}
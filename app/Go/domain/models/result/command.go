@@ -4,10 +4,13 @@ import (
 	"fmt"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/gofrs/uuid"
 	"github.com/jinzhu/gorm"
 	domain_errors "sports/backend/domain/errors"
 	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/outbox"
 	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/transaction"
 )
 
 // Create a new result.
@@ -22,91 +25,124 @@ func Create(db gorm.DB, pendingResult PendingResult) (*ResultCreatedEvent, error
 		return nil, err
 	}
 
-	err := db.Model(Result{}).Where(
-		"checkpoint_id = ? AND sportsmen_id = ?",
-		pendingResult.CheckpointID,
-		pendingResult.SportsmenID,
-	).Take(&Result{}).Error
-	if err == nil {
-		return nil, AlreadyExists{}
-	} else if !gorm.IsRecordNotFoundError(err) {
-		return nil, err
-	}
-
-	err = db.Model(&checkpoint.Checkpoint{}).Where(
-		"id = ?",
-		pendingResult.CheckpointID,
-	).Take(&checkpoint.Checkpoint{}).Error
-	if gorm.IsRecordNotFoundError(err) {
-		return nil, checkpoint.NotFound{}
-	}
-
-	err = db.Model(&sportsmen.Sportsmen{}).Where(
-		"id = ?",
-		pendingResult.SportsmenID,
-	).Take(&sportsmen.Sportsmen{}).Error
-	if gorm.IsRecordNotFoundError(err) {
-		return nil, sportsmen.NotFound{}
-	}
-
 	newResult := Result{
 		ID:           pendingResult.ID,
 		CheckpointID: pendingResult.CheckpointID,
 		SportsmenID:  pendingResult.SportsmenID,
 		TimeStart:    pendingResult.TimeStart,
+		DeviceID:     pendingResult.DeviceID,
 		Version:      1,
 	}
 
-	if err := db.Create(&Result{
-		ID:           newResult.ID,
-		CheckpointID: newResult.CheckpointID,
-		SportsmenID:  newResult.SportsmenID,
-		TimeStart:    newResult.TimeStart,
-		Version:      1,
-	}).Error; err != nil {
-		return nil, err
-	}
-
-	return &ResultCreatedEvent{
+	event := &ResultCreatedEvent{
 		ResultID:     newResult.ID.String(),
 		CheckpointID: newResult.CheckpointID.String(),
 		SportsmenID:  newResult.SportsmenID.String(),
 		TimeStart:    newResult.TimeStart,
 		Version:      1,
-	}, nil
-}
+	}
 
-func AddFinishTime(db gorm.DB, finishTime int64, unfinishedResult UnfinishedResult) (*ResultFinishedEvent, error) {
-	err := db.Model(Result{}).Where(
-		"checkpoint_id = ? AND sportsmen_id = ? AND time_start = ? AND time_finish = ?",
-		unfinishedResult.CheckpointID,
-		unfinishedResult.SportsmenID,
-		unfinishedResult.TimeStart,
-		finishTime,
-	).Take(&Result{}).Error
-	if err == nil {
-		return nil, AlreadyFinished{}
-	} else if !gorm.IsRecordNotFoundError(err) {
-		return nil, err
+	if newResult.DeviceID != nil {
+		event.DeviceID = newResult.DeviceID.String()
 	}
 
-	// Update attributes with `struct`, will only update non-zero fields.
-	// Update attributes with `map` instead.
-	// https://gorm.io/docs/update.html#Updates-multiple-columns
-	result := db.Model(&Result{}).
-		Where("id = ? AND version = ?",
-			unfinishedResult.ID,
-			1,
-		).Updates(map[string]interface{}{"time_finish": finishTime, "version": unfinishedResult.Version + 1})
-	if result.Error != nil {
-		return nil, fmt.Errorf("Error adding finish time to the result: %w", result.Error)
-	} else if result.RowsAffected != 1 {
-		return nil, fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		err := tx.Model(&checkpoint.Checkpoint{}).Where(
+			"id = ?",
+			pendingResult.CheckpointID,
+		).Take(&checkpoint.Checkpoint{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return checkpoint.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		err = tx.Model(&sportsmen.Sportsmen{}).Where(
+			"id = ?",
+			pendingResult.SportsmenID,
+		).Take(&sportsmen.Sportsmen{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return sportsmen.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		// The unique index rejects the second start of the sportsmen at the checkpoint, including the concurrent one.
+		if err := tx.Create(&Result{
+			ID:           newResult.ID,
+			CheckpointID: newResult.CheckpointID,
+			SportsmenID:  newResult.SportsmenID,
+			TimeStart:    newResult.TimeStart,
+			DeviceID:     newResult.DeviceID,
+			Version:      1,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		return outbox.AppendEvent(tx, TopicCreated, newResult.ID, event)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &ResultFinishedEvent{
+	return event, nil
+}
+
+func AddFinishTime(db gorm.DB, finishTime int64, unfinishedResult UnfinishedResult) (*ResultFinishedEvent, error) {
+	return AddFinishTimeByDevice(db, finishTime, nil, unfinishedResult)
+}
+
+// AddFinishTimeByDevice adds the finish time submitted by the timekeeper device, nil device stands for other credential.
+func AddFinishTimeByDevice(db gorm.DB, finishTime int64, deviceID *uuid.UUID, unfinishedResult UnfinishedResult) (*ResultFinishedEvent, error) {
+	event := &ResultFinishedEvent{
 		ResultID:   unfinishedResult.ID.String(),
 		TimeFinish: finishTime,
 		Version:    unfinishedResult.Version + 1,
-	}, nil
+	}
+
+	if deviceID != nil {
+		event.DeviceID = deviceID.String()
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		err := tx.Model(Result{}).Where(
+			"checkpoint_id = ? AND sportsmen_id = ? AND time_start = ? AND time_finish = ?",
+			unfinishedResult.CheckpointID,
+			unfinishedResult.SportsmenID,
+			unfinishedResult.TimeStart,
+			finishTime,
+		).Take(&Result{}).Error
+		if err == nil {
+			return AlreadyFinished{}
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		// Update attributes with `struct`, will only update non-zero fields.
+		// Update attributes with `map` instead.
+		// https://gorm.io/docs/update.html#Updates-multiple-columns
+		result := tx.Model(&Result{}).
+			Where("id = ? AND version = ?",
+				unfinishedResult.ID,
+				unfinishedResult.Version,
+			).Updates(map[string]interface{}{
+			"time_finish":      finishTime,
+			"finish_device_id": deviceID,
+			"version":          unfinishedResult.Version + 1,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("Error adding finish time to the result: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return outbox.AppendEvent(tx, TopicFinished, unfinishedResult.ID, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
 }
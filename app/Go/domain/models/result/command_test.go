@@ -9,11 +9,14 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/spf13/viper"
 	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
 	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/outbox"
 	"sports/backend/domain/models/result"
 	"sports/backend/domain/models/sportsmen"
 	"sports/backend/srv/cmd/config"
 	"sports/backend/srv/utils"
+	"sync"
 )
 
 var _ = Describe("Managing results", func() {
@@ -122,6 +125,92 @@ var _ = Describe("Managing results", func() {
 		})
 	})
 
+	Describe("Creating the same result concurrently", func() {
+		var pendingCheckpoint checkpoint.PendingCheckpoint
+		var pendingSportsmen sportsmen.PendingSportsmen
+
+		// The concurrent timekeepers use separate connections and commit, so the rows are removed afterwards.
+		BeforeEach(func() {
+			pendingCheckpoint = checkpoint.PendingCheckpoint{
+				ID:   uuid.Must(uuid.NewV4()),
+				Name: "Corridor1",
+			}
+
+			_, err := checkpoint.Create(*conn, pendingCheckpoint)
+			Expect(err).To(BeNil())
+
+			pendingSportsmen = sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+			}
+
+			_, err = sportsmen.Create(*conn, pendingSportsmen)
+			Expect(err).To(BeNil())
+		})
+
+		AfterEach(func() {
+			Expect(conn.Where(
+				"aggregate_id IN ?",
+				conn.Table("results").Select("id").Where("sportsmen_id = ?", pendingSportsmen.ID).SubQuery(),
+			).Delete(&outbox.Message{}).Error).To(BeNil())
+			Expect(conn.Where("sportsmen_id = ?", pendingSportsmen.ID).Delete(&result.Result{}).Error).To(BeNil())
+			Expect(conn.Where("id = ?", pendingSportsmen.ID).Delete(&sportsmen.Sportsmen{}).Error).To(BeNil())
+			Expect(conn.Where("id = ?", pendingCheckpoint.ID).Delete(&checkpoint.Checkpoint{}).Error).To(BeNil())
+		})
+
+		When("two timekeepers start the sportsmen simultaneously", func() {
+			Specify("exactly one result is created and the other start gets AlreadyExists", func() {
+				const timekeepers = 2
+
+				start := make(chan struct{})
+				errs := make([]error, timekeepers)
+				var wg sync.WaitGroup
+
+				for i := 0; i < timekeepers; i++ {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						<-start
+
+						_, errs[i] = result.Create(*conn, result.PendingResult{
+							ID:           uuid.Must(uuid.NewV4()),
+							CheckpointID: pendingCheckpoint.ID,
+							SportsmenID:  pendingSportsmen.ID,
+							TimeStart:    utils.MakeTimestampInMilliseconds(),
+						})
+					}(i)
+				}
+
+				close(start)
+				wg.Wait()
+
+				created, alreadyExists := 0, 0
+				for _, err := range errs {
+					if err == nil {
+						created++
+					} else if errors.As(err, &result.AlreadyExists{}) {
+						alreadyExists++
+					} else {
+						Fail("unexpected error: " + err.Error())
+					}
+				}
+				Expect(created).To(Equal(1))
+				Expect(alreadyExists).To(Equal(1))
+
+				var count int
+				err := conn.Model(&result.Result{}).Where(
+					"checkpoint_id = ? AND sportsmen_id = ?",
+					pendingCheckpoint.ID,
+					pendingSportsmen.ID,
+				).Count(&count).Error
+				Expect(err).To(BeNil())
+				Expect(count).To(Equal(1))
+			})
+		})
+	})
+
 	Describe("Appending a result with finish time", func() {
 		var pendingResult result.PendingResult
 		var unfinishedResult result.UnfinishedResult
@@ -201,6 +290,24 @@ var _ = Describe("Managing results", func() {
 			})
 		})
 
+		When("the finish time is submitted by the device", func() {
+			Specify("the device is persisted in the database", func() {
+				deviceID := uuid.Must(uuid.NewV4())
+				time := utils.MakeTimestampInMilliseconds()
+
+				event, err := result.AddFinishTimeByDevice(*db, time, &deviceID, unfinishedResult)
+				Expect(err).To(BeNil())
+				Expect(event.DeviceID).To(Equal(deviceID.String()))
+
+				fetched := result.Result{}
+				err = db.Model(&fetched).Where("id = ?", pendingResult.ID).Take(&fetched).Error
+				Expect(err).To(BeNil())
+
+				Expect(fetched.FinishDeviceID).To(Equal(&deviceID))
+				Expect(fetched.DeviceID).To(BeNil())
+			})
+		})
+
 		When("the finished result already exists", func() {
 			Specify("the error returned is of AlreadyFinished domain error type", func() {
 				time := utils.MakeTimestampInMilliseconds()
@@ -211,5 +318,15 @@ var _ = Describe("Managing results", func() {
 				Expect(errors.As(err, &result.AlreadyFinished{})).To(BeTrue())
 			})
 		})
+
+		When("the result has been updated since it was fetched", func() {
+			Specify("the error returned is of StateConflict domain error type", func() {
+				err := db.Model(&result.Result{}).Where("id = ?", unfinishedResult.ID).Update("version", unfinishedResult.Version+1).Error
+				Expect(err).To(BeNil())
+
+				_, err = result.AddFinishTime(*db, utils.MakeTimestampInMilliseconds(), unfinishedResult)
+				Expect(errors.As(err, &domain_errors.StateConflict{})).To(BeTrue())
+			})
+		})
 	})
 })
@@ -4,23 +4,33 @@ import (
 	"github.com/gofrs/uuid"
 )
 
+// Outbox topics of the result events.
+const (
+	TopicCreated  = "result.created"
+	TopicFinished = "result.finished"
+)
+
 // Result represents a persistence model for the event result.
 type Result struct {
 	ID           uuid.UUID `gorm:"primary_key" json:"id"`
-	CheckpointID uuid.UUID `gorm:"not null" json:"checkpoint_id"`
-	SportsmenID  uuid.UUID `gorm:"not null" json:"sportsmen_id"`
+	CheckpointID uuid.UUID `gorm:"not null;unique_index:idx_result_checkpoint_sportsmen" json:"checkpoint_id"`
+	SportsmenID  uuid.UUID `gorm:"not null;unique_index:idx_result_checkpoint_sportsmen" json:"sportsmen_id"`
 	TimeStart    int64     `gorm:"not null" json:"time_start"`
 	TimeFinish   *int64    `json:"time_finish"`
-	CreatedAt    int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
-	Version      uint32    `gorm:"not null" json:"version"`
+	// Devices which submitted the start and the finish time, nil when submitted by other credential.
+	DeviceID       *uuid.UUID `gorm:"type:uuid" json:"device_id"`
+	FinishDeviceID *uuid.UUID `gorm:"type:uuid" json:"finish_device_id"`
+	CreatedAt      int64      `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version        uint32     `gorm:"not null" json:"version"`
 }
 
 // PendingResult represents an event result about to create.
 type PendingResult struct {
-	ID           uuid.UUID `gorm:"primary_key" json:"id"`
-	CheckpointID uuid.UUID `gorm:"not null" json:"checkpoint_id"`
-	SportsmenID  uuid.UUID `gorm:"not null" json:"sportsmen_id"`
-	TimeStart    int64     `gorm:"not null" json:"time_start"`
+	ID           uuid.UUID  `gorm:"primary_key" json:"id"`
+	CheckpointID uuid.UUID  `gorm:"not null" json:"checkpoint_id"`
+	SportsmenID  uuid.UUID  `gorm:"not null" json:"sportsmen_id"`
+	TimeStart    int64      `gorm:"not null" json:"time_start"`
+	DeviceID     *uuid.UUID `json:"device_id"`
 }
 
 // UnfinishedResult represents an unfinished event result without finish time.
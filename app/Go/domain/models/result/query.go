@@ -8,6 +8,22 @@ import (
 	domain_errors "sports/backend/domain/errors"
 )
 
+// GetResult fetches a result.
+func GetResult(db gorm.DB, pk uuid.UUID, version *uint32) (*Result, error) {
+	var result Result
+
+	err := db.Model(&result).Where("id = ?", pk).Take(&result).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Result not found: %w", NotFound{})
+	} else if version != nil && result.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading result: %w", err)
+	}
+
+	return &result, nil
+}
+
 // GetUnfinishedResult fetches a result.
 func GetUnfinishedResult(db gorm.DB, checkpoint_id, sportsmen_id uuid.UUID, version *uint32) (*UnfinishedResult, error) {
 	var result Result
@@ -32,10 +48,26 @@ func GetUnfinishedResult(db gorm.DB, checkpoint_id, sportsmen_id uuid.UUID, vers
 	}, nil
 }
 
+// GetLastTenResults fetches the 10 latest results.
 func GetLastTenResults(db gorm.DB) (*[]Result, error) {
+	return GetLastResults(db, "", 10)
+}
+
+// ofEvent narrows down the results to the results recorded at the checkpoints of the event,
+// the results of every event are kept when the event is blank.
+func ofEvent(db gorm.DB, event string) *gorm.DB {
+	if event == "" {
+		return &db
+	}
+
+	return db.Where("results.checkpoint_id IN (SELECT checkpoints.id FROM checkpoints WHERE checkpoints.event = ?)", event)
+}
+
+// GetLastResults fetches the latest results of the event ordered by start time.
+func GetLastResults(db gorm.DB, event string, limit int) (*[]Result, error) {
 	var results []Result
 
-	err := db.Order("time_start desc").Limit(10).Find(&results).Error
+	err := ofEvent(db, event).Order("time_start desc").Limit(limit).Find(&results).Error
 	if gorm.IsRecordNotFoundError(err) {
 		return &results, nil
 	} else if err != nil {
@@ -45,3 +77,67 @@ func GetLastTenResults(db gorm.DB) (*[]Result, error) {
 
 	return &results, nil
 }
+
+// GetUnfinishedResults fetches the latest results of the event without finish time ordered by start time.
+func GetUnfinishedResults(db gorm.DB, event string, limit int) (*[]Result, error) {
+	var results []Result
+
+	err := ofEvent(db, event).Where("time_finish IS NULL").Order("time_start desc").Limit(limit).Find(&results).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &results, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading unfinished results: %w", err)
+	}
+
+	return &results, nil
+}
+
+// GetLeaderboard fetches the finished results of the event with the best time first, the results of the circuit races
+// are ranked by the distance and the laps completed first.
+func GetLeaderboard(db gorm.DB, event string, limit int) (*[]Result, error) {
+	var results []Result
+
+	err := ofEvent(db, event).Where("time_finish IS NOT NULL").
+		Order("(SELECT COALESCE(SUM(laps.distance), 0) FROM laps WHERE laps.result_id = results.id) desc").
+		Order("(SELECT COUNT(*) FROM laps WHERE laps.result_id = results.id AND NOT laps.partial) desc").
+		Order("(time_finish - time_start) asc, time_finish asc").
+		Limit(limit).
+		Find(&results).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &results, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading leaderboard: %w", err)
+	}
+
+	return &results, nil
+}
+
+// GetLastResultsPerCategory fetches the latest results of the event of every sportsmen category ordered by start time.
+func GetLastResultsPerCategory(db gorm.DB, event string, limit int) (*[]Result, error) {
+	var results []Result
+
+	err := db.Raw(`
+		SELECT ranked.id, ranked.checkpoint_id, ranked.sportsmen_id, ranked.time_start,
+			ranked.time_finish, ranked.created_at, ranked.version
+		FROM (
+			SELECT results.*, ROW_NUMBER() OVER (
+				PARTITION BY sportsmens.category ORDER BY results.time_start DESC
+			) AS position
+			FROM results
+			JOIN sportsmens ON sportsmens.id = results.sportsmen_id
+			WHERE ? = '' OR results.checkpoint_id IN (
+				SELECT checkpoints.id FROM checkpoints WHERE checkpoints.event = ?
+			)
+		) AS ranked
+		WHERE ranked.position <= ?
+		ORDER BY ranked.time_start DESC`,
+		event, event, limit,
+	).Scan(&results).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &results, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading results per category: %w", err)
+	}
+
+	return &results, nil
+}
@@ -1,6 +1,7 @@
 package result_test
 
 import (
+	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
@@ -8,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/spf13/viper"
 	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
 	"sports/backend/domain/models/checkpoint"
 	"sports/backend/domain/models/result"
 	"sports/backend/domain/models/sportsmen"
@@ -107,6 +109,61 @@ var _ = Describe("Managing results", func() {
 		})
 	})
 
+	Describe("Fetching a result", func() {
+		var resultID uuid.UUID
+
+		BeforeEach(func() {
+			checkpointID := uuid.Must(uuid.NewV4())
+
+			err := db.Create(&checkpoint.Checkpoint{
+				ID:      checkpointID,
+				Name:    "Corridor1",
+				Version: 1,
+			}).Error
+			Expect(err).To(BeNil())
+
+			sportsmenID := uuid.Must(uuid.NewV4())
+
+			err = db.Create(&sportsmen.Sportsmen{
+				ID:          sportsmenID,
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+				Version:     1,
+			}).Error
+			Expect(err).To(BeNil())
+
+			resultID = uuid.Must(uuid.NewV4())
+
+			err = db.Create(&result.Result{
+				ID:           resultID,
+				TimeStart:    utils.MakeTimestampInMilliseconds(),
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenID,
+				Version:      2,
+			}).Error
+			Expect(err).To(BeNil())
+		})
+
+		When("the version matches", func() {
+			Specify("the result returned", func() {
+				v := uint32(2)
+				fetched, err := result.GetResult(*db, resultID, &v)
+				Expect(err).To(BeNil())
+				Expect(fetched.ID).To(Equal(resultID))
+				Expect(fetched.Version).To(Equal(uint32(2)))
+			})
+		})
+
+		When("the version is outdated", func() {
+			Specify("the error returned", func() {
+				v := uint32(1)
+				_, err := result.GetResult(*db, resultID, &v)
+				Expect(errors.As(err, &domain_errors.InvalidVersion{})).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("Fetching last results", func() {
 		When("More than 10 results are stored", func() {
 			BeforeEach(func() {
@@ -161,4 +218,132 @@ var _ = Describe("Managing results", func() {
 			})
 		})
 	})
+
+	Describe("Fetching results for the dashboard snapshot", func() {
+		var checkpointID uuid.UUID
+
+		createResult := func(category string, timeStart int64, timeFinish *int64) {
+			sportsmenID := uuid.Must(uuid.NewV4())
+
+			err := db.Create(&sportsmen.Sportsmen{
+				ID:          sportsmenID,
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+				Category:    category,
+				Version:     1,
+			}).Error
+			Expect(err).To(BeNil())
+
+			err = db.Create(&result.Result{
+				ID:           uuid.Must(uuid.NewV4()),
+				CheckpointID: checkpointID,
+				SportsmenID:  sportsmenID,
+				TimeStart:    timeStart,
+				TimeFinish:   timeFinish,
+				Version:      1,
+			}).Error
+			Expect(err).To(BeNil())
+		}
+
+		finish := func(timeFinish int64) *int64 {
+			return &timeFinish
+		}
+
+		BeforeEach(func() {
+			checkpointID = uuid.Must(uuid.NewV4())
+
+			err := db.Create(&checkpoint.Checkpoint{
+				ID:      checkpointID,
+				Name:    "Corridor1",
+				Event:   "City Marathon",
+				Version: 1,
+			}).Error
+			Expect(err).To(BeNil())
+
+			createResult("M", 1, finish(100))
+			createResult("M", 2, nil)
+			createResult("W", 3, finish(13))
+			createResult("W", 4, nil)
+			createResult("W", 5, finish(55))
+		})
+
+		Specify("Unfinished results returned ordered by TimeStart", func() {
+			fetched, err := result.GetUnfinishedResults(*db, "", 10)
+			Expect(err).To(BeNil())
+			Expect(len(*fetched)).To(Equal(2))
+			Expect((*fetched)[0].TimeStart).To(Equal(int64(4)))
+			Expect((*fetched)[1].TimeStart).To(Equal(int64(2)))
+		})
+
+		Specify("Finished results returned ordered by the best time", func() {
+			fetched, err := result.GetLeaderboard(*db, "", 2)
+			Expect(err).To(BeNil())
+			Expect(len(*fetched)).To(Equal(2))
+			Expect((*fetched)[0].TimeStart).To(Equal(int64(3)))
+			Expect((*fetched)[1].TimeStart).To(Equal(int64(5)))
+		})
+
+		Specify("Last results of every category returned ordered by TimeStart", func() {
+			fetched, err := result.GetLastResultsPerCategory(*db, "", 1)
+			Expect(err).To(BeNil())
+			Expect(len(*fetched)).To(Equal(2))
+			Expect((*fetched)[0].TimeStart).To(Equal(int64(5)))
+			Expect((*fetched)[1].TimeStart).To(Equal(int64(2)))
+		})
+
+		When("results of the other event exist", func() {
+			BeforeEach(func() {
+				checkpointID = uuid.Must(uuid.NewV4())
+
+				err := db.Create(&checkpoint.Checkpoint{
+					ID:      checkpointID,
+					Name:    "Corridor2",
+					Event:   "Night Run",
+					Version: 1,
+				}).Error
+				Expect(err).To(BeNil())
+
+				createResult("M", 6, finish(10))
+				createResult("W", 7, nil)
+			})
+
+			Specify("Last results of the event returned only", func() {
+				fetched, err := result.GetLastResults(*db, "City Marathon", 10)
+				Expect(err).To(BeNil())
+				Expect(len(*fetched)).To(Equal(5))
+				Expect((*fetched)[0].TimeStart).To(Equal(int64(5)))
+
+				fetched, err = result.GetLastResults(*db, "Night Run", 10)
+				Expect(err).To(BeNil())
+				Expect(len(*fetched)).To(Equal(2))
+				Expect((*fetched)[0].TimeStart).To(Equal(int64(7)))
+				Expect((*fetched)[1].TimeStart).To(Equal(int64(6)))
+			})
+
+			Specify("Unfinished results of the event returned only", func() {
+				fetched, err := result.GetUnfinishedResults(*db, "City Marathon", 10)
+				Expect(err).To(BeNil())
+				Expect(len(*fetched)).To(Equal(2))
+				Expect((*fetched)[0].TimeStart).To(Equal(int64(4)))
+				Expect((*fetched)[1].TimeStart).To(Equal(int64(2)))
+			})
+
+			Specify("Leaderboard of the event returned only", func() {
+				fetched, err := result.GetLeaderboard(*db, "City Marathon", 2)
+				Expect(err).To(BeNil())
+				Expect(len(*fetched)).To(Equal(2))
+				Expect((*fetched)[0].TimeStart).To(Equal(int64(3)))
+				Expect((*fetched)[1].TimeStart).To(Equal(int64(5)))
+			})
+
+			Specify("Last results of every category of the event returned only", func() {
+				fetched, err := result.GetLastResultsPerCategory(*db, "Night Run", 1)
+				Expect(err).To(BeNil())
+				Expect(len(*fetched)).To(Equal(2))
+				Expect((*fetched)[0].TimeStart).To(Equal(int64(7)))
+				Expect((*fetched)[1].TimeStart).To(Equal(int64(6)))
+			})
+		})
+	})
 })
@@ -27,6 +27,7 @@ type ResultCreatedEvent struct {
 	CheckpointID         string   `protobuf:"bytes,2,opt,name=CheckpointID,proto3" json:"CheckpointID,omitempty"`
 	SportsmenID          string   `protobuf:"bytes,3,opt,name=SportsmenID,proto3" json:"SportsmenID,omitempty"`
 	TimeStart            int64    `protobuf:"varint,4,opt,name=TimeStart,proto3" json:"TimeStart,omitempty"`
+	DeviceID             string   `protobuf:"bytes,5,opt,name=DeviceID,proto3" json:"DeviceID,omitempty"`
 	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -94,6 +95,13 @@ func (m *ResultCreatedEvent) GetTimeStart() int64 {
 	return 0
 }
 
+func (m *ResultCreatedEvent) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
 func (m *ResultCreatedEvent) GetVersion() uint32 {
 	if m != nil {
 		return m.Version
@@ -104,6 +112,7 @@ func (m *ResultCreatedEvent) GetVersion() uint32 {
 type ResultFinishedEvent struct {
 	ResultID             string   `protobuf:"bytes,1,opt,name=ResultID,proto3" json:"ResultID,omitempty"`
 	TimeFinish           int64    `protobuf:"varint,2,opt,name=TimeFinish,proto3" json:"TimeFinish,omitempty"`
+	DeviceID             string   `protobuf:"bytes,3,opt,name=DeviceID,proto3" json:"DeviceID,omitempty"`
 	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -157,6 +166,13 @@ func (m *ResultFinishedEvent) GetTimeFinish() int64 {
 	return 0
 }
 
+func (m *ResultFinishedEvent) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
 func (m *ResultFinishedEvent) GetVersion() uint32 {
 	if m != nil {
 		return m.Version
@@ -172,21 +188,22 @@ func init() {
 func init() { proto.RegisterFile("result.proto", fileDescriptor_4feee897733d2100) }
 
 var fileDescriptor_4feee897733d2100 = []byte{
-	// 215 bytes of a gzipped FileDescriptorProto
+	// 233 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0x4a, 0x2d, 0x2e,
-	0xcd, 0x29, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0x94, 0xd6, 0x33, 0x72,
+	0xcd, 0x29, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0, 0x94, 0x4e, 0x33, 0x72,
 	0x09, 0x05, 0x81, 0x99, 0xce, 0x45, 0xa9, 0x89, 0x25, 0xa9, 0x29, 0xae, 0x65, 0xa9, 0x79, 0x25,
 	0x42, 0x52, 0x5c, 0x1c, 0x10, 0x51, 0x4f, 0x17, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xce, 0x20, 0x38,
 	0x5f, 0x48, 0x89, 0x8b, 0xc7, 0x39, 0x23, 0x35, 0x39, 0xbb, 0x20, 0x3f, 0x33, 0x0f, 0x24, 0xcf,
 	0x04, 0x96, 0x47, 0x11, 0x13, 0x52, 0xe0, 0xe2, 0x0e, 0x2e, 0xc8, 0x2f, 0x2a, 0x29, 0xce, 0x4d,
 	0xcd, 0xf3, 0x74, 0x91, 0x60, 0x06, 0x2b, 0x41, 0x16, 0x12, 0x92, 0xe1, 0xe2, 0x0c, 0xc9, 0xcc,
-	0x4d, 0x0d, 0x2e, 0x49, 0x2c, 0x2a, 0x91, 0x60, 0x51, 0x60, 0xd4, 0x60, 0x0e, 0x42, 0x08, 0x08,
-	0x49, 0x72, 0xb1, 0x87, 0xa5, 0x16, 0x15, 0x67, 0xe6, 0xe7, 0x49, 0xfc, 0x07, 0xd9, 0xcf, 0x1b,
-	0x04, 0xe3, 0x2b, 0xe5, 0x70, 0x09, 0x43, 0x9c, 0xe2, 0x96, 0x99, 0x97, 0x59, 0x9c, 0x41, 0x8c,
-	0x8b, 0xe5, 0xb8, 0xb8, 0x40, 0x46, 0x43, 0x34, 0x80, 0xdd, 0xcb, 0x1c, 0x84, 0x24, 0x82, 0xc7,
-	0x36, 0x27, 0x81, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71,
-	0xc6, 0x63, 0x39, 0x86, 0x24, 0x36, 0x70, 0x00, 0x1a, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xc8,
-	0xed, 0x4d, 0xd8, 0x50, 0x01, 0x00, 0x00,
+	0x4d, 0x0d, 0x2e, 0x49, 0x2c, 0x2a, 0x91, 0x60, 0x51, 0x60, 0xd4, 0x60, 0x0e, 0x42, 0x08, 0x80,
+	0xec, 0x77, 0x49, 0x2d, 0xcb, 0x4c, 0x4e, 0xf5, 0x74, 0x91, 0x60, 0x85, 0xd8, 0x0f, 0xe3, 0x0b,
+	0x49, 0x72, 0xb1, 0x87, 0xa5, 0x16, 0x15, 0x67, 0xe6, 0xe7, 0x49, 0xfc, 0x07, 0xb9, 0x8d, 0x37,
+	0x08, 0xc6, 0x57, 0xea, 0x60, 0xe4, 0x12, 0x86, 0xb8, 0xd3, 0x2d, 0x33, 0x2f, 0xb3, 0x38, 0x83,
+	0x18, 0xef, 0xc8, 0x71, 0x71, 0x81, 0xec, 0x85, 0x68, 0x00, 0x7b, 0x86, 0x39, 0x08, 0x49, 0x04,
+	0xc5, 0x29, 0xcc, 0x44, 0x3b, 0xc5, 0x49, 0xe0, 0xc4, 0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18,
+	0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf1, 0x58, 0x8e, 0x21, 0x89, 0x0d, 0x1c, 0xf2, 0xc6, 0x80, 0x01,
+	0x00, 0x81, 0x95, 0xf2, 0x3d, 0x89, 0x01, 0x00, 0x00,
 }
 
 func (m *ResultCreatedEvent) Marshal() (dAtA []byte, err error) {
@@ -220,6 +237,13 @@ func (m *ResultCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xf8
 	}
+	if len(m.DeviceID) > 0 {
+		i -= len(m.DeviceID)
+		copy(dAtA[i:], m.DeviceID)
+		i = encodeVarintResult(dAtA, i, uint64(len(m.DeviceID)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.TimeStart != 0 {
 		i = encodeVarintResult(dAtA, i, uint64(m.TimeStart))
 		i--
@@ -280,6 +304,13 @@ func (m *ResultFinishedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xf8
 	}
+	if len(m.DeviceID) > 0 {
+		i -= len(m.DeviceID)
+		copy(dAtA[i:], m.DeviceID)
+		i = encodeVarintResult(dAtA, i, uint64(len(m.DeviceID)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.TimeFinish != 0 {
 		i = encodeVarintResult(dAtA, i, uint64(m.TimeFinish))
 		i--
@@ -327,6 +358,10 @@ func (m *ResultCreatedEvent) Size() (n int) {
 	if m.TimeStart != 0 {
 		n += 1 + sovResult(uint64(m.TimeStart))
 	}
+	l = len(m.DeviceID)
+	if l > 0 {
+		n += 1 + l + sovResult(uint64(l))
+	}
 	if m.Version != 0 {
 		n += 2 + sovResult(uint64(m.Version))
 	}
@@ -349,6 +384,10 @@ func (m *ResultFinishedEvent) Size() (n int) {
 	if m.TimeFinish != 0 {
 		n += 1 + sovResult(uint64(m.TimeFinish))
 	}
+	l = len(m.DeviceID)
+	if l > 0 {
+		n += 1 + l + sovResult(uint64(l))
+	}
 	if m.Version != 0 {
 		n += 2 + sovResult(uint64(m.Version))
 	}
@@ -508,6 +547,38 @@ func (m *ResultCreatedEvent) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResult
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResult
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResult
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 255:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
@@ -629,6 +700,38 @@ func (m *ResultFinishedEvent) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowResult
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthResult
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthResult
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 255:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
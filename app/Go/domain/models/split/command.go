@@ -0,0 +1,87 @@
+package split
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/transaction"
+)
+
+// Create a new split time.
+func Create(db gorm.DB, pendingSplit PendingSplit) (*SplitCreatedEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingSplit,
+		validation.Field(&pendingSplit.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingSplit.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingSplit.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingSplit.Time, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	newSplit := Split{
+		ID:           pendingSplit.ID,
+		CheckpointID: pendingSplit.CheckpointID,
+		SportsmenID:  pendingSplit.SportsmenID,
+		Time:         pendingSplit.Time,
+		DeviceID:     pendingSplit.DeviceID,
+		Version:      1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		err := tx.Model(&checkpoint.Checkpoint{}).Where(
+			"id = ?",
+			pendingSplit.CheckpointID,
+		).Take(&checkpoint.Checkpoint{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return checkpoint.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		err = tx.Model(&sportsmen.Sportsmen{}).Where(
+			"id = ?",
+			pendingSplit.SportsmenID,
+		).Take(&sportsmen.Sportsmen{}).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return sportsmen.NotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		// The unique index rejects the second split of the sportsmen at the checkpoint, including the concurrent one.
+		if err := tx.Create(&Split{
+			ID:           newSplit.ID,
+			CheckpointID: newSplit.CheckpointID,
+			SportsmenID:  newSplit.SportsmenID,
+			Time:         newSplit.Time,
+			DeviceID:     newSplit.DeviceID,
+			Version:      newSplit.Version,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := &SplitCreatedEvent{
+		SplitID:      newSplit.ID.String(),
+		CheckpointID: newSplit.CheckpointID.String(),
+		SportsmenID:  newSplit.SportsmenID.String(),
+		Time:         newSplit.Time,
+		Version:      newSplit.Version,
+	}
+
+	if newSplit.DeviceID != nil {
+		event.DeviceID = newSplit.DeviceID.String()
+	}
+
+	return event, nil
+}
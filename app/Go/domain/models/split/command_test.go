@@ -0,0 +1,123 @@
+package split_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/split"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing splits", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var pendingSplit split.PendingSplit
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingCheckpoint := checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "10 km",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+
+		pendingSportsmen := sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			StartNumber: 101,
+		}
+
+		_, err = sportsmen.Create(*db, pendingSportsmen)
+		Expect(err).To(BeNil())
+
+		pendingSplit = split.PendingSplit{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: pendingCheckpoint.ID,
+			SportsmenID:  pendingSportsmen.ID,
+			Time:         utils.MakeTimestampInMilliseconds(),
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Recording a split time", func() {
+		When("the split is recorded", func() {
+			Specify("the returned event", func() {
+				event, err := split.Create(*db, pendingSplit)
+				Expect(err).To(BeNil())
+
+				Expect(event).To(Equal(&split.SplitCreatedEvent{
+					SplitID:      pendingSplit.ID.String(),
+					CheckpointID: pendingSplit.CheckpointID.String(),
+					SportsmenID:  pendingSplit.SportsmenID.String(),
+					Time:         pendingSplit.Time,
+					Version:      1,
+				}))
+			})
+
+			Specify("the split is persisted in the database", func() {
+				_, err := split.Create(*db, pendingSplit)
+				Expect(err).To(BeNil())
+
+				splits, err := split.GetSplits(*db, pendingSplit.SportsmenID)
+				Expect(err).To(BeNil())
+				Expect(*splits).To(HaveLen(1))
+				Expect((*splits)[0].ID).To(Equal(pendingSplit.ID))
+				Expect((*splits)[0].Time).To(Equal(pendingSplit.Time))
+			})
+		})
+
+		When("the split of the checkpoint exists already", func() {
+			Specify("the error returned", func() {
+				_, err := split.Create(*db, pendingSplit)
+				Expect(err).To(BeNil())
+
+				pendingSplit.ID = uuid.Must(uuid.NewV4())
+				_, err = split.Create(*db, pendingSplit)
+				Expect(errors.As(err, &split.AlreadyExists{})).To(BeTrue())
+			})
+		})
+
+		When("the sportsmen does not exist", func() {
+			Specify("the error returned", func() {
+				pendingSplit.SportsmenID = uuid.Must(uuid.NewV4())
+
+				_, err := split.Create(*db, pendingSplit)
+				Expect(errors.As(err, &sportsmen.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,10 @@
+package split
+
+type (
+	// AlreadyExists signifies the split time of the sportsmen at the checkpoint has been recorded already.
+	AlreadyExists struct{}
+)
+
+func (err AlreadyExists) Error() string {
+	return "Split already exists"
+}
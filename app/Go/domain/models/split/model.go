@@ -0,0 +1,25 @@
+package split
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Split represents a persistence model for the intermediate time of the sportsmen passing the checkpoint.
+type Split struct {
+	ID           uuid.UUID  `gorm:"primary_key" json:"id"`
+	CheckpointID uuid.UUID  `gorm:"not null;unique_index:idx_split_checkpoint_sportsmen" json:"checkpoint_id"`
+	SportsmenID  uuid.UUID  `gorm:"not null;unique_index:idx_split_checkpoint_sportsmen" json:"sportsmen_id"`
+	Time         int64      `gorm:"not null" json:"time"`
+	DeviceID     *uuid.UUID `gorm:"type:uuid" json:"device_id"`
+	CreatedAt    int64      `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version      uint32     `gorm:"not null" json:"version"`
+}
+
+// PendingSplit represents a split time about to record.
+type PendingSplit struct {
+	ID           uuid.UUID  `json:"id"`
+	CheckpointID uuid.UUID  `json:"checkpoint_id"`
+	SportsmenID  uuid.UUID  `json:"sportsmen_id"`
+	Time         int64      `json:"time"`
+	DeviceID     *uuid.UUID `json:"device_id"`
+}
@@ -0,0 +1,19 @@
+package split
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+)
+
+// GetSplits fetches the split times of the sportsmen, the earliest split comes first.
+func GetSplits(db gorm.DB, sportsmenID uuid.UUID) (*[]Split, error) {
+	var splits []Split
+
+	err := db.Where("sportsmen_id = ?", sportsmenID).Order("time asc").Find(&splits).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading splits: %w", err)
+	}
+
+	return &splits, nil
+}
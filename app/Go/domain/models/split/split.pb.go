@@ -0,0 +1,553 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: split.proto
+
+package split
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SplitCreatedEvent struct {
+	SplitID              string   `protobuf:"bytes,1,opt,name=SplitID,proto3" json:"SplitID,omitempty"`
+	CheckpointID         string   `protobuf:"bytes,2,opt,name=CheckpointID,proto3" json:"CheckpointID,omitempty"`
+	SportsmenID          string   `protobuf:"bytes,3,opt,name=SportsmenID,proto3" json:"SportsmenID,omitempty"`
+	Time                 int64    `protobuf:"varint,4,opt,name=Time,proto3" json:"Time,omitempty"`
+	DeviceID             string   `protobuf:"bytes,5,opt,name=DeviceID,proto3" json:"DeviceID,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SplitCreatedEvent) Reset()         { *m = SplitCreatedEvent{} }
+func (m *SplitCreatedEvent) String() string { return proto.CompactTextString(m) }
+func (*SplitCreatedEvent) ProtoMessage()    {}
+func (*SplitCreatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9b30d6da5b8db9fc, []int{0}
+}
+func (m *SplitCreatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SplitCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SplitCreatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SplitCreatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SplitCreatedEvent.Merge(m, src)
+}
+func (m *SplitCreatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SplitCreatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SplitCreatedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SplitCreatedEvent proto.InternalMessageInfo
+
+func (m *SplitCreatedEvent) GetSplitID() string {
+	if m != nil {
+		return m.SplitID
+	}
+	return ""
+}
+
+func (m *SplitCreatedEvent) GetCheckpointID() string {
+	if m != nil {
+		return m.CheckpointID
+	}
+	return ""
+}
+
+func (m *SplitCreatedEvent) GetSportsmenID() string {
+	if m != nil {
+		return m.SportsmenID
+	}
+	return ""
+}
+
+func (m *SplitCreatedEvent) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *SplitCreatedEvent) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
+func (m *SplitCreatedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SplitCreatedEvent)(nil), "split.SplitCreatedEvent")
+}
+
+func init() { proto.RegisterFile("split.proto", fileDescriptor_9b30d6da5b8db9fc) }
+
+var fileDescriptor_9b30d6da5b8db9fc = []byte{
+	// 193 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x2e, 0xc8, 0xc9,
+	0x2c, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x73, 0x94, 0xf6, 0x31, 0x72, 0x09,
+	0x06, 0x83, 0x58, 0xce, 0x45, 0xa9, 0x89, 0x25, 0xa9, 0x29, 0xae, 0x65, 0xa9, 0x79, 0x25, 0x42,
+	0x12, 0x5c, 0xec, 0x60, 0x41, 0x4f, 0x17, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xce, 0x20, 0x18, 0x57,
+	0x48, 0x89, 0x8b, 0xc7, 0x39, 0x23, 0x35, 0x39, 0xbb, 0x20, 0x3f, 0x33, 0x0f, 0x24, 0xcd, 0x04,
+	0x96, 0x46, 0x11, 0x13, 0x52, 0xe0, 0xe2, 0x0e, 0x2e, 0xc8, 0x2f, 0x2a, 0x29, 0xce, 0x4d, 0xcd,
+	0xf3, 0x74, 0x91, 0x60, 0x06, 0x2b, 0x41, 0x16, 0x12, 0x12, 0xe2, 0x62, 0x09, 0xc9, 0xcc, 0x4d,
+	0x95, 0x60, 0x51, 0x60, 0xd4, 0x60, 0x0e, 0x02, 0xb3, 0x85, 0xa4, 0xb8, 0x38, 0x5c, 0x52, 0xcb,
+	0x32, 0x93, 0x53, 0x3d, 0x5d, 0x24, 0x58, 0xc1, 0x5a, 0xe0, 0x7c, 0x21, 0x49, 0x2e, 0xf6, 0xb0,
+	0xd4, 0xa2, 0xe2, 0xcc, 0xfc, 0x3c, 0x89, 0xff, 0x20, 0x07, 0xf1, 0x06, 0xc1, 0xf8, 0x4e, 0x02,
+	0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x8c, 0xc7, 0x72,
+	0x0c, 0x49, 0x6c, 0x60, 0x0f, 0x1a, 0x03, 0x06, 0x00, 0x4f, 0xe4, 0x92, 0x9b, 0xef, 0x00, 0x00,
+	0x00,
+}
+
+func (m *SplitCreatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SplitCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SplitCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintSplit(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if len(m.DeviceID) > 0 {
+		i -= len(m.DeviceID)
+		copy(dAtA[i:], m.DeviceID)
+		i = encodeVarintSplit(dAtA, i, uint64(len(m.DeviceID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Time != 0 {
+		i = encodeVarintSplit(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.SportsmenID) > 0 {
+		i -= len(m.SportsmenID)
+		copy(dAtA[i:], m.SportsmenID)
+		i = encodeVarintSplit(dAtA, i, uint64(len(m.SportsmenID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.CheckpointID) > 0 {
+		i -= len(m.CheckpointID)
+		copy(dAtA[i:], m.CheckpointID)
+		i = encodeVarintSplit(dAtA, i, uint64(len(m.CheckpointID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SplitID) > 0 {
+		i -= len(m.SplitID)
+		copy(dAtA[i:], m.SplitID)
+		i = encodeVarintSplit(dAtA, i, uint64(len(m.SplitID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSplit(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSplit(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SplitCreatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SplitID)
+	if l > 0 {
+		n += 1 + l + sovSplit(uint64(l))
+	}
+	l = len(m.CheckpointID)
+	if l > 0 {
+		n += 1 + l + sovSplit(uint64(l))
+	}
+	l = len(m.SportsmenID)
+	if l > 0 {
+		n += 1 + l + sovSplit(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovSplit(uint64(m.Time))
+	}
+	l = len(m.DeviceID)
+	if l > 0 {
+		n += 1 + l + sovSplit(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 2 + sovSplit(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovSplit(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSplit(x uint64) (n int) {
+	return sovSplit(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SplitCreatedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSplit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SplitCreatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SplitCreatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSplit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSplit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SplitID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSplit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSplit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CheckpointID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SportsmenID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSplit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSplit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SportsmenID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSplit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSplit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSplit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSplit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSplit(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSplit
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSplit
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSplit
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSplit
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSplit
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSplit        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSplit          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSplit = fmt.Errorf("proto: unexpected end of group")
+)
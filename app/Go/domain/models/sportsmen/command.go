@@ -1,13 +1,20 @@
 package sportsmen
 
 import (
+	"fmt"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/transaction"
+	"strings"
 )
 
 // Create a new sportsmen.
 func Create(db gorm.DB, pendingSportsmen PendingSportsmen) (*SportsmenCreatedEvent, error) {
+	pendingSportsmen.Category = strings.TrimSpace(pendingSportsmen.Category)
+
 	if err := validation.ValidateStruct(
 		&pendingSportsmen,
 		validation.Field(&pendingSportsmen.ID, validation.Required, is.UUIDv4),
@@ -23,16 +30,21 @@ func Create(db gorm.DB, pendingSportsmen PendingSportsmen) (*SportsmenCreatedEve
 		StartNumber: pendingSportsmen.StartNumber,
 		FirstName:   pendingSportsmen.FirstName,
 		LastName:    pendingSportsmen.LastName,
+		Category:    pendingSportsmen.Category,
 		Version:     1,
 	}
 
-	if err := db.Create(&Sportsmen{
-		ID:          newSportsmen.ID,
-		StartNumber: newSportsmen.StartNumber,
-		FirstName:   newSportsmen.FirstName,
-		LastName:    newSportsmen.LastName,
-		Version:     newSportsmen.Version,
-	}).Error; err != nil {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		return tx.Create(&Sportsmen{
+			ID:          newSportsmen.ID,
+			StartNumber: newSportsmen.StartNumber,
+			FirstName:   newSportsmen.FirstName,
+			LastName:    newSportsmen.LastName,
+			Category:    newSportsmen.Category,
+			Version:     newSportsmen.Version,
+		}).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -41,6 +53,43 @@ func Create(db gorm.DB, pendingSportsmen PendingSportsmen) (*SportsmenCreatedEve
 		StartNumber: newSportsmen.StartNumber,
 		FirstName:   newSportsmen.FirstName,
 		LastName:    newSportsmen.LastName,
+		Category:    newSportsmen.Category,
 		Version:     newSportsmen.Version,
 	}, nil
 }
+
+// SetStatus changes the race status of the sportsmen, e.g. marks the sportsmen did not finish.
+func SetStatus(db gorm.DB, status string, sportsmen Sportsmen) (*SportsmenStatusChangedEvent, error) {
+	if err := validation.Validate(
+		status,
+		validation.In(StatusDidNotStart, StatusDidNotFinish, StatusDisqualified),
+	); err != nil {
+		return nil, validation.Errors{"status": err}
+	}
+
+	event := &SportsmenStatusChangedEvent{
+		SportsmenID: sportsmen.ID.String(),
+		Status:      status,
+		Version:     sportsmen.Version + 1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Sportsmen{}).
+			Where("id = ? AND version = ?",
+				sportsmen.ID,
+				sportsmen.Version,
+			).Updates(map[string]interface{}{"status": status, "version": sportsmen.Version + 1})
+		if result.Error != nil {
+			return fmt.Errorf("Error changing the sportsmen status: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return outbox.AppendEvent(tx, TopicStatusChanged, sportsmen.ID, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -85,4 +85,42 @@ var _ = Describe("Managing sportsmens", func() {
 			})
 		})
 	})
+
+	Describe("Changing the sportsmen status", func() {
+		var created *sportsmen.Sportsmen
+
+		BeforeEach(func() {
+			pendingSportsmen := sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+			}
+
+			_, err := sportsmen.Create(*db, pendingSportsmen)
+			Expect(err).To(BeNil())
+
+			created, err = sportsmen.GetSportsmen(*db, pendingSportsmen.ID, nil)
+			Expect(err).To(BeNil())
+		})
+
+		When("the sportsmen did not finish", func() {
+			Specify("the status is persisted in the database", func() {
+				event, err := sportsmen.SetStatus(*db, sportsmen.StatusDidNotFinish, *created)
+				Expect(err).To(BeNil())
+				Expect(event.Version).To(Equal(uint32(2)))
+
+				fetched, err := sportsmen.GetSportsmen(*db, created.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(fetched.Status).To(Equal(sportsmen.StatusDidNotFinish))
+			})
+		})
+
+		When("the status is unknown", func() {
+			Specify("the error returned", func() {
+				_, err := sportsmen.SetStatus(*db, "retired", *created)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
 })
@@ -4,12 +4,27 @@ import (
 	"github.com/gofrs/uuid"
 )
 
+// Race statuses of the sportsmen, the empty status means the sportsmen races as usual.
+const (
+	StatusNone         = ""
+	StatusDidNotStart  = "dns"
+	StatusDidNotFinish = "dnf"
+	StatusDisqualified = "dsq"
+)
+
+// Outbox topics of the sportsmen events.
+const (
+	TopicStatusChanged = "sportsmen.status_changed"
+)
+
 // Sportsmen represents a persistence model for the sportsmen entity.
 type Sportsmen struct {
 	ID          uuid.UUID `gorm:"primary_key" json:"id"`
 	StartNumber uint32    `gorm:"not null" json:"start_number"`
 	FirstName   string    `gorm:"not null" json:"first_name"`
 	LastName    string    `gorm:"not null" json:"last_name"`
+	Category    string    `gorm:"not null;default:''" json:"category"`
+	Status      string    `gorm:"not null;default:''" json:"status"`
 	CreatedAt   int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
 	Version     uint32    `gorm:"not null" json:"version"`
 }
@@ -20,4 +35,5 @@ type PendingSportsmen struct {
 	StartNumber uint32    `gorm:"not null" json:"start_number"`
 	FirstName   string    `gorm:"not null" json:"first_name"`
 	LastName    string    `gorm:"not null" json:"last_name"`
+	Category    string    `json:"category"`
 }
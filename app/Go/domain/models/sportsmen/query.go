@@ -25,6 +25,8 @@ func GetSportsmen(db gorm.DB, pk uuid.UUID, version *uint32) (*Sportsmen, error)
 		StartNumber: sportsmen.StartNumber,
 		FirstName:   sportsmen.FirstName,
 		LastName:    sportsmen.LastName,
+		Category:    sportsmen.Category,
+		Status:      sportsmen.Status,
 		CreatedAt:   sportsmen.CreatedAt,
 		Version:     sportsmen.Version,
 	}, nil
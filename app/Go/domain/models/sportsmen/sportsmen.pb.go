@@ -27,6 +27,7 @@ type SportsmenCreatedEvent struct {
 	StartNumber          uint32   `protobuf:"varint,2,opt,name=StartNumber,proto3" json:"StartNumber,omitempty"`
 	FirstName            string   `protobuf:"bytes,3,opt,name=FirstName,proto3" json:"FirstName,omitempty"`
 	LastName             string   `protobuf:"bytes,4,opt,name=LastName,proto3" json:"LastName,omitempty"`
+	Category             string   `protobuf:"bytes,5,opt,name=Category,proto3" json:"Category,omitempty"`
 	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -94,6 +95,13 @@ func (m *SportsmenCreatedEvent) GetLastName() string {
 	return ""
 }
 
+func (m *SportsmenCreatedEvent) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
 func (m *SportsmenCreatedEvent) GetVersion() uint32 {
 	if m != nil {
 		return m.Version
@@ -101,25 +109,92 @@ func (m *SportsmenCreatedEvent) GetVersion() uint32 {
 	return 0
 }
 
+type SportsmenStatusChangedEvent struct {
+	SportsmenID          string   `protobuf:"bytes,1,opt,name=SportsmenID,proto3" json:"SportsmenID,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SportsmenStatusChangedEvent) Reset()         { *m = SportsmenStatusChangedEvent{} }
+func (m *SportsmenStatusChangedEvent) String() string { return proto.CompactTextString(m) }
+func (*SportsmenStatusChangedEvent) ProtoMessage()    {}
+func (*SportsmenStatusChangedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9830e3586cd45bd4, []int{1}
+}
+func (m *SportsmenStatusChangedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SportsmenStatusChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SportsmenStatusChangedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SportsmenStatusChangedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SportsmenStatusChangedEvent.Merge(m, src)
+}
+func (m *SportsmenStatusChangedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SportsmenStatusChangedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SportsmenStatusChangedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SportsmenStatusChangedEvent proto.InternalMessageInfo
+
+func (m *SportsmenStatusChangedEvent) GetSportsmenID() string {
+	if m != nil {
+		return m.SportsmenID
+	}
+	return ""
+}
+
+func (m *SportsmenStatusChangedEvent) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SportsmenStatusChangedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*SportsmenCreatedEvent)(nil), "sportsmen.SportsmenCreatedEvent")
+	proto.RegisterType((*SportsmenStatusChangedEvent)(nil), "sportsmen.SportsmenStatusChangedEvent")
 }
 
 func init() { proto.RegisterFile("sportsmen.proto", fileDescriptor_9830e3586cd45bd4) }
 
 var fileDescriptor_9830e3586cd45bd4 = []byte{
-	// 176 bytes of a gzipped FileDescriptorProto
+	// 222 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0x2e, 0xc8, 0x2f,
 	0x2a, 0x29, 0xce, 0x4d, 0xcd, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x84, 0x0b, 0x28,
-	0x6d, 0x60, 0xe4, 0x12, 0x0d, 0x86, 0xf1, 0x9c, 0x8b, 0x52, 0x13, 0x4b, 0x52, 0x53, 0x5c, 0xcb,
+	0x9d, 0x61, 0xe4, 0x12, 0x0d, 0x86, 0xf1, 0x9c, 0x8b, 0x52, 0x13, 0x4b, 0x52, 0x53, 0x5c, 0xcb,
 	0x52, 0xf3, 0x4a, 0x84, 0x14, 0xb8, 0xb8, 0xe1, 0x12, 0x9e, 0x2e, 0x12, 0x8c, 0x0a, 0x8c, 0x1a,
 	0x9c, 0x41, 0xc8, 0x42, 0x60, 0x15, 0x25, 0x89, 0x45, 0x25, 0x7e, 0xa5, 0xb9, 0x49, 0xa9, 0x45,
 	0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0xbc, 0x41, 0xc8, 0x42, 0x42, 0x32, 0x5c, 0x9c, 0x6e, 0x99, 0x45,
 	0xc5, 0x25, 0x7e, 0x89, 0xb9, 0xa9, 0x12, 0xcc, 0x60, 0x13, 0x10, 0x02, 0x42, 0x52, 0x5c, 0x1c,
-	0x3e, 0x89, 0x50, 0x49, 0x16, 0xb0, 0x24, 0x9c, 0x2f, 0x24, 0xc9, 0xc5, 0x1e, 0x96, 0x5a, 0x54,
-	0x9c, 0x99, 0x9f, 0x27, 0xf1, 0x9f, 0x11, 0x6c, 0x30, 0x8c, 0xef, 0x24, 0x70, 0xe2, 0x91, 0x1c,
-	0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x90, 0xc4, 0x06,
-	0xf6, 0x96, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x3a, 0xe5, 0x0a, 0xea, 0xe9, 0x00, 0x00, 0x00,
+	0x3e, 0x89, 0x50, 0x49, 0x16, 0xb0, 0x24, 0x9c, 0x0f, 0x92, 0x73, 0x4e, 0x2c, 0x49, 0x4d, 0xcf,
+	0x2f, 0xaa, 0x94, 0x60, 0x85, 0xc8, 0xc1, 0xf8, 0x42, 0x92, 0x5c, 0xec, 0x61, 0xa9, 0x45, 0xc5,
+	0x99, 0xf9, 0x79, 0x12, 0xff, 0x19, 0xc1, 0x96, 0xc2, 0xf8, 0x4a, 0x45, 0x5c, 0xd2, 0x70, 0x17,
+	0x06, 0x97, 0x24, 0x96, 0x94, 0x16, 0x3b, 0x67, 0x24, 0xe6, 0xa5, 0x13, 0xef, 0x27, 0x31, 0x2e,
+	0x36, 0x88, 0x3e, 0xb0, 0x77, 0x38, 0x83, 0xa0, 0x3c, 0x3c, 0x76, 0x3a, 0x09, 0x9c, 0x78, 0x24,
+	0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x33, 0x1e, 0xcb, 0x31, 0x24, 0xb1,
+	0x81, 0x83, 0xd9, 0x18, 0x30, 0x00, 0x38, 0x51, 0xca, 0xc7, 0x79, 0x01, 0x00, 0x00,
 }
 
 func (m *SportsmenCreatedEvent) Marshal() (dAtA []byte, err error) {
@@ -153,6 +228,13 @@ func (m *SportsmenCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xf8
 	}
+	if len(m.Category) > 0 {
+		i -= len(m.Category)
+		copy(dAtA[i:], m.Category)
+		i = encodeVarintSportsmen(dAtA, i, uint64(len(m.Category)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.LastName) > 0 {
 		i -= len(m.LastName)
 		copy(dAtA[i:], m.LastName)
@@ -182,6 +264,54 @@ func (m *SportsmenCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SportsmenStatusChangedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SportsmenStatusChangedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SportsmenStatusChangedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintSportsmen(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if len(m.Status) > 0 {
+		i -= len(m.Status)
+		copy(dAtA[i:], m.Status)
+		i = encodeVarintSportsmen(dAtA, i, uint64(len(m.Status)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SportsmenID) > 0 {
+		i -= len(m.SportsmenID)
+		copy(dAtA[i:], m.SportsmenID)
+		i = encodeVarintSportsmen(dAtA, i, uint64(len(m.SportsmenID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintSportsmen(dAtA []byte, offset int, v uint64) int {
 	offset -= sovSportsmen(v)
 	base := offset
@@ -214,6 +344,33 @@ func (m *SportsmenCreatedEvent) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSportsmen(uint64(l))
 	}
+	l = len(m.Category)
+	if l > 0 {
+		n += 1 + l + sovSportsmen(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 2 + sovSportsmen(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SportsmenStatusChangedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SportsmenID)
+	if l > 0 {
+		n += 1 + l + sovSportsmen(uint64(l))
+	}
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovSportsmen(uint64(l))
+	}
 	if m.Version != 0 {
 		n += 2 + sovSportsmen(uint64(m.Version))
 	}
@@ -373,6 +530,172 @@ func (m *SportsmenCreatedEvent) Unmarshal(dAtA []byte) error {
 			}
 			m.LastName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Category", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSportsmen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Category = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSportsmen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSportsmen(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SportsmenStatusChangedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSportsmen
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SportsmenStatusChangedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SportsmenStatusChangedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SportsmenID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSportsmen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SportsmenID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSportsmen
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSportsmen
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 255:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
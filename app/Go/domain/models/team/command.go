@@ -0,0 +1,206 @@
+package team
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/transaction"
+	"strings"
+)
+
+// Maximum number of the legs of the team.
+const maxLegs = 100
+
+// Validate checks the leg assignment.
+func (l PendingLeg) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&l.CheckpointID, validation.Required, is.UUIDv4),
+	)
+}
+
+// Create a new relay team with the members assigned to the legs in the given order.
+func Create(db gorm.DB, pendingTeam PendingTeam) (*TeamCreatedEvent, error) {
+	pendingTeam.Name = strings.TrimSpace(pendingTeam.Name)
+	pendingTeam.Category = strings.TrimSpace(pendingTeam.Category)
+
+	if err := validation.ValidateStruct(
+		&pendingTeam,
+		validation.Field(&pendingTeam.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingTeam.Name, validation.Required),
+		validation.Field(&pendingTeam.Legs, validation.Required, validation.Length(1, maxLegs)),
+	); err != nil {
+		return nil, err
+	}
+
+	newTeam := Team{
+		ID:       pendingTeam.ID,
+		Name:     pendingTeam.Name,
+		Category: pendingTeam.Category,
+		Legs:     uint32(len(pendingTeam.Legs)),
+		Version:  1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		// The unique index rejects the second team of the name, including the concurrent one.
+		if err := tx.Create(&Team{
+			ID:       newTeam.ID,
+			Name:     newTeam.Name,
+			Category: newTeam.Category,
+			Legs:     newTeam.Legs,
+			Version:  newTeam.Version,
+		}).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyExists{}
+		} else if err != nil {
+			return err
+		}
+
+		for index, pendingLeg := range pendingTeam.Legs {
+			err := tx.Model(&checkpoint.Checkpoint{}).Where(
+				"id = ?",
+				pendingLeg.CheckpointID,
+			).Take(&checkpoint.Checkpoint{}).Error
+			if gorm.IsRecordNotFoundError(err) {
+				return checkpoint.NotFound{}
+			} else if err != nil {
+				return err
+			}
+
+			err = tx.Model(&sportsmen.Sportsmen{}).Where(
+				"id = ?",
+				pendingLeg.SportsmenID,
+			).Take(&sportsmen.Sportsmen{}).Error
+			if gorm.IsRecordNotFoundError(err) {
+				return sportsmen.NotFound{}
+			} else if err != nil {
+				return err
+			}
+
+			// The unique index rejects the sportsmen running the second leg at the checkpoint,
+			// the result of the sportsmen at the checkpoint would not tell the legs apart.
+			if err := tx.Create(&Leg{
+				ID:           uuid.Must(uuid.NewV4()),
+				TeamID:       newTeam.ID,
+				Number:       uint32(index + 1),
+				SportsmenID:  pendingLeg.SportsmenID,
+				CheckpointID: pendingLeg.CheckpointID,
+				Version:      1,
+			}).Error; transaction.IsUniqueViolation(err) {
+				return LegAlreadyAssigned{}
+			} else if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamCreatedEvent{
+		TeamID:   newTeam.ID.String(),
+		Name:     newTeam.Name,
+		Category: newTeam.Category,
+		Legs:     newTeam.Legs,
+		Version:  newTeam.Version,
+	}, nil
+}
+
+// Handover finishes the leg of the sportsmen at the handover checkpoint and starts the next leg
+// at the same time, the handover of the last leg finishes the team.
+func Handover(db gorm.DB, pendingHandover PendingHandover) (*TeamHandedOverEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingHandover,
+		validation.Field(&pendingHandover.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingHandover.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingHandover.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingHandover.Time, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	var event *TeamHandedOverEvent
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		legFetched := Leg{}
+		err := tx.Where(
+			"checkpoint_id = ? AND sportsmen_id = ?",
+			pendingHandover.CheckpointID,
+			pendingHandover.SportsmenID,
+		).Take(&legFetched).Error
+		if gorm.IsRecordNotFoundError(err) {
+			return LegNotFound{}
+		} else if err != nil {
+			return err
+		}
+
+		teamFetched := Team{}
+		err = tx.Where("id = ?", legFetched.TeamID).Take(&teamFetched).Error
+		if err != nil {
+			return err
+		}
+
+		resultUnfinished, err := result.GetUnfinishedResult(tx, legFetched.CheckpointID, legFetched.SportsmenID, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = result.AddFinishTimeByDevice(tx, pendingHandover.Time, pendingHandover.DeviceID, *resultUnfinished)
+		if err != nil {
+			return err
+		}
+
+		event = &TeamHandedOverEvent{
+			TeamID:      teamFetched.ID.String(),
+			Leg:         legFetched.Number,
+			ResultID:    resultUnfinished.ID.String(),
+			SportsmenID: legFetched.SportsmenID.String(),
+			Time:        pendingHandover.Time,
+			Finished:    legFetched.Number >= teamFetched.Legs,
+			Version:     teamFetched.Version,
+		}
+
+		if pendingHandover.DeviceID != nil {
+			event.DeviceID = pendingHandover.DeviceID.String()
+		}
+
+		if !event.Finished {
+			nextLeg := Leg{}
+			err = tx.Where(
+				"team_id = ? AND number = ?",
+				teamFetched.ID,
+				legFetched.Number+1,
+			).Take(&nextLeg).Error
+			if err != nil {
+				return err
+			}
+
+			_, err = result.Create(tx, result.PendingResult{
+				ID:           pendingHandover.ID,
+				CheckpointID: nextLeg.CheckpointID,
+				SportsmenID:  nextLeg.SportsmenID,
+				TimeStart:    pendingHandover.Time,
+				DeviceID:     pendingHandover.DeviceID,
+			})
+			if err != nil {
+				return err
+			}
+
+			event.NextResultID = pendingHandover.ID.String()
+			event.NextSportsmenID = nextLeg.SportsmenID.String()
+		}
+
+		return outbox.AppendEvent(tx, TopicHandedOver, teamFetched.ID, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,311 @@
+package team_test
+
+import (
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing relay teams", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	const (
+		timeStart = int64(1600000000000)
+		minute    = int64(60 * 1000)
+	)
+
+	var (
+		stadium, road uuid.UUID
+		pendingTeam   team.PendingTeam
+		members       []uuid.UUID
+		startNumber   uint32
+	)
+
+	// member creates the sportsmen to assign to the leg.
+	member := func() uuid.UUID {
+		startNumber++
+		pendingSportsmen := sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			StartNumber: startNumber,
+		}
+
+		_, err := sportsmen.Create(*db, pendingSportsmen)
+		Expect(err).To(BeNil())
+
+		return pendingSportsmen.ID
+	}
+
+	// newCheckpoint creates the checkpoint timing the legs.
+	newCheckpoint := func(name string) uuid.UUID {
+		pendingCheckpoint := checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: name,
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+
+		return pendingCheckpoint.ID
+	}
+
+	// newTeam defines the team of three legs, the first two legs are run in the stadium and the last one on the road.
+	newTeam := func(name string) (team.PendingTeam, []uuid.UUID) {
+		members := []uuid.UUID{member(), member(), member()}
+
+		return team.PendingTeam{
+			ID:       uuid.Must(uuid.NewV4()),
+			Name:     name,
+			Category: "Mixed",
+			Legs: []team.PendingLeg{
+				{SportsmenID: members[0], CheckpointID: stadium},
+				{SportsmenID: members[1], CheckpointID: stadium},
+				{SportsmenID: members[2], CheckpointID: road},
+			},
+		}, members
+	}
+
+	// start starts the first leg of the team.
+	start := func(sportsmenID uuid.UUID, time int64) {
+		_, err := result.Create(*db, result.PendingResult{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: stadium,
+			SportsmenID:  sportsmenID,
+			TimeStart:    time,
+		})
+		Expect(err).To(BeNil())
+	}
+
+	// handover ends the leg of the sportsmen at the checkpoint.
+	handover := func(checkpointID, sportsmenID uuid.UUID, time int64) (*team.TeamHandedOverEvent, error) {
+		return team.Handover(*db, team.PendingHandover{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: checkpointID,
+			SportsmenID:  sportsmenID,
+			Time:         time,
+		})
+	}
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		startNumber = 700
+
+		stadium = newCheckpoint("Stadium")
+		road = newCheckpoint("Road")
+
+		pendingTeam, members = newTeam("Harriers")
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Creating a team", func() {
+		When("the team is created", func() {
+			Specify("the legs are numbered in the given order", func() {
+				event, err := team.Create(*db, pendingTeam)
+				Expect(err).To(BeNil())
+				Expect(event).To(Equal(&team.TeamCreatedEvent{
+					TeamID:   pendingTeam.ID.String(),
+					Name:     pendingTeam.Name,
+					Category: pendingTeam.Category,
+					Legs:     3,
+					Version:  1,
+				}))
+
+				legResults, err := team.GetLegResults(*db, []uuid.UUID{pendingTeam.ID})
+				Expect(err).To(BeNil())
+				Expect(*legResults).To(HaveLen(3))
+				Expect((*legResults)[0].SportsmenID).To(Equal(members[0]))
+				Expect((*legResults)[2].Number).To(Equal(uint32(3)))
+				Expect((*legResults)[2].CheckpointID).To(Equal(road))
+				Expect((*legResults)[2].ResultID).To(BeNil())
+			})
+		})
+
+		When("the team of the name exists already", func() {
+			Specify("the error returned", func() {
+				_, err := team.Create(*db, pendingTeam)
+				Expect(err).To(BeNil())
+
+				duplicate, _ := newTeam(pendingTeam.Name)
+				_, err = team.Create(*db, duplicate)
+				Expect(errors.As(err, &team.AlreadyExists{})).To(BeTrue())
+			})
+		})
+
+		When("the sportsmen runs a leg at the checkpoint already", func() {
+			Specify("the error returned", func() {
+				pendingTeam.Legs[1].SportsmenID = members[0]
+
+				_, err := team.Create(*db, pendingTeam)
+				Expect(errors.As(err, &team.LegAlreadyAssigned{})).To(BeTrue())
+			})
+		})
+
+		When("the team has no legs", func() {
+			Specify("the error returned", func() {
+				pendingTeam.Legs = nil
+
+				_, err := team.Create(*db, pendingTeam)
+				Expect(errors.As(err, &validation.Errors{})).To(BeTrue())
+			})
+		})
+
+		When("the sportsmen does not exist", func() {
+			Specify("the error returned", func() {
+				pendingTeam.Legs[2].SportsmenID = uuid.Must(uuid.NewV4())
+
+				_, err := team.Create(*db, pendingTeam)
+				Expect(errors.As(err, &sportsmen.NotFound{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Handing over", func() {
+		BeforeEach(func() {
+			_, err := team.Create(*db, pendingTeam)
+			Expect(err).To(BeNil())
+		})
+
+		When("the legs are handed over", func() {
+			Specify("the leg ends and the next leg starts at the handover time", func() {
+				start(members[0], timeStart)
+
+				event, err := handover(stadium, members[0], timeStart+10*minute)
+				Expect(err).To(BeNil())
+				Expect(event.Leg).To(Equal(uint32(1)))
+				Expect(event.NextSportsmenID).To(Equal(members[1].String()))
+				Expect(event.Finished).To(BeFalse())
+
+				nextResult, err := result.GetResult(*db, uuid.FromStringOrNil(event.NextResultID), nil)
+				Expect(err).To(BeNil())
+				Expect(nextResult.TimeStart).To(Equal(timeStart + 10*minute))
+				Expect(nextResult.CheckpointID).To(Equal(stadium))
+
+				_, err = handover(stadium, members[1], timeStart+22*minute)
+				Expect(err).To(BeNil())
+
+				event, err = handover(road, members[2], timeStart+30*minute)
+				Expect(err).To(BeNil())
+				Expect(event.Leg).To(Equal(uint32(3)))
+				Expect(event.Finished).To(BeTrue())
+				Expect(event.NextResultID).To(BeEmpty())
+
+				legResults, err := team.GetLegResults(*db, []uuid.UUID{pendingTeam.ID})
+				Expect(err).To(BeNil())
+				Expect(*(*legResults)[0].LegTime).To(Equal(10 * minute))
+				Expect(*(*legResults)[1].LegTime).To(Equal(12 * minute))
+				Expect(*(*legResults)[2].LegTime).To(Equal(8 * minute))
+
+				standing, err := team.GetStanding(*db, pendingTeam.ID)
+				Expect(err).To(BeNil())
+				Expect(standing.LegsFinished).To(Equal(uint32(3)))
+				Expect(standing.TotalTime).To(Equal(30 * minute))
+				Expect(*standing.TimeFinish).To(Equal(timeStart + 30*minute))
+			})
+		})
+
+		When("the sportsmen runs no leg at the checkpoint", func() {
+			Specify("the error returned", func() {
+				_, err := handover(road, members[0], timeStart+10*minute)
+				Expect(errors.As(err, &team.LegNotFound{})).To(BeTrue())
+			})
+		})
+
+		When("the leg has not started", func() {
+			Specify("the error returned", func() {
+				_, err := handover(stadium, members[0], timeStart+10*minute)
+				Expect(errors.As(err, &result.NotFound{})).To(BeTrue())
+			})
+		})
+
+		When("the leg has been handed over already", func() {
+			Specify("the error returned", func() {
+				start(members[0], timeStart)
+
+				_, err := handover(stadium, members[0], timeStart+10*minute)
+				Expect(err).To(BeNil())
+
+				_, err = handover(stadium, members[0], timeStart+11*minute)
+				Expect(errors.As(err, &result.AlreadyFinished{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Team rankings", func() {
+		Specify("the most legs finished come first and the same legs are ranked by the total time", func() {
+			_, err := team.Create(*db, pendingTeam)
+			Expect(err).To(BeNil())
+
+			second, secondMembers := newTeam("Athletics club")
+			_, err = team.Create(*db, second)
+			Expect(err).To(BeNil())
+
+			third, thirdMembers := newTeam("Joggers")
+			_, err = team.Create(*db, third)
+			Expect(err).To(BeNil())
+
+			// The first team finishes two legs, the second team two slower legs and the third team one leg.
+			start(members[0], timeStart)
+			_, err = handover(stadium, members[0], timeStart+10*minute)
+			Expect(err).To(BeNil())
+			_, err = handover(stadium, members[1], timeStart+20*minute)
+			Expect(err).To(BeNil())
+
+			start(secondMembers[0], timeStart)
+			_, err = handover(stadium, secondMembers[0], timeStart+11*minute)
+			Expect(err).To(BeNil())
+			_, err = handover(stadium, secondMembers[1], timeStart+22*minute)
+			Expect(err).To(BeNil())
+
+			start(thirdMembers[0], timeStart)
+			_, err = handover(stadium, thirdMembers[0], timeStart+9*minute)
+			Expect(err).To(BeNil())
+
+			standings, err := team.GetStandings(*db, 10)
+			Expect(err).To(BeNil())
+			Expect(*standings).To(HaveLen(3))
+
+			Expect((*standings)[0].TeamID).To(Equal(pendingTeam.ID))
+			Expect((*standings)[0].LegsFinished).To(Equal(uint32(2)))
+			Expect((*standings)[0].TotalTime).To(Equal(20 * minute))
+			Expect((*standings)[0].TimeFinish).To(BeNil())
+			Expect((*standings)[1].TeamID).To(Equal(second.ID))
+			Expect((*standings)[2].TeamID).To(Equal(third.ID))
+			Expect(*(*standings)[2].TimeStart).To(Equal(timeStart))
+		})
+	})
+})
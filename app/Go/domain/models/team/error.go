@@ -0,0 +1,31 @@
+package team
+
+type (
+	// AlreadyExists signifies the team of the name already exists.
+	AlreadyExists struct{}
+
+	// NotFound signifies a team is not found.
+	NotFound struct{}
+
+	// LegAlreadyAssigned signifies the sportsmen runs a leg at the checkpoint already.
+	LegAlreadyAssigned struct{}
+
+	// LegNotFound signifies the sportsmen runs no leg at the checkpoint.
+	LegNotFound struct{}
+)
+
+func (err AlreadyExists) Error() string {
+	return "Team already exists"
+}
+
+func (err NotFound) Error() string {
+	return "Team does not exist"
+}
+
+func (err LegAlreadyAssigned) Error() string {
+	return "Sportsmen is assigned to the leg at the checkpoint already"
+}
+
+func (err LegNotFound) Error() string {
+	return "Leg of the sportsmen at the checkpoint does not exist"
+}
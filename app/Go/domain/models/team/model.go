@@ -0,0 +1,85 @@
+package team
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// Outbox topics of the team events.
+const (
+	TopicHandedOver = "team.handed_over"
+)
+
+// Team represents a persistence model for the relay team, the team result is the sum of the results of its legs.
+type Team struct {
+	ID        uuid.UUID `gorm:"primary_key" json:"id"`
+	Name      string    `gorm:"not null;unique_index:idx_team_name" json:"name"`
+	Category  string    `gorm:"not null;default:''" json:"category"`
+	Legs      uint32    `gorm:"not null" json:"legs"`
+	CreatedAt int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version   uint32    `gorm:"not null" json:"version"`
+}
+
+// Leg represents the member of the team assigned to the leg, the leg is timed by the result of the member
+// at the checkpoint of the leg, so the sportsmen runs one leg at the checkpoint.
+type Leg struct {
+	ID           uuid.UUID `gorm:"primary_key" json:"id"`
+	TeamID       uuid.UUID `gorm:"not null;unique_index:idx_leg_team_number" json:"team_id"`
+	Number       uint32    `gorm:"not null;unique_index:idx_leg_team_number" json:"number"`
+	SportsmenID  uuid.UUID `gorm:"not null;unique_index:idx_leg_checkpoint_sportsmen" json:"sportsmen_id"`
+	CheckpointID uuid.UUID `gorm:"not null;unique_index:idx_leg_checkpoint_sportsmen" json:"checkpoint_id"`
+	CreatedAt    int64     `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version      uint32    `gorm:"not null" json:"version"`
+}
+
+// PendingTeam represents a relay team about to create, the legs are numbered in the given order from 1.
+type PendingTeam struct {
+	ID       uuid.UUID    `json:"id"`
+	Name     string       `json:"name"`
+	Category string       `json:"category"`
+	Legs     []PendingLeg `json:"legs"`
+}
+
+// PendingLeg represents the member assigned to the leg timed at the checkpoint.
+type PendingLeg struct {
+	SportsmenID  uuid.UUID `json:"sportsmen_id"`
+	CheckpointID uuid.UUID `json:"checkpoint_id"`
+}
+
+// PendingHandover represents the passing of the handover checkpoint ending the leg of the sportsmen,
+// the ID is given to the result of the next leg started at the same time.
+type PendingHandover struct {
+	ID           uuid.UUID  `json:"id"`
+	CheckpointID uuid.UUID  `json:"checkpoint_id"`
+	SportsmenID  uuid.UUID  `json:"sportsmen_id"`
+	Time         int64      `json:"time"`
+	DeviceID     *uuid.UUID `json:"device_id"`
+}
+
+// LegResult represents the individual result of the leg, the result is nil until the leg has started
+// and the leg time is nil until the leg has finished.
+type LegResult struct {
+	TeamID       uuid.UUID  `json:"team_id"`
+	Number       uint32     `json:"number"`
+	SportsmenID  uuid.UUID  `json:"sportsmen_id"`
+	StartNumber  uint32     `json:"start_number"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	CheckpointID uuid.UUID  `json:"checkpoint_id"`
+	ResultID     *uuid.UUID `json:"result_id"`
+	TimeStart    *int64     `json:"time_start"`
+	TimeFinish   *int64     `json:"time_finish"`
+	LegTime      *int64     `json:"leg_time"`
+}
+
+// Standing represents the place of the team in the rankings, the total time is the sum of the finished legs
+// and the finish time is set once all the legs have finished.
+type Standing struct {
+	TeamID       uuid.UUID `json:"team_id"`
+	Name         string    `json:"name"`
+	Category     string    `json:"category"`
+	Legs         uint32    `json:"legs"`
+	LegsFinished uint32    `json:"legs_finished"`
+	TimeStart    *int64    `json:"time_start"`
+	TimeFinish   *int64    `json:"time_finish"`
+	TotalTime    int64     `json:"total_time"`
+}
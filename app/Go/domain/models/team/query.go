@@ -0,0 +1,115 @@
+package team
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+)
+
+// GetTeam fetches a team.
+func GetTeam(db gorm.DB, pk uuid.UUID, version *uint32) (*Team, error) {
+	var team Team
+
+	err := db.Model(&team).Where("id = ?", pk).Take(&team).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Team not found: %w", NotFound{})
+	} else if version != nil && team.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading team: %w", err)
+	}
+
+	return &team, nil
+}
+
+// GetTeams fetches the teams ordered by the name.
+func GetTeams(db gorm.DB) (*[]Team, error) {
+	var teams []Team
+
+	err := db.Order("name asc").Find(&teams).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading teams: %w", err)
+	}
+
+	return &teams, nil
+}
+
+// GetLegResults fetches the individual results of the legs of the teams, the legs of the team
+// come together with the first leg first.
+func GetLegResults(db gorm.DB, teamIDs []uuid.UUID) (*[]LegResult, error) {
+	var legResults []LegResult
+
+	if len(teamIDs) == 0 {
+		return &legResults, nil
+	}
+
+	err := db.Raw(`
+		SELECT legs.team_id, legs.number, legs.sportsmen_id, legs.checkpoint_id,
+			sportsmens.start_number, sportsmens.first_name, sportsmens.last_name,
+			results.id AS result_id, results.time_start, results.time_finish,
+			results.time_finish - results.time_start AS leg_time
+		FROM legs
+		JOIN sportsmens ON sportsmens.id = legs.sportsmen_id
+		LEFT JOIN results ON results.checkpoint_id = legs.checkpoint_id AND results.sportsmen_id = legs.sportsmen_id
+		WHERE legs.team_id IN (?)
+		ORDER BY legs.team_id, legs.number ASC`,
+		teamIDs,
+	).Scan(&legResults).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &legResults, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading leg results: %w", err)
+	}
+
+	return &legResults, nil
+}
+
+// GetStandings fetches the team rankings, the most legs finished come first
+// and the same legs are ranked by the total time.
+func GetStandings(db gorm.DB, limit int) (*[]Standing, error) {
+	var standings []Standing
+
+	err := db.Raw(standingsQuery+`
+		GROUP BY teams.id
+		ORDER BY legs_finished DESC, total_time ASC, teams.name ASC
+		LIMIT ?`,
+		limit,
+	).Scan(&standings).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return &standings, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading team standings: %w", err)
+	}
+
+	return &standings, nil
+}
+
+// GetStanding fetches the standing of the team.
+func GetStanding(db gorm.DB, teamID uuid.UUID) (*Standing, error) {
+	var standings []Standing
+
+	err := db.Raw(standingsQuery+`
+		WHERE teams.id = ?
+		GROUP BY teams.id`,
+		teamID,
+	).Scan(&standings).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Error loading team standing: %w", err)
+	} else if len(standings) == 0 {
+		return nil, fmt.Errorf("Team not found: %w", NotFound{})
+	}
+
+	return &standings[0], nil
+}
+
+// standingsQuery sums up the results of the legs of the teams, the legs not started yet have no results.
+const standingsQuery = `
+		SELECT teams.id AS team_id, teams.name, teams.category, teams.legs,
+			COUNT(results.time_finish) AS legs_finished,
+			MIN(results.time_start) AS time_start,
+			CASE WHEN COUNT(results.time_finish) = teams.legs THEN MAX(results.time_finish) END AS time_finish,
+			COALESCE(SUM(results.time_finish - results.time_start), 0) AS total_time
+		FROM teams
+		JOIN legs ON legs.team_id = teams.id
+		LEFT JOIN results ON results.checkpoint_id = legs.checkpoint_id AND results.sportsmen_id = legs.sportsmen_id`
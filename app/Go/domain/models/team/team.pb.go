@@ -0,0 +1,1092 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: team.proto
+
+package team
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type TeamCreatedEvent struct {
+	TeamID               string   `protobuf:"bytes,1,opt,name=TeamID,proto3" json:"TeamID,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Category             string   `protobuf:"bytes,3,opt,name=Category,proto3" json:"Category,omitempty"`
+	Legs                 uint32   `protobuf:"varint,4,opt,name=Legs,proto3" json:"Legs,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamCreatedEvent) Reset()         { *m = TeamCreatedEvent{} }
+func (m *TeamCreatedEvent) String() string { return proto.CompactTextString(m) }
+func (*TeamCreatedEvent) ProtoMessage()    {}
+func (*TeamCreatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{0}
+}
+func (m *TeamCreatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TeamCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TeamCreatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TeamCreatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamCreatedEvent.Merge(m, src)
+}
+func (m *TeamCreatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *TeamCreatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamCreatedEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamCreatedEvent proto.InternalMessageInfo
+
+func (m *TeamCreatedEvent) GetTeamID() string {
+	if m != nil {
+		return m.TeamID
+	}
+	return ""
+}
+
+func (m *TeamCreatedEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TeamCreatedEvent) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *TeamCreatedEvent) GetLegs() uint32 {
+	if m != nil {
+		return m.Legs
+	}
+	return 0
+}
+
+func (m *TeamCreatedEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type TeamHandedOverEvent struct {
+	TeamID               string   `protobuf:"bytes,1,opt,name=TeamID,proto3" json:"TeamID,omitempty"`
+	Leg                  uint32   `protobuf:"varint,2,opt,name=Leg,proto3" json:"Leg,omitempty"`
+	ResultID             string   `protobuf:"bytes,3,opt,name=ResultID,proto3" json:"ResultID,omitempty"`
+	SportsmenID          string   `protobuf:"bytes,4,opt,name=SportsmenID,proto3" json:"SportsmenID,omitempty"`
+	NextResultID         string   `protobuf:"bytes,5,opt,name=NextResultID,proto3" json:"NextResultID,omitempty"`
+	NextSportsmenID      string   `protobuf:"bytes,6,opt,name=NextSportsmenID,proto3" json:"NextSportsmenID,omitempty"`
+	Time                 int64    `protobuf:"varint,7,opt,name=Time,proto3" json:"Time,omitempty"`
+	Finished             bool     `protobuf:"varint,8,opt,name=Finished,proto3" json:"Finished,omitempty"`
+	DeviceID             string   `protobuf:"bytes,9,opt,name=DeviceID,proto3" json:"DeviceID,omitempty"`
+	Version              uint32   `protobuf:"varint,255,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TeamHandedOverEvent) Reset()         { *m = TeamHandedOverEvent{} }
+func (m *TeamHandedOverEvent) String() string { return proto.CompactTextString(m) }
+func (*TeamHandedOverEvent) ProtoMessage()    {}
+func (*TeamHandedOverEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8b4e9e93d7b2c6bb, []int{1}
+}
+func (m *TeamHandedOverEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TeamHandedOverEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TeamHandedOverEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TeamHandedOverEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TeamHandedOverEvent.Merge(m, src)
+}
+func (m *TeamHandedOverEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *TeamHandedOverEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_TeamHandedOverEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TeamHandedOverEvent proto.InternalMessageInfo
+
+func (m *TeamHandedOverEvent) GetTeamID() string {
+	if m != nil {
+		return m.TeamID
+	}
+	return ""
+}
+
+func (m *TeamHandedOverEvent) GetLeg() uint32 {
+	if m != nil {
+		return m.Leg
+	}
+	return 0
+}
+
+func (m *TeamHandedOverEvent) GetResultID() string {
+	if m != nil {
+		return m.ResultID
+	}
+	return ""
+}
+
+func (m *TeamHandedOverEvent) GetSportsmenID() string {
+	if m != nil {
+		return m.SportsmenID
+	}
+	return ""
+}
+
+func (m *TeamHandedOverEvent) GetNextResultID() string {
+	if m != nil {
+		return m.NextResultID
+	}
+	return ""
+}
+
+func (m *TeamHandedOverEvent) GetNextSportsmenID() string {
+	if m != nil {
+		return m.NextSportsmenID
+	}
+	return ""
+}
+
+func (m *TeamHandedOverEvent) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *TeamHandedOverEvent) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+func (m *TeamHandedOverEvent) GetDeviceID() string {
+	if m != nil {
+		return m.DeviceID
+	}
+	return ""
+}
+
+func (m *TeamHandedOverEvent) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*TeamCreatedEvent)(nil), "team.TeamCreatedEvent")
+	proto.RegisterType((*TeamHandedOverEvent)(nil), "team.TeamHandedOverEvent")
+}
+
+func init() { proto.RegisterFile("team.proto", fileDescriptor_8b4e9e93d7b2c6bb) }
+
+var fileDescriptor_8b4e9e93d7b2c6bb = []byte{
+	// 301 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x91, 0x41, 0x4a, 0xc3, 0x40,
+	0x14, 0x86, 0x9d, 0xb6, 0xb6, 0xc9, 0xd3, 0x62, 0x18, 0x41, 0x46, 0x17, 0x21, 0x64, 0x95, 0x95,
+	0x1b, 0x6f, 0x60, 0xa3, 0x18, 0x28, 0x15, 0xc6, 0xe2, 0x7e, 0x34, 0x8f, 0x18, 0x30, 0x49, 0x99,
+	0x8c, 0x41, 0x8f, 0xe0, 0x0d, 0x3c, 0x87, 0xa7, 0x70, 0xe9, 0x11, 0x24, 0x1e, 0x44, 0x99, 0xa7,
+	0x09, 0xd5, 0x45, 0x77, 0xff, 0xff, 0xe5, 0xf1, 0xf2, 0xcd, 0x0c, 0x80, 0x41, 0x55, 0x1c, 0xaf,
+	0x74, 0x65, 0x2a, 0x3e, 0xb2, 0x39, 0x7c, 0x66, 0xe0, 0x2d, 0x51, 0x15, 0x33, 0x8d, 0xca, 0x60,
+	0x7a, 0xd6, 0x60, 0x69, 0xf8, 0x01, 0x8c, 0x2d, 0x4b, 0x62, 0xc1, 0x02, 0x16, 0xb9, 0xf2, 0xb7,
+	0x71, 0x0e, 0xa3, 0x85, 0x2a, 0x50, 0x0c, 0x88, 0x52, 0xe6, 0x47, 0xe0, 0xcc, 0x94, 0xc1, 0xac,
+	0xd2, 0x4f, 0x62, 0x48, 0xbc, 0xef, 0x76, 0x7e, 0x8e, 0x59, 0x2d, 0x46, 0x01, 0x8b, 0xa6, 0x92,
+	0x32, 0x3f, 0x84, 0xc9, 0x35, 0xea, 0x3a, 0xaf, 0x4a, 0xf1, 0xc5, 0x88, 0x77, 0x3d, 0x7c, 0x1d,
+	0xc0, 0xbe, 0xfd, 0xd3, 0x85, 0x2a, 0x53, 0x4c, 0x2f, 0x1b, 0xd4, 0x9b, 0x75, 0x3c, 0x18, 0xce,
+	0x31, 0x23, 0x9b, 0xa9, 0xb4, 0xd1, 0xca, 0x48, 0xac, 0x1f, 0xee, 0x4d, 0x12, 0x77, 0x32, 0x5d,
+	0xe7, 0x01, 0xec, 0x5c, 0xad, 0x2a, 0x6d, 0xea, 0x02, 0xcb, 0x24, 0x26, 0x27, 0x57, 0xae, 0x23,
+	0x1e, 0xc2, 0xee, 0x02, 0x1f, 0x4d, 0xbf, 0x61, 0x9b, 0x46, 0xfe, 0x30, 0x1e, 0xc1, 0x9e, 0xed,
+	0xeb, 0x9b, 0xc6, 0x34, 0xf6, 0x1f, 0xdb, 0xc3, 0x2f, 0xf3, 0x02, 0xc5, 0x24, 0x60, 0xd1, 0x50,
+	0x52, 0xb6, 0x7e, 0xe7, 0x79, 0x99, 0xd7, 0x77, 0x98, 0x0a, 0x27, 0x60, 0x91, 0x23, 0xfb, 0x6e,
+	0xbf, 0xc5, 0xd8, 0xe4, 0xb7, 0x98, 0xc4, 0xc2, 0xfd, 0x71, 0xef, 0xfa, 0x86, 0x4b, 0x3b, 0xf5,
+	0xde, 0x5a, 0x9f, 0xbd, 0xb7, 0x3e, 0xfb, 0x68, 0x7d, 0xf6, 0xf2, 0xe9, 0x6f, 0xdd, 0x8c, 0xe9,
+	0x7d, 0x4f, 0xbe, 0x07, 0x00, 0x16, 0xc0, 0x7f, 0x61, 0xed, 0x01, 0x00, 0x00,
+}
+
+func (m *TeamCreatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TeamCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TeamCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintTeam(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.Legs != 0 {
+		i = encodeVarintTeam(dAtA, i, uint64(m.Legs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Category) > 0 {
+		i -= len(m.Category)
+		copy(dAtA[i:], m.Category)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.Category)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TeamID) > 0 {
+		i -= len(m.TeamID)
+		copy(dAtA[i:], m.TeamID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.TeamID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TeamHandedOverEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TeamHandedOverEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TeamHandedOverEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Version != 0 {
+		i = encodeVarintTeam(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0xf
+		i--
+		dAtA[i] = 0xf8
+	}
+	if len(m.DeviceID) > 0 {
+		i -= len(m.DeviceID)
+		copy(dAtA[i:], m.DeviceID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.DeviceID)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Finished {
+		i--
+		if m.Finished {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Time != 0 {
+		i = encodeVarintTeam(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.NextSportsmenID) > 0 {
+		i -= len(m.NextSportsmenID)
+		copy(dAtA[i:], m.NextSportsmenID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.NextSportsmenID)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.NextResultID) > 0 {
+		i -= len(m.NextResultID)
+		copy(dAtA[i:], m.NextResultID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.NextResultID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.SportsmenID) > 0 {
+		i -= len(m.SportsmenID)
+		copy(dAtA[i:], m.SportsmenID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.SportsmenID)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ResultID) > 0 {
+		i -= len(m.ResultID)
+		copy(dAtA[i:], m.ResultID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.ResultID)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Leg != 0 {
+		i = encodeVarintTeam(dAtA, i, uint64(m.Leg))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TeamID) > 0 {
+		i -= len(m.TeamID)
+		copy(dAtA[i:], m.TeamID)
+		i = encodeVarintTeam(dAtA, i, uint64(len(m.TeamID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTeam(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTeam(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *TeamCreatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TeamID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	l = len(m.Category)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	if m.Legs != 0 {
+		n += 1 + sovTeam(uint64(m.Legs))
+	}
+	if m.Version != 0 {
+		n += 2 + sovTeam(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TeamHandedOverEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TeamID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	if m.Leg != 0 {
+		n += 1 + sovTeam(uint64(m.Leg))
+	}
+	l = len(m.ResultID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	l = len(m.SportsmenID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	l = len(m.NextResultID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	l = len(m.NextSportsmenID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	if m.Time != 0 {
+		n += 1 + sovTeam(uint64(m.Time))
+	}
+	if m.Finished {
+		n += 2
+	}
+	l = len(m.DeviceID)
+	if l > 0 {
+		n += 1 + l + sovTeam(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 2 + sovTeam(uint64(m.Version))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovTeam(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTeam(x uint64) (n int) {
+	return sovTeam(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *TeamCreatedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTeam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TeamCreatedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TeamCreatedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TeamID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TeamID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Category", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Category = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Legs", wireType)
+			}
+			m.Legs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Legs |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTeam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TeamHandedOverEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTeam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TeamHandedOverEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TeamHandedOverEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TeamID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TeamID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leg", wireType)
+			}
+			m.Leg = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Leg |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResultID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SportsmenID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SportsmenID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextResultID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextResultID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextSportsmenID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextSportsmenID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finished", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Finished = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeviceID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTeam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeviceID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 255:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTeam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTeam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTeam(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTeam
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTeam
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTeam
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTeam
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTeam
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTeam        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTeam          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTeam = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,13 @@
+package team_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTeam(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Team Suite")
+}
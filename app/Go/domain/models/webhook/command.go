@@ -0,0 +1,289 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/transaction"
+	"strings"
+)
+
+// Minimal length of the secret the deliveries are signed with.
+const secretMinLength = 16
+
+// Create a new webhook subscription.
+func Create(db gorm.DB, pendingSubscription PendingSubscription) (*SubscriptionCreatedEvent, error) {
+	pendingSubscription.URL = strings.TrimSpace(pendingSubscription.URL)
+	pendingSubscription.Category = strings.TrimSpace(pendingSubscription.Category)
+
+	if err := validation.ValidateStruct(
+		&pendingSubscription,
+		validation.Field(&pendingSubscription.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingSubscription.URL, validation.Required, is.URL, validation.By(httpScheme)),
+		validation.Field(&pendingSubscription.Secret, validation.Required, validation.Length(secretMinLength, 0)),
+		validation.Field(&pendingSubscription.EventTypes, validation.Required, validation.Each(validation.In(EventTypes...))),
+	); err != nil {
+		return nil, err
+	}
+
+	newSubscription := Subscription{
+		ID:           pendingSubscription.ID,
+		URL:          pendingSubscription.URL,
+		Secret:       pendingSubscription.Secret,
+		EventTypes:   pendingSubscription.EventTypes,
+		CheckpointID: pendingSubscription.CheckpointID,
+		Category:     pendingSubscription.Category,
+		Version:      1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		return tx.Create(&newSubscription).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := &SubscriptionCreatedEvent{
+		SubscriptionID: newSubscription.ID.String(),
+		URL:            newSubscription.URL,
+		EventTypes:     newSubscription.EventTypes,
+		Category:       newSubscription.Category,
+		Version:        newSubscription.Version,
+	}
+
+	if newSubscription.CheckpointID != nil {
+		event.CheckpointID = newSubscription.CheckpointID.String()
+	}
+
+	return event, nil
+}
+
+// httpScheme lets the webhooks be posted over HTTP(S) only.
+func httpScheme(value interface{}) error {
+	url, _ := value.(string)
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return errors.New("must be an http or https URL")
+	}
+
+	return nil
+}
+
+// Remove the webhook subscription, its queued deliveries are not sent any more.
+func Remove(db gorm.DB, removedAt int64, subscription Subscription) (*SubscriptionRemovedEvent, error) {
+	if subscription.RemovedAt != nil {
+		return nil, AlreadyRemoved{}
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Subscription{}).
+			Where("id = ? AND version = ? AND removed_at IS NULL",
+				subscription.ID,
+				subscription.Version,
+			).Updates(map[string]interface{}{"removed_at": removedAt, "version": subscription.Version + 1})
+		if result.Error != nil {
+			return fmt.Errorf("Error removing the webhook: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscriptionRemovedEvent{
+		SubscriptionID: subscription.ID.String(),
+		RemovedAt:      removedAt,
+		Version:        subscription.Version + 1,
+	}, nil
+}
+
+// Queue the event for the delivery to the webhook, the event is queued once for every subscription.
+func Queue(db gorm.DB, pendingDelivery PendingDelivery) (*DeliveryQueuedEvent, error) {
+	if err := validation.ValidateStruct(
+		&pendingDelivery,
+		validation.Field(&pendingDelivery.ID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingDelivery.SubscriptionID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingDelivery.MessageID, validation.Required, is.UUIDv4),
+		validation.Field(&pendingDelivery.EventType, validation.Required),
+		validation.Field(&pendingDelivery.Payload, validation.Required),
+	); err != nil {
+		return nil, err
+	}
+
+	newDelivery := Delivery{
+		ID:             pendingDelivery.ID,
+		SubscriptionID: pendingDelivery.SubscriptionID,
+		MessageID:      pendingDelivery.MessageID,
+		EventType:      pendingDelivery.EventType,
+		Payload:        pendingDelivery.Payload,
+		Status:         StatusPending,
+		NextAttemptAt:  pendingDelivery.NextAttemptAt,
+		Version:        1,
+	}
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		if err := tx.Create(&newDelivery).Error; transaction.IsUniqueViolation(err) {
+			return AlreadyQueued{}
+		} else if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryQueuedEvent{
+		DeliveryID:     newDelivery.ID.String(),
+		SubscriptionID: newDelivery.SubscriptionID.String(),
+		MessageID:      newDelivery.MessageID.String(),
+		EventType:      newDelivery.EventType,
+		NextAttemptAt:  newDelivery.NextAttemptAt,
+		Version:        newDelivery.Version,
+	}, nil
+}
+
+// Claim hides the due delivery from the other senders until the given time in milliseconds while it is posted,
+// the delivery whose attempt is not recorded by then, e.g. the sender has crashed, is due again.
+func Claim(db gorm.DB, claimedUntil int64, delivery Delivery) (*DeliveryClaimedEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Delivery{}).
+			Where("id = ? AND version = ? AND status = ?",
+				delivery.ID,
+				delivery.Version,
+				StatusPending,
+			).Updates(map[string]interface{}{
+			"next_attempt_at": claimedUntil,
+			"version":         delivery.Version + 1,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("Error claiming the webhook delivery: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryClaimedEvent{
+		DeliveryID:   delivery.ID.String(),
+		ClaimedUntil: claimedUntil,
+		Version:      delivery.Version + 1,
+	}, nil
+}
+
+// MarkDelivered records the attempt accepted by the receiver.
+func MarkDelivered(db gorm.DB, deliveredAt int64, responseStatus int, delivery Delivery) (*DeliveryAttemptedEvent, error) {
+	return attempt(db, delivery, map[string]interface{}{
+		"status":          StatusDelivered,
+		"attempts":        delivery.Attempts + 1,
+		"response_status": responseStatus,
+		"last_error":      "",
+		"delivered_at":    deliveredAt,
+		"version":         delivery.Version + 1,
+	})
+}
+
+// Retry records the failed attempt, the delivery is attempted again at the given time in milliseconds.
+func Retry(db gorm.DB, reason string, responseStatus int, nextAttemptAt int64, delivery Delivery) (*DeliveryAttemptedEvent, error) {
+	return attempt(db, delivery, map[string]interface{}{
+		"status":          StatusPending,
+		"attempts":        delivery.Attempts + 1,
+		"response_status": responseStatus,
+		"last_error":      reason,
+		"next_attempt_at": nextAttemptAt,
+		"version":         delivery.Version + 1,
+	})
+}
+
+// Fail records the last failed attempt, the delivery is given up until it is redelivered.
+func Fail(db gorm.DB, reason string, responseStatus int, delivery Delivery) (*DeliveryAttemptedEvent, error) {
+	return attempt(db, delivery, map[string]interface{}{
+		"status":          StatusFailed,
+		"attempts":        delivery.Attempts + 1,
+		"response_status": responseStatus,
+		"last_error":      reason,
+		"version":         delivery.Version + 1,
+	})
+}
+
+func attempt(db gorm.DB, delivery Delivery, updates map[string]interface{}) (*DeliveryAttemptedEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Delivery{}).
+			Where("id = ? AND version = ?",
+				delivery.ID,
+				delivery.Version,
+			).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("Error recording the webhook delivery attempt: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := &DeliveryAttemptedEvent{
+		DeliveryID:     delivery.ID.String(),
+		Status:         updates["status"].(string),
+		Attempts:       updates["attempts"].(uint32),
+		ResponseStatus: int32(updates["response_status"].(int)),
+		Version:        updates["version"].(uint32),
+	}
+
+	if reason, ok := updates["last_error"].(string); ok {
+		event.Error = reason
+	}
+
+	if nextAttemptAt, ok := updates["next_attempt_at"].(int64); ok {
+		event.NextAttemptAt = nextAttemptAt
+	}
+
+	return event, nil
+}
+
+// Redeliver queues the delivery to be sent again at the given time in milliseconds with the fresh attempts,
+// e.g. the failed one once the receiver has been fixed or the delivered one the receiver has lost.
+func Redeliver(db gorm.DB, nextAttemptAt int64, delivery Delivery) (*DeliveryRedeliveredEvent, error) {
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		result := tx.Model(&Delivery{}).
+			Where("id = ? AND version = ?",
+				delivery.ID,
+				delivery.Version,
+			).Updates(map[string]interface{}{
+			"status":          StatusPending,
+			"attempts":        0,
+			"next_attempt_at": nextAttemptAt,
+			"delivered_at":    nil,
+			"version":         delivery.Version + 1,
+		})
+		if result.Error != nil {
+			return fmt.Errorf("Error redelivering the webhook delivery: %w", result.Error)
+		} else if result.RowsAffected != 1 {
+			return fmt.Errorf("State conflict: %w", domain_errors.StateConflict{})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryRedeliveredEvent{
+		DeliveryID:    delivery.ID.String(),
+		NextAttemptAt: nextAttemptAt,
+		Version:       delivery.Version + 1,
+	}, nil
+}
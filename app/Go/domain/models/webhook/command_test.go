@@ -0,0 +1,248 @@
+package webhook_test
+
+import (
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"path/filepath"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Managing webhooks", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../../srv/cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	var pendingSubscription webhook.PendingSubscription
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		pendingSubscription = webhook.PendingSubscription{
+			ID:         uuid.Must(uuid.NewV4()),
+			URL:        "https://example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{result.TopicFinished},
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	queue := func(nextAttemptAt int64) webhook.Delivery {
+		event, err := webhook.Queue(*db, webhook.PendingDelivery{
+			ID:             uuid.Must(uuid.NewV4()),
+			SubscriptionID: pendingSubscription.ID,
+			MessageID:      uuid.Must(uuid.NewV4()),
+			EventType:      result.TopicFinished,
+			Payload:        []byte(`{}`),
+			NextAttemptAt:  nextAttemptAt,
+		})
+		Expect(err).To(BeNil())
+
+		delivery, err := webhook.GetDelivery(*db, pendingSubscription.ID, uuid.Must(uuid.FromString(event.DeliveryID)))
+		Expect(err).To(BeNil())
+		return *delivery
+	}
+
+	Describe("Subscribing the webhook", func() {
+		When("the webhook is subscribed", func() {
+			Specify("it receives the events of its types", func() {
+				_, err := webhook.Create(*db, pendingSubscription)
+				Expect(err).To(BeNil())
+
+				subscriptions, err := webhook.GetSubscriptionsOf(*db, result.TopicFinished)
+				Expect(err).To(BeNil())
+				Expect(*subscriptions).To(ContainElement(WithTransform(func(s webhook.Subscription) uuid.UUID { return s.ID }, Equal(pendingSubscription.ID))))
+
+				subscriptions, err = webhook.GetSubscriptionsOf(*db, sportsmen.TopicStatusChanged)
+				Expect(err).To(BeNil())
+				Expect(*subscriptions).ToNot(ContainElement(WithTransform(func(s webhook.Subscription) uuid.UUID { return s.ID }, Equal(pendingSubscription.ID))))
+			})
+		})
+
+		When("the webhook is removed", func() {
+			Specify("it receives no events and its deliveries are not due", func() {
+				_, err := webhook.Create(*db, pendingSubscription)
+				Expect(err).To(BeNil())
+				delivery := queue(0)
+
+				subscription, err := webhook.GetSubscription(*db, pendingSubscription.ID, nil)
+				Expect(err).To(BeNil())
+
+				_, err = webhook.Remove(*db, utils.MakeTimestampInMilliseconds(), *subscription)
+				Expect(err).To(BeNil())
+
+				subscriptions, err := webhook.GetSubscriptionsOf(*db, result.TopicFinished)
+				Expect(err).To(BeNil())
+				Expect(*subscriptions).ToNot(ContainElement(WithTransform(func(s webhook.Subscription) uuid.UUID { return s.ID }, Equal(pendingSubscription.ID))))
+
+				due, err := webhook.GetDueDelivery(*db, utils.MakeTimestampInMilliseconds())
+				Expect(err).To(BeNil())
+				if due != nil {
+					Expect(due.ID).ToNot(Equal(delivery.ID))
+				}
+
+				_, err = webhook.Remove(*db, utils.MakeTimestampInMilliseconds(), *subscription)
+				Expect(errors.As(err, &webhook.AlreadyRemoved{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Matching the events", func() {
+		When("the webhook filters the events", func() {
+			Specify("the events of the other checkpoints and categories are not delivered", func() {
+				checkpointID := uuid.Must(uuid.NewV4())
+				subscription := webhook.Subscription{
+					EventTypes:   []string{result.TopicFinished},
+					CheckpointID: &checkpointID,
+					Category:     "M40",
+				}
+
+				Expect(subscription.Matches(result.TopicFinished, webhook.Attributes{CheckpointID: checkpointID.String(), Category: "M40"})).To(BeTrue())
+				Expect(subscription.Matches(result.TopicFinished, webhook.Attributes{CheckpointID: checkpointID.String(), Category: "W40"})).To(BeFalse())
+				Expect(subscription.Matches(result.TopicFinished, webhook.Attributes{CheckpointID: uuid.Must(uuid.NewV4()).String(), Category: "M40"})).To(BeFalse())
+				Expect(subscription.Matches(result.TopicCreated, webhook.Attributes{CheckpointID: checkpointID.String(), Category: "M40"})).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Delivering the events", func() {
+		BeforeEach(func() {
+			_, err := webhook.Create(*db, pendingSubscription)
+			Expect(err).To(BeNil())
+		})
+
+		When("the event is queued twice", func() {
+			Specify("the error returned", func() {
+				delivery := queue(0)
+
+				_, err := webhook.Queue(*db, webhook.PendingDelivery{
+					ID:             uuid.Must(uuid.NewV4()),
+					SubscriptionID: delivery.SubscriptionID,
+					MessageID:      delivery.MessageID,
+					EventType:      delivery.EventType,
+					Payload:        delivery.Payload,
+				})
+				Expect(errors.As(err, &webhook.AlreadyQueued{})).To(BeTrue())
+			})
+		})
+
+		When("the delivery is retried", func() {
+			Specify("it is not due before the next attempt", func() {
+				delivery := queue(0)
+				now := utils.MakeTimestampInMilliseconds()
+
+				event, err := webhook.Retry(*db, "503 Service Unavailable", 503, now+60000, delivery)
+				Expect(err).To(BeNil())
+				Expect(event.Status).To(Equal(webhook.StatusPending))
+				Expect(event.Attempts).To(Equal(uint32(1)))
+
+				due, err := webhook.GetDueDelivery(*db, now)
+				Expect(err).To(BeNil())
+				if due != nil {
+					Expect(due.ID).ToNot(Equal(delivery.ID))
+				}
+
+				retried, err := webhook.GetDelivery(*db, pendingSubscription.ID, delivery.ID)
+				Expect(err).To(BeNil())
+				Expect(retried.ResponseStatus).To(Equal(503))
+				Expect(retried.LastError).To(Equal("503 Service Unavailable"))
+			})
+		})
+
+		When("the delivery is claimed", func() {
+			Specify("it is not due before the claim ends and is recorded with the claimed version", func() {
+				delivery := queue(0)
+				now := utils.MakeTimestampInMilliseconds()
+
+				event, err := webhook.Claim(*db, now+60000, delivery)
+				Expect(err).To(BeNil())
+				Expect(event.Version).To(Equal(delivery.Version + 1))
+
+				due, err := webhook.GetDueDelivery(*db, now)
+				Expect(err).To(BeNil())
+				if due != nil {
+					Expect(due.ID).ToNot(Equal(delivery.ID))
+				}
+
+				_, err = webhook.Claim(*db, now+60000, delivery)
+				Expect(err).ToNot(BeNil())
+
+				claimed, err := webhook.GetDelivery(*db, pendingSubscription.ID, delivery.ID)
+				Expect(err).To(BeNil())
+				Expect(claimed.NextAttemptAt).To(Equal(now + 60000))
+
+				_, err = webhook.MarkDelivered(*db, 10, 204, *claimed)
+				Expect(err).To(BeNil())
+			})
+		})
+
+		When("the failed delivery is redelivered", func() {
+			Specify("it is pending with the fresh attempts", func() {
+				delivery := queue(0)
+
+				_, err := webhook.Fail(*db, "timeout", 0, delivery)
+				Expect(err).To(BeNil())
+
+				failed, err := webhook.GetDelivery(*db, pendingSubscription.ID, delivery.ID)
+				Expect(err).To(BeNil())
+				Expect(failed.Status).To(Equal(webhook.StatusFailed))
+
+				_, err = webhook.Redeliver(*db, 1, *failed)
+				Expect(err).To(BeNil())
+
+				redelivered, err := webhook.GetDelivery(*db, pendingSubscription.ID, delivery.ID)
+				Expect(err).To(BeNil())
+				Expect(redelivered.Status).To(Equal(webhook.StatusPending))
+				Expect(redelivered.Attempts).To(Equal(uint32(0)))
+				Expect(redelivered.NextAttemptAt).To(Equal(int64(1)))
+
+				_, err = webhook.Redeliver(*db, 1, *failed)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the delivery is delivered", func() {
+			Specify("it is logged with the response status", func() {
+				delivery := queue(0)
+
+				_, err := webhook.MarkDelivered(*db, 10, 204, delivery)
+				Expect(err).To(BeNil())
+
+				deliveries, err := webhook.GetDeliveries(*db, pendingSubscription.ID, 10)
+				Expect(err).To(BeNil())
+				Expect(*deliveries).To(HaveLen(1))
+				Expect((*deliveries)[0].Status).To(Equal(webhook.StatusDelivered))
+				Expect((*deliveries)[0].ResponseStatus).To(Equal(204))
+				Expect(*(*deliveries)[0].DeliveredAt).To(Equal(int64(10)))
+			})
+		})
+	})
+})
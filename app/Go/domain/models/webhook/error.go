@@ -0,0 +1,31 @@
+package webhook
+
+type (
+	// NotFound signifies a webhook subscription is not found.
+	NotFound struct{}
+
+	// AlreadyRemoved signifies a webhook subscription has been removed already.
+	AlreadyRemoved struct{}
+
+	// DeliveryNotFound signifies a webhook delivery is not found.
+	DeliveryNotFound struct{}
+
+	// AlreadyQueued signifies the event has been queued for the webhook already.
+	AlreadyQueued struct{}
+)
+
+func (err NotFound) Error() string {
+	return "Webhook does not exist"
+}
+
+func (err AlreadyRemoved) Error() string {
+	return "Webhook has been removed already"
+}
+
+func (err DeliveryNotFound) Error() string {
+	return "Webhook delivery does not exist"
+}
+
+func (err AlreadyQueued) Error() string {
+	return "Webhook delivery has been queued already"
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+)
+
+// EventTypes are the outbox topics the webhooks may subscribe to.
+var EventTypes = []interface{}{
+	result.TopicCreated,
+	result.TopicFinished,
+	sportsmen.TopicStatusChanged,
+	announcement.TopicCreated,
+	announcement.TopicRetracted,
+}
+
+// Statuses of the delivery.
+const (
+	// StatusPending is waiting for the first or the next attempt.
+	StatusPending = "pending"
+	// StatusDelivered has been accepted by the receiver with the 2xx response.
+	StatusDelivered = "delivered"
+	// StatusFailed has been given up after the max attempts, it is sent again only when redelivered.
+	StatusFailed = "failed"
+)
+
+// Subscription represents a persistence model for the webhook, the events of its types which pass
+// the filter are posted to the URL signed with the secret.
+type Subscription struct {
+	ID         uuid.UUID      `gorm:"primary_key" json:"id"`
+	URL        string         `gorm:"not null" json:"url"`
+	Secret     string         `gorm:"not null" json:"-"`
+	EventTypes pq.StringArray `gorm:"type:text[];not null" json:"event_types"`
+	// CheckpointID filters the events by the checkpoint of the result, nil passes all of them.
+	CheckpointID *uuid.UUID `gorm:"type:uuid" json:"checkpoint_id"`
+	// Category filters the events by the category of the sportsmen, empty passes all of them.
+	Category  string `gorm:"not null;default:''" json:"category"`
+	RemovedAt *int64 `json:"removed_at"`
+	CreatedAt int64  `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version   uint32 `gorm:"not null" json:"version"`
+}
+
+// TableName keeps the table name telling apart from the other subscriptions.
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// Attributes of the event the subscription filter is matched against, empty when the event has none.
+type Attributes struct {
+	CheckpointID string
+	Category     string
+}
+
+// Matches reports whether the event of the type with the attributes is delivered to the subscription.
+func (s Subscription) Matches(eventType string, attributes Attributes) bool {
+	if s.RemovedAt != nil {
+		return false
+	}
+
+	subscribed := false
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			subscribed = true
+			break
+		}
+	}
+
+	if !subscribed {
+		return false
+	} else if s.CheckpointID != nil && s.CheckpointID.String() != attributes.CheckpointID {
+		return false
+	} else if s.Category != "" && s.Category != attributes.Category {
+		return false
+	}
+
+	return true
+}
+
+// Delivery represents a persistence model for the event posted to the webhook, it is the log of the attempts.
+type Delivery struct {
+	ID             uuid.UUID `gorm:"primary_key" json:"id"`
+	SubscriptionID uuid.UUID `gorm:"not null;unique_index:idx_webhook_delivery_subscription_message" json:"subscription_id"`
+	// MessageID is the outbox message of the event, the event is delivered to the subscription once.
+	MessageID uuid.UUID `gorm:"not null;unique_index:idx_webhook_delivery_subscription_message" json:"message_id"`
+	EventType string    `gorm:"not null" json:"event_type"`
+	// Payload is the JSON body posted to the webhook.
+	Payload       []byte `gorm:"not null" json:"payload"`
+	Status        string `gorm:"not null;index" json:"status"`
+	Attempts      uint32 `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt int64  `gorm:"not null;index" json:"next_attempt_at"`
+	// ResponseStatus is the HTTP status of the last attempt, 0 when the receiver has not responded.
+	ResponseStatus int    `gorm:"not null;default:0" json:"response_status"`
+	LastError      string `gorm:"not null;default:''" json:"last_error"`
+	DeliveredAt    *int64 `json:"delivered_at"`
+	CreatedAt      int64  `gorm:"default:extract(epoch from now());not null" json:"created_at"`
+	Version        uint32 `gorm:"not null" json:"version"`
+}
+
+// TableName keeps the table name telling apart from the other deliveries.
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// PendingSubscription represents a webhook about to subscribe.
+type PendingSubscription struct {
+	ID           uuid.UUID  `json:"id"`
+	URL          string     `json:"url"`
+	Secret       string     `json:"secret"`
+	EventTypes   []string   `json:"event_types"`
+	CheckpointID *uuid.UUID `json:"checkpoint_id"`
+	Category     string     `json:"category"`
+}
+
+// PendingDelivery represents an event about to post to the webhook.
+type PendingDelivery struct {
+	ID             uuid.UUID `json:"id"`
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	MessageID      uuid.UUID `json:"message_id"`
+	EventType      string    `json:"event_type"`
+	Payload        []byte    `json:"payload"`
+	NextAttemptAt  int64     `json:"next_attempt_at"`
+}
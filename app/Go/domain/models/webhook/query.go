@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	domain_errors "sports/backend/domain/errors"
+)
+
+// GetSubscription fetches a webhook subscription.
+func GetSubscription(db gorm.DB, pk uuid.UUID, version *uint32) (*Subscription, error) {
+	var subscription Subscription
+
+	err := db.Model(&subscription).Where("id = ?", pk).Take(&subscription).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Webhook not found: %w", NotFound{})
+	} else if version != nil && subscription.Version != *version {
+		return nil, fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading webhook: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// GetSubscriptions fetches the webhook subscriptions not removed, the oldest one comes first.
+func GetSubscriptions(db gorm.DB) (*[]Subscription, error) {
+	var subscriptions []Subscription
+
+	err := db.Where("removed_at IS NULL").Order("created_at asc").Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading webhooks: %w", err)
+	}
+
+	return &subscriptions, nil
+}
+
+// GetSubscriptionsOf fetches the webhook subscriptions not removed subscribed to the event type.
+func GetSubscriptionsOf(db gorm.DB, eventType string) (*[]Subscription, error) {
+	var subscriptions []Subscription
+
+	err := db.Where("removed_at IS NULL AND ? = ANY(event_types)", eventType).Order("created_at asc").Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading webhooks: %w", err)
+	}
+
+	return &subscriptions, nil
+}
+
+// GetDelivery fetches a delivery of the webhook subscription.
+func GetDelivery(db gorm.DB, subscriptionID uuid.UUID, pk uuid.UUID) (*Delivery, error) {
+	var delivery Delivery
+
+	err := db.Model(&delivery).Where("id = ? AND subscription_id = ?", pk, subscriptionID).Take(&delivery).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, fmt.Errorf("Webhook delivery not found: %w", DeliveryNotFound{})
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading webhook delivery: %w", err)
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveries fetches the latest deliveries of the webhook subscription, the newest one comes first.
+func GetDeliveries(db gorm.DB, subscriptionID uuid.UUID, limit int) (*[]Delivery, error) {
+	var deliveries []Delivery
+
+	err := db.Where("subscription_id = ?", subscriptionID).Order("created_at desc, id").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, fmt.Errorf("Error loading webhook deliveries: %w", err)
+	}
+
+	return &deliveries, nil
+}
+
+// GetDueDelivery fetches the pending delivery of the subscription not removed whose next attempt is due
+// at the given time in milliseconds. The delivery is locked until the end of the transaction,
+// the ones locked by another sender are skipped, nil is returned when none is due.
+func GetDueDelivery(db gorm.DB, now int64) (*Delivery, error) {
+	var delivery Delivery
+
+	active := db.Model(&Subscription{}).Select("id").Where("removed_at IS NULL").SubQuery()
+
+	err := db.Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").
+		Model(&Delivery{}).
+		Where("status = ? AND next_attempt_at <= ? AND subscription_id IN ?", StatusPending, now, active).
+		Order("next_attempt_at").
+		Take(&delivery).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Error loading due webhook deliveries: %w", err)
+	}
+
+	return &delivery, nil
+}
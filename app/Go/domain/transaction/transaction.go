@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the PostgreSQL error code of the unique constraint violation.
+const uniqueViolation = "23505"
+
+// Run runs the command in a transaction committed when the command succeeds and rolled back otherwise.
+// The connection which is a transaction already, e.g. the sync batch or the test, runs the command in a savepoint
+// instead, so the failed command is undone without aborting the enclosing transaction.
+func Run(db gorm.DB, command func(tx gorm.DB) error) (err error) {
+	if _, ok := db.CommonDB().(*sql.Tx); ok {
+		return savepoint(db, command)
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := command(*tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// savepoint runs the command in a savepoint of the enclosing transaction.
+func savepoint(tx gorm.DB, command func(tx gorm.DB) error) error {
+	if err := tx.Exec("SAVEPOINT command").Error; err != nil {
+		return err
+	}
+
+	if err := command(tx); err != nil {
+		if rollbackErr := tx.Exec("ROLLBACK TO SAVEPOINT command").Error; rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	return tx.Exec("RELEASE SAVEPOINT command").Error
+}
+
+// IsUniqueViolation tells whether the error is the unique constraint violation, e.g. lost the race to a concurrent insert.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
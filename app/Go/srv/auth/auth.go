@@ -0,0 +1,285 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"net/http"
+	"sports/backend/domain/models/credential"
+	"strings"
+	"time"
+)
+
+// Authentication methods of the identity.
+const (
+	MethodAPIKey = "api_key"
+	MethodToken  = "token"
+)
+
+// Prefix of the generated API keys, helps to recognize leaked keys.
+const apiKeyPrefix = "sk_"
+
+// Settings configures the bearer tokens and the device client certificates.
+type Settings struct {
+	TokenSecret []byte
+	TokenTTL    time.Duration
+
+	// RequireDeviceCerts requires the device credentials to come with the client certificate
+	// issued for the device, the common name of the certificate is the device id.
+	RequireDeviceCerts bool
+}
+
+// Identity is the authenticated API client.
+type Identity struct {
+	CredentialID  uuid.UUID
+	Name          string
+	Role          string
+	CheckpointIDs []uuid.UUID
+	DeviceID      *uuid.UUID
+	Method        string
+}
+
+// Unauthenticated signifies the request credentials are missing or invalid.
+type Unauthenticated struct {
+	Reason string
+}
+
+func (err Unauthenticated) Error() string {
+	return fmt.Sprintf("Unauthenticated: %s", err.Reason)
+}
+
+// Forbidden signifies the authenticated identity is not allowed to perform the request.
+type Forbidden struct {
+	Reason string
+}
+
+func (err Forbidden) Error() string {
+	return fmt.Sprintf("Forbidden: %s", err.Reason)
+}
+
+// HasRole reports whether the identity has one of the roles.
+func (i Identity) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if i.Role == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanUseCheckpoint reports whether the identity may submit times of the checkpoint,
+// admins may use any checkpoint, timekeepers the ones they are bound to.
+func (i Identity) CanUseCheckpoint(checkpointID uuid.UUID) bool {
+	switch i.Role {
+	case credential.RoleAdmin:
+		return true
+	case credential.RoleTimekeeper:
+		for _, id := range i.CheckpointIDs {
+			if id == checkpointID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// errCheckpointForbidden rejects the times of the checkpoint the credential is not bound to.
+var errCheckpointForbidden = Forbidden{Reason: "credential is not bound to the checkpoint"}
+
+// RequireCheckpoint returns the authenticated identity of the context when it may submit times of the checkpoint.
+// The context without identity is rejected as well, so the handler missing the authentication fails closed.
+func RequireCheckpoint(ctx context.Context, checkpointID uuid.UUID) (Identity, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return Identity{}, Unauthenticated{Reason: "credentials required"}
+	}
+
+	if !identity.CanUseCheckpoint(checkpointID) {
+		return Identity{}, errCheckpointForbidden
+	}
+
+	return identity, nil
+}
+
+type identityKey struct{}
+
+// WithIdentity returns the context carrying the authenticated identity.
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the authenticated identity of the request.
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+// GenerateAPIKey generates a new random API key and its hash to store.
+func GenerateAPIKey() (key string, keyHash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash the API key is stored and looked up by.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Alphabet of the enrollment codes, the characters easy to confuse when typed in are left out.
+const enrollmentCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateEnrollmentCode generates a new one-time device enrollment code, e.g. "K7QM-2XPA", and its hash to store.
+func GenerateEnrollmentCode() (code string, codeHash string, err error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	for i := range b {
+		b[i] = enrollmentCodeAlphabet[int(b[i])%len(enrollmentCodeAlphabet)]
+	}
+
+	code = string(b[:4]) + "-" + string(b[4:])
+
+	return code, HashEnrollmentCode(code), nil
+}
+
+// HashEnrollmentCode returns the hash the enrollment code is stored and looked up by,
+// the code is accepted regardless of the case and the separators.
+func HashEnrollmentCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate resolves the identity of the request from the "Authorization: Bearer <token>"
+// or the "X-API-Key: <key>" header, revoked credentials are rejected for both.
+func Authenticate(db gorm.DB, settings Settings, r *http.Request) (*Identity, error) {
+	return AuthenticateHeaders(db, settings, r.Header.Get("Authorization"), r.Header.Get("X-API-Key"))
+}
+
+// AuthenticateHeaders resolves the identity from the values of the Authorization and the X-API-Key headers,
+// e.g. sent as the gRPC metadata.
+func AuthenticateHeaders(db gorm.DB, settings Settings, authorization, apiKey string) (*Identity, error) {
+	var found *credential.Credential
+	var method string
+
+	if header := authorization; header != "" {
+		token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+		if token == header {
+			return nil, Unauthenticated{Reason: "unsupported authorization scheme"}
+		}
+
+		claims, err := ParseToken(settings.TokenSecret, token)
+		if err != nil {
+			return nil, Unauthenticated{Reason: "invalid token"}
+		}
+
+		credentialID, err := uuid.FromString(claims.Subject)
+		if err != nil {
+			return nil, Unauthenticated{Reason: "invalid token"}
+		}
+
+		found, err = credential.GetCredential(db, credentialID, nil)
+		if errors.As(err, &credential.NotFound{}) {
+			return nil, Unauthenticated{Reason: "invalid token"}
+		} else if err != nil {
+			return nil, err
+		}
+
+		method = MethodToken
+	} else if key := apiKey; key != "" {
+		var err error
+		found, err = credential.GetCredentialByKeyHash(db, HashAPIKey(key))
+		if errors.As(err, &credential.NotFound{}) {
+			return nil, Unauthenticated{Reason: "invalid API key"}
+		} else if err != nil {
+			return nil, err
+		}
+
+		method = MethodAPIKey
+	} else {
+		return nil, Unauthenticated{Reason: "credentials required"}
+	}
+
+	if found.RevokedAt != nil {
+		return nil, Unauthenticated{Reason: "credential revoked"}
+	}
+
+	identity := &Identity{
+		CredentialID: found.ID,
+		Name:         found.Name,
+		Role:         found.Role,
+		DeviceID:     found.DeviceID,
+		Method:       method,
+	}
+
+	for _, checkpointID := range found.CheckpointIDs {
+		id, err := uuid.FromString(checkpointID)
+		if err != nil {
+			return nil, err
+		}
+
+		identity.CheckpointIDs = append(identity.CheckpointIDs, id)
+	}
+
+	return identity, nil
+}
+
+// VerifyDeviceCertificate checks the identity bound to the device presented the verified client
+// certificate of the device when the settings require it, the state is nil on the plain connections.
+func VerifyDeviceCertificate(settings Settings, identity Identity, state *tls.ConnectionState) error {
+	if !settings.RequireDeviceCerts || identity.DeviceID == nil {
+		return nil
+	}
+
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return Unauthenticated{Reason: "device client certificate required"}
+	}
+
+	if state.VerifiedChains[0][0].Subject.CommonName != identity.DeviceID.String() {
+		return Unauthenticated{Reason: "client certificate of another device"}
+	}
+
+	return nil
+}
+
+// EnsureBootstrapCredential creates the admin credential for the configured API key
+// unless it exists already, so that the very first credentials can be issued over the API.
+func EnsureBootstrapCredential(db gorm.DB, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	_, err := credential.GetCredentialByKeyHash(db, HashAPIKey(key))
+	if err == nil {
+		return nil
+	} else if !errors.As(err, &credential.NotFound{}) {
+		return err
+	}
+
+	_, err = credential.Create(db, credential.PendingCredential{
+		ID:      uuid.Must(uuid.NewV4()),
+		Name:    "bootstrap",
+		Role:    credential.RoleAdmin,
+		KeyHash: HashAPIKey(key),
+	})
+
+	return err
+}
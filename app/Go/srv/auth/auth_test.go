@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"path/filepath"
+	"sports/backend/domain/models/credential"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+	"time"
+)
+
+var _ = Describe("Authenticating requests", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	settings := auth.Settings{TokenSecret: []byte("secret"), TokenTTL: time.Minute}
+
+	var key string
+	var pendingCredential credential.PendingCredential
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		var keyHash string
+		key, keyHash, err = auth.GenerateAPIKey()
+		Expect(err).To(BeNil())
+
+		pendingCredential = credential.PendingCredential{
+			ID:      uuid.Must(uuid.NewV4()),
+			Name:    "Admin",
+			Role:    credential.RoleAdmin,
+			KeyHash: keyHash,
+		}
+
+		_, err = credential.Create(*db, pendingCredential)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	When("the request has valid API key", func() {
+		Specify("the identity returned", func() {
+			req, _ := http.NewRequest("GET", "/results", nil)
+			req.Header.Set("X-API-Key", key)
+
+			identity, err := auth.Authenticate(*db, settings, req)
+			Expect(err).To(BeNil())
+			Expect(identity.CredentialID).To(Equal(pendingCredential.ID))
+			Expect(identity.Role).To(Equal(credential.RoleAdmin))
+			Expect(identity.Method).To(Equal(auth.MethodAPIKey))
+		})
+	})
+
+	When("the request has valid bearer token", func() {
+		Specify("the identity returned", func() {
+			token, _, err := auth.IssueToken(settings.TokenSecret, auth.Identity{CredentialID: pendingCredential.ID}, time.Minute)
+			Expect(err).To(BeNil())
+
+			req, _ := http.NewRequest("GET", "/results", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			identity, err := auth.Authenticate(*db, settings, req)
+			Expect(err).To(BeNil())
+			Expect(identity.CredentialID).To(Equal(pendingCredential.ID))
+			Expect(identity.Method).To(Equal(auth.MethodToken))
+		})
+	})
+
+	When("the request has no credentials", func() {
+		Specify("the error returned", func() {
+			req, _ := http.NewRequest("GET", "/results", nil)
+
+			_, err := auth.Authenticate(*db, settings, req)
+			Expect(errors.As(err, &auth.Unauthenticated{})).To(BeTrue())
+		})
+	})
+
+	When("the credential is revoked", func() {
+		Specify("both API key and token are rejected", func() {
+			token, _, err := auth.IssueToken(settings.TokenSecret, auth.Identity{CredentialID: pendingCredential.ID}, time.Minute)
+			Expect(err).To(BeNil())
+
+			created, err := credential.GetCredential(*db, pendingCredential.ID, nil)
+			Expect(err).To(BeNil())
+
+			_, err = credential.Revoke(*db, utils.MakeTimestampInMilliseconds(), *created)
+			Expect(err).To(BeNil())
+
+			req, _ := http.NewRequest("GET", "/results", nil)
+			req.Header.Set("X-API-Key", key)
+			_, err = auth.Authenticate(*db, settings, req)
+			Expect(errors.As(err, &auth.Unauthenticated{})).To(BeTrue())
+
+			req, _ = http.NewRequest("GET", "/results", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			_, err = auth.Authenticate(*db, settings, req)
+			Expect(errors.As(err, &auth.Unauthenticated{})).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Authorizing the checkpoint", func() {
+	checkpointID := uuid.Must(uuid.NewV4())
+
+	Specify("the admin may use any checkpoint", func() {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{Role: credential.RoleAdmin})
+
+		identity, err := auth.RequireCheckpoint(ctx, checkpointID)
+		Expect(err).To(BeNil())
+		Expect(identity.Role).To(Equal(credential.RoleAdmin))
+	})
+
+	Specify("the timekeeper may use the bound checkpoints only", func() {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{
+			Role:          credential.RoleTimekeeper,
+			CheckpointIDs: []uuid.UUID{checkpointID},
+		})
+
+		_, err := auth.RequireCheckpoint(ctx, checkpointID)
+		Expect(err).To(BeNil())
+
+		_, err = auth.RequireCheckpoint(ctx, uuid.Must(uuid.NewV4()))
+		Expect(errors.As(err, &auth.Forbidden{})).To(BeTrue())
+	})
+
+	Specify("the context without identity is rejected", func() {
+		_, err := auth.RequireCheckpoint(context.Background(), checkpointID)
+		Expect(errors.As(err, &auth.Unauthenticated{})).To(BeTrue())
+	})
+})
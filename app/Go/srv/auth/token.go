@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v4"
+	"time"
+)
+
+// Claims of the bearer token, the role is informational, the credential is reloaded on every request.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// IssueToken signs a bearer token for the identity valid for the given time.
+func IssueToken(secret []byte, identity Identity, ttl time.Duration) (string, time.Time, error) {
+	if len(secret) == 0 {
+		return "", time.Time{}, errors.New("Token secret is not configured")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   identity.CredentialID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: identity.Role,
+	})
+
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseToken verifies the bearer token signature and expiration.
+func ParseToken(secret []byte, token string) (*Claims, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("Token secret is not configured")
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("Unexpected signing method %v", t.Header["alg"])
+		}
+
+		return secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,92 @@
+package auth_test
+
+import (
+	"github.com/gofrs/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sports/backend/domain/models/credential"
+	"sports/backend/srv/auth"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Credentials", func() {
+	secret := []byte("secret")
+
+	Describe("Issuing bearer token", func() {
+		identity := auth.Identity{
+			CredentialID: uuid.Must(uuid.NewV4()),
+			Role:         credential.RoleViewer,
+		}
+
+		When("the token is parsed with the same secret", func() {
+			Specify("the claims returned", func() {
+				token, _, err := auth.IssueToken(secret, identity, time.Minute)
+				Expect(err).To(BeNil())
+
+				claims, err := auth.ParseToken(secret, token)
+				Expect(err).To(BeNil())
+				Expect(claims.Subject).To(Equal(identity.CredentialID.String()))
+				Expect(claims.Role).To(Equal(credential.RoleViewer))
+			})
+		})
+
+		When("the token is parsed with another secret", func() {
+			Specify("the error returned", func() {
+				token, _, err := auth.IssueToken(secret, identity, time.Minute)
+				Expect(err).To(BeNil())
+
+				_, err = auth.ParseToken([]byte("another"), token)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+
+		When("the token has expired", func() {
+			Specify("the error returned", func() {
+				token, _, err := auth.IssueToken(secret, identity, -time.Minute)
+				Expect(err).To(BeNil())
+
+				_, err = auth.ParseToken(secret, token)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	Describe("Generating API key", func() {
+		Specify("the key and its hash", func() {
+			key, keyHash, err := auth.GenerateAPIKey()
+			Expect(err).To(BeNil())
+			Expect(strings.HasPrefix(key, "sk_")).To(BeTrue())
+			Expect(keyHash).To(Equal(auth.HashAPIKey(key)))
+			Expect(keyHash).ToNot(ContainSubstring(key))
+		})
+	})
+
+	Describe("Generating enrollment code", func() {
+		Specify("the code and its hash", func() {
+			code, codeHash, err := auth.GenerateEnrollmentCode()
+			Expect(err).To(BeNil())
+			Expect(code).To(MatchRegexp(`^[A-Z2-9]{4}-[A-Z2-9]{4}$`))
+			Expect(codeHash).To(Equal(auth.HashEnrollmentCode(code)))
+			Expect(auth.HashEnrollmentCode(strings.ToLower(strings.Replace(code, "-", "", 1)))).To(Equal(codeHash))
+		})
+	})
+
+	Describe("Checking checkpoint access", func() {
+		checkpointID := uuid.Must(uuid.NewV4())
+
+		Specify("admin may use any checkpoint", func() {
+			Expect(auth.Identity{Role: credential.RoleAdmin}.CanUseCheckpoint(checkpointID)).To(BeTrue())
+		})
+
+		Specify("timekeeper may use the bound checkpoints only", func() {
+			identity := auth.Identity{Role: credential.RoleTimekeeper, CheckpointIDs: []uuid.UUID{checkpointID}}
+			Expect(identity.CanUseCheckpoint(checkpointID)).To(BeTrue())
+			Expect(identity.CanUseCheckpoint(uuid.Must(uuid.NewV4()))).To(BeFalse())
+		})
+
+		Specify("viewer may not use any checkpoint", func() {
+			Expect(auth.Identity{Role: credential.RoleViewer}.CanUseCheckpoint(checkpointID)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,251 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files of the local CA and the server certificate generated in the directory.
+const (
+	CACertFile     = "ca.crt"
+	CAKeyFile      = "ca.key"
+	ServerCertFile = "server.crt"
+	ServerKeyFile  = "server.key"
+)
+
+const (
+	caTTL     = 10 * 365 * 24 * time.Hour
+	serverTTL = 365 * 24 * time.Hour
+
+	// renewBefore is the time before the expiry the generated server certificate is renewed at.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// LocalCA is the certificate authority generated for the local setup, it signs the server
+// certificate and the client certificates of the devices.
+type LocalCA struct {
+	Cert *x509.Certificate
+	Key  *ecdsa.PrivateKey
+
+	// CertFile is the path of the CA certificate the clients trust.
+	CertFile string
+}
+
+// EnsureLocalCA loads the CA from the directory, it is generated on the first start.
+func EnsureLocalCA(dir string) (*LocalCA, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	certFile := filepath.Join(dir, CACertFile)
+	keyFile := filepath.Join(dir, CAKeyFile)
+
+	if !exists(certFile) || !exists(keyFile) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		template, err := newTemplate("Sports event timing local CA", caTTL)
+		if err != nil {
+			return nil, err
+		}
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writePair(certFile, keyFile, der, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cert, key, err := loadPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalCA{Cert: cert, Key: key, CertFile: certFile}, nil
+}
+
+// EnsureServerCert returns the paths of the server certificate and key signed by the CA for the hosts,
+// the certificate is generated again when it is missing, expiring or issued for other hosts.
+func (ca *LocalCA) EnsureServerCert(dir string, hosts []string) (string, string, error) {
+	certFile := filepath.Join(dir, ServerCertFile)
+	keyFile := filepath.Join(dir, ServerKeyFile)
+
+	if exists(certFile) && exists(keyFile) {
+		cert, _, err := loadPair(certFile, keyFile)
+		if err == nil && current(cert, ca, hosts) {
+			return certFile, keyFile, nil
+		}
+	}
+
+	template, err := newTemplate(hosts[0], serverTTL)
+	if err != nil {
+		return "", "", err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, key, err := ca.sign(template)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = writePair(certFile, keyFile, der, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	return certFile, keyFile, nil
+}
+
+// IssueClientCert issues the client certificate of the device, the common name is the device id.
+func (ca *LocalCA) IssueClientCert(commonName string, ttl time.Duration) (certPEM []byte, keyPEM []byte, err error) {
+	template, err := newTemplate(commonName, ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	der, key, err := ca.sign(template)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		nil
+}
+
+func (ca *LocalCA) sign(template *x509.Certificate) ([]byte, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, &key.PublicKey, ca.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return der, key, nil
+}
+
+// current reports whether the certificate is signed by the CA for the hosts and is not expiring.
+func current(cert *x509.Certificate, ca *LocalCA, hosts []string) bool {
+	if cert.CheckSignatureFrom(ca.Cert) != nil || time.Now().Add(renewBefore).After(cert.NotAfter) {
+		return false
+	}
+
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newTemplate(commonName string, ttl time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Sports event timing"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(ttl),
+	}, nil
+}
+
+func writePair(certFile, keyFile string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+}
+
+func loadPair(certFile, keyFile string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("the CA key must be the ECDSA key")
+	}
+
+	return cert, key, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// CertPool returns the pool of the certificates of the PEM file.
+func CertPool(file string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificates in %s", file)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,159 @@
+package certs_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"github.com/gofrs/uuid"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/certs"
+	"time"
+)
+
+var _ = Describe("Certificates", func() {
+	var (
+		dir      string
+		settings certs.Settings
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "certs")
+		Expect(err).To(BeNil())
+
+		settings = certs.Settings{
+			Mode:       certs.ModeTLS,
+			Dir:        dir,
+			Hosts:      []string{"localhost", "127.0.0.1"},
+			MinVersion: "1.2",
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Specify("Local CA and server certificate generated on the first start and reused", func() {
+		_, err := certs.ServerConfig(settings)
+		Expect(err).To(BeNil())
+
+		first, err := ioutil.ReadFile(filepath.Join(dir, certs.ServerCertFile))
+		Expect(err).To(BeNil())
+
+		_, err = certs.ServerConfig(settings)
+		Expect(err).To(BeNil())
+
+		second, err := ioutil.ReadFile(filepath.Join(dir, certs.ServerCertFile))
+		Expect(err).To(BeNil())
+		Expect(second).To(Equal(first))
+	})
+
+	Specify("Server certificate generated again for the other hosts", func() {
+		_, err := certs.ServerConfig(settings)
+		Expect(err).To(BeNil())
+
+		settings.Hosts = []string{"timing.local"}
+		cfg, err := certs.ServerConfig(settings)
+		Expect(err).To(BeNil())
+
+		cert, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+		Expect(err).To(BeNil())
+		Expect(cert.VerifyHostname("timing.local")).To(Succeed())
+	})
+
+	Specify("No TLS configuration behind the proxy", func() {
+		settings.Mode = certs.ModeOff
+
+		cfg, err := certs.ServerConfig(settings)
+		Expect(err).To(BeNil())
+		Expect(cfg).To(BeNil())
+	})
+
+	Specify("Unsupported TLS version rejected", func() {
+		settings.MinVersion = "1.0"
+
+		_, err := certs.ServerConfig(settings)
+		Expect(err).NotTo(BeNil())
+	})
+
+	Describe("Device client certificates", func() {
+		var (
+			server   *httptest.Server
+			state    chan *tls.ConnectionState
+			deviceID uuid.UUID
+			identity auth.Identity
+			required = auth.Settings{RequireDeviceCerts: true}
+		)
+
+		BeforeEach(func() {
+			cfg, err := certs.ServerConfig(settings)
+			Expect(err).To(BeNil())
+
+			state = make(chan *tls.ConnectionState, 1)
+			server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				state <- r.TLS
+			}))
+			server.TLS = cfg
+			server.StartTLS()
+
+			deviceID = uuid.Must(uuid.NewV4())
+			identity = auth.Identity{DeviceID: &deviceID}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		// connect calls the server trusting the local CA with the client certificate of the common name.
+		connect := func(commonName string) *tls.ConnectionState {
+			ca, err := certs.EnsureLocalCA(dir)
+			Expect(err).To(BeNil())
+
+			pool, err := certs.CertPool(ca.CertFile)
+			Expect(err).To(BeNil())
+
+			cfg := &tls.Config{RootCAs: pool}
+
+			if commonName != "" {
+				certPEM, keyPEM, err := ca.IssueClientCert(commonName, 24*time.Hour)
+				Expect(err).To(BeNil())
+
+				cert, err := tls.X509KeyPair(certPEM, keyPEM)
+				Expect(err).To(BeNil())
+				cfg.Certificates = []tls.Certificate{cert}
+			}
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+			resp, err := client.Get(server.URL)
+			Expect(err).To(BeNil())
+			resp.Body.Close()
+
+			return <-state
+		}
+
+		Specify("Certificate of the device accepted", func() {
+			Expect(auth.VerifyDeviceCertificate(required, identity, connect(deviceID.String()))).To(Succeed())
+		})
+
+		Specify("Missing certificate rejected", func() {
+			err := auth.VerifyDeviceCertificate(required, identity, connect(""))
+			Expect(errors.As(err, &auth.Unauthenticated{})).To(BeTrue())
+		})
+
+		Specify("Certificate of another device rejected", func() {
+			err := auth.VerifyDeviceCertificate(required, identity, connect(uuid.Must(uuid.NewV4()).String()))
+			Expect(errors.As(err, &auth.Unauthenticated{})).To(BeTrue())
+		})
+
+		Specify("Certificate not required unless configured", func() {
+			Expect(auth.VerifyDeviceCertificate(auth.Settings{}, identity, connect(""))).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package certs
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// Modes of serving the API.
+const (
+	ModeTLS = "tls"
+	// ModeOff serves plain HTTP and gRPC behind the proxy terminating TLS.
+	ModeOff = "off"
+)
+
+// Settings configure the TLS of the servers.
+type Settings struct {
+	Mode string
+
+	// CertFile and KeyFile are the server certificate, when not given the local CA
+	// and the server certificate for the Hosts are generated in the Dir.
+	CertFile string
+	KeyFile  string
+	Dir      string
+	Hosts    []string
+
+	// MinVersion is the minimum TLS version, "1.2" or "1.3".
+	MinVersion string
+
+	// ClientCAFile is the CA of the device client certificates, the local CA when not given.
+	ClientCAFile string
+}
+
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ServerConfig returns the TLS configuration of the HTTP and gRPC servers, nil in the ModeOff.
+// The client certificates are verified when they are given, the devices may be required
+// to present them by the authentication.
+func ServerConfig(settings Settings) (*tls.Config, error) {
+	if settings.Mode == ModeOff {
+		return nil, nil
+	}
+
+	version, ok := versions[settings.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS version %q", settings.MinVersion)
+	}
+
+	certFile, keyFile, clientCAFile := settings.CertFile, settings.KeyFile, settings.ClientCAFile
+
+	if certFile == "" || clientCAFile == "" {
+		ca, err := EnsureLocalCA(settings.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("generating the local CA: %w", err)
+		}
+
+		if certFile == "" {
+			certFile, keyFile, err = ca.EnsureServerCert(settings.Dir, settings.Hosts)
+			if err != nil {
+				return nil, fmt.Errorf("generating the server certificate: %w", err)
+			}
+		}
+
+		if clientCAFile == "" {
+			clientCAFile = ca.CertFile
+		}
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs, err := CertPool(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   version,
+		ClientAuth:   tls.VerifyClientCertIfGiven,
+		ClientCAs:    clientCAs,
+		// HTTP/2 is negotiated for the gRPC clients.
+		NextProtos: []string{"h2", "http/1.1"},
+	}, nil
+}
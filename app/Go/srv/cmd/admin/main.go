@@ -0,0 +1,265 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/device"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/certs"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/utils"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const usage = `Usage: admin [-config <file>] <command> [flags]
+
+The configuration is read like by the server, the SPORTS_* environment variables override the file.
+
+Commands:
+  issue   -name <name> -role <admin|timekeeper|viewer> [-checkpoints <id,id>]
+          Issues the credential and prints its API key, the key is shown only once.
+  revoke  -id <credential id>
+          Revokes the credential, its API key and bearer tokens stop working immediately.
+  list    Lists the credentials.
+  token   -id <credential id> [-ttl <duration>]
+          Issues the bearer token of the credential.
+  device-cert -id <device id> [-out <dir>] [-ttl <duration>]
+          Issues the client certificate of the device signed by the local CA of tls_dir,
+          writes <device id>.crt and <device id>.key.
+`
+
+func main() {
+	configPath := flag.String("config", "", "configuration file, "+config.DefaultFile+" by default")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var configArgs []string
+	if *configPath != "" {
+		configArgs = []string{"--config", *configPath}
+	}
+
+	cfg, err := config.Load(configArgs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	args := flag.Args()[1:]
+
+	switch flag.Arg(0) {
+	case "issue":
+		err = issue(*db, args)
+	case "revoke":
+		err = revoke(*db, args)
+	case "list":
+		err = list(*db)
+	case "token":
+		err = token(*db, cfg, args)
+	case "device-cert":
+		err = deviceCert(*db, cfg, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func issue(db gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("issue", flag.ExitOnError)
+	name := fs.String("name", "", "credential name, e.g. the device or the person")
+	role := fs.String("role", "", "credential role: admin, timekeeper or viewer")
+	checkpoints := fs.String("checkpoints", "", "comma separated checkpoint ids of the timekeeper")
+	fs.Parse(args)
+
+	var checkpointIDs []uuid.UUID
+	for _, value := range strings.Split(*checkpoints, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		id, err := uuid.FromString(value)
+		if err != nil {
+			return fmt.Errorf("checkpoints: invalid value %q", value)
+		}
+
+		checkpointIDs = append(checkpointIDs, id)
+	}
+
+	key, keyHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return err
+	}
+
+	credentialCreatedEvent, err := credential.Create(db, credential.PendingCredential{
+		ID:            uuid.Must(uuid.NewV4()),
+		Name:          *name,
+		Role:          *role,
+		CheckpointIDs: checkpointIDs,
+		KeyHash:       keyHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Credential: %s\nAPI key:    %s\n", credentialCreatedEvent.CredentialID, key)
+
+	return nil
+}
+
+func revoke(db gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := fs.String("id", "", "credential id")
+	fs.Parse(args)
+
+	credentialID, err := uuid.FromString(*id)
+	if err != nil {
+		return fmt.Errorf("id: invalid value %q", *id)
+	}
+
+	found, err := credential.GetCredential(db, credentialID, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = credential.Revoke(db, utils.MakeTimestampInMilliseconds(), *found)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Credential %s revoked\n", credentialID)
+
+	return nil
+}
+
+func list(db gorm.DB) error {
+	credentials, err := credential.GetCredentials(db)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tROLE\tCHECKPOINTS\tREVOKED")
+	for _, c := range *credentials {
+		revoked := ""
+		if c.RevokedAt != nil {
+			revoked = time.Unix(0, *c.RevokedAt*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Role, strings.Join(c.CheckpointIDs, ","), revoked)
+	}
+
+	return w.Flush()
+}
+
+func token(db gorm.DB, cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	id := fs.String("id", "", "credential id")
+	ttl := fs.Duration("ttl", cfg.AuthTokenTTL, "token lifetime")
+	fs.Parse(args)
+
+	credentialID, err := uuid.FromString(*id)
+	if err != nil {
+		return fmt.Errorf("id: invalid value %q", *id)
+	}
+
+	found, err := credential.GetCredential(db, credentialID, nil)
+	if err != nil {
+		return err
+	}
+
+	if found.RevokedAt != nil {
+		return credential.AlreadyRevoked{}
+	}
+
+	signed, expiresAt, err := auth.IssueToken([]byte(cfg.AuthTokenSecret), auth.Identity{
+		CredentialID: found.ID,
+		Name:         found.Name,
+		Role:         found.Role,
+	}, *ttl)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Token:   %s\nExpires: %s\n", signed, expiresAt.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+func deviceCert(db gorm.DB, cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("device-cert", flag.ExitOnError)
+	id := fs.String("id", "", "device id")
+	out := fs.String("out", ".", "directory to write the certificate and the key to")
+	ttl := fs.Duration("ttl", 365*24*time.Hour, "certificate lifetime")
+	fs.Parse(args)
+
+	deviceID, err := uuid.FromString(*id)
+	if err != nil {
+		return fmt.Errorf("id: invalid value %q", *id)
+	}
+
+	found, err := device.GetDevice(db, deviceID, nil)
+	if err != nil {
+		return err
+	}
+
+	if found.RevokedAt != nil {
+		return device.AlreadyRevoked{}
+	}
+
+	ca, err := certs.EnsureLocalCA(cfg.TLSDir)
+	if err != nil {
+		return err
+	}
+
+	certPEM, keyPEM, err := ca.IssueClientCert(found.ID.String(), *ttl)
+	if err != nil {
+		return err
+	}
+
+	certFile := filepath.Join(*out, found.ID.String()+".crt")
+	keyFile := filepath.Join(*out, found.ID.String()+".key")
+
+	err = ioutil.WriteFile(keyFile, keyPEM, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(certFile, certPEM, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Certificate: %s\nKey:         %s\nCA:          %s\n", certFile, keyFile, ca.CertFile)
+
+	return nil
+}
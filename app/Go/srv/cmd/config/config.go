@@ -1,14 +1,252 @@
 package config
 
-// config declares connection details.
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DefaultFile is the configuration file read when no other file is given with the --config flag.
+const DefaultFile = "./srv/cmd/config/configuration.yaml"
+
+// EnvPrefix prefixes the environment variables overriding the settings, e.g. SPORTS_DB_HOST.
+const EnvPrefix = "SPORTS"
+
+// FileSuffix suffixes the settings naming the files the secrets are read from, e.g. db_password_file.
+const FileSuffix = "_file"
+
+// Config declares the settings of the service, the secrets are not printed and may be read from the files.
+// The reloadable settings are applied on SIGHUP without restarting the service.
 type Config struct {
 	DBHost     string `mapstructure:"db_host"`
 	DBDriver   string `mapstructure:"db_driver"`
 	DBUsername string `mapstructure:"db_username"`
-	DBPassword string `mapstructure:"db_password"`
+	DBPassword string `mapstructure:"db_password" secret:"true"`
 	DBName     string `mapstructure:"db_name"`
 	DBPort     string `mapstructure:"db_port"`
 
 	APIAddress     string `mapstructure:"api_address"`
 	TestAPIAddress string `mapstructure:"test_api_address"`
+	GRPCAddress    string `mapstructure:"grpc_address"`
+
+	ShutdownGracePeriod time.Duration `mapstructure:"shutdown_grace_period"`
+
+	DashboardSnapshotPolicy string                   `mapstructure:"dashboard_snapshot_policy"`
+	DashboardSnapshotSize   int                      `mapstructure:"dashboard_snapshot_size"`
+	DashboardSnapshotEvents []DashboardSnapshotEvent `mapstructure:"dashboard_snapshot_events"`
+	DashboardReconnectIn    time.Duration            `mapstructure:"dashboard_reconnect_in"`
+
+	AuthTokenSecret     string        `mapstructure:"auth_token_secret" secret:"true"`
+	AuthTokenTTL        time.Duration `mapstructure:"auth_token_ttl"`
+	AuthBootstrapAPIKey string        `mapstructure:"auth_bootstrap_api_key" secret:"true"`
+	CORSAllowedOrigins  []string      `mapstructure:"cors_allowed_origins" reloadable:"true"`
+
+	IdempotencyKeyTTL time.Duration `mapstructure:"idempotency_key_ttl"`
+
+	OutboxInterval    time.Duration `mapstructure:"outbox_interval"`
+	OutboxBatchSize   int           `mapstructure:"outbox_batch_size"`
+	OutboxMaxAttempts int           `mapstructure:"outbox_max_attempts"`
+	OutboxRetention   time.Duration `mapstructure:"outbox_retention"`
+
+	WebhookTimeout     time.Duration `mapstructure:"webhook_timeout"`
+	WebhookMaxAttempts int           `mapstructure:"webhook_max_attempts"`
+	WebhookBackoff     time.Duration `mapstructure:"webhook_backoff"`
+	WebhookMaxBackoff  time.Duration `mapstructure:"webhook_max_backoff"`
+
+	LogEncoding string `mapstructure:"log_encoding"`
+	LogLevel    string `mapstructure:"log_level" reloadable:"true"`
+
+	TracingExporter     string  `mapstructure:"tracing_exporter"`
+	TracingOTLPEndpoint string  `mapstructure:"tracing_otlp_endpoint"`
+	TracingOTLPInsecure bool    `mapstructure:"tracing_otlp_insecure"`
+	TracingSampleRatio  float64 `mapstructure:"tracing_sample_ratio"`
+
+	TLSMode               string   `mapstructure:"tls_mode"`
+	TLSCertFile           string   `mapstructure:"tls_cert_file"`
+	TLSKeyFile            string   `mapstructure:"tls_key_file"`
+	TLSDir                string   `mapstructure:"tls_dir"`
+	TLSHosts              []string `mapstructure:"tls_hosts"`
+	TLSMinVersion         string   `mapstructure:"tls_min_version"`
+	TLSClientCAFile       string   `mapstructure:"tls_client_ca_file"`
+	TLSRequireDeviceCerts bool     `mapstructure:"tls_require_device_certs"`
+}
+
+// DashboardSnapshotEvent overrides the dashboard snapshot policy and size for the clients subscribed to the event.
+type DashboardSnapshotEvent struct {
+	Event  string `mapstructure:"event" yaml:"event" json:"event"`
+	Policy string `mapstructure:"policy" yaml:"policy" json:"policy"`
+	Size   int    `mapstructure:"size" yaml:"size" json:"size"`
+}
+
+// Default returns the settings used unless they are overridden.
+func Default() Config {
+	return Config{
+		DBHost:     "localhost",
+		DBDriver:   "postgres",
+		DBUsername: "postgres",
+		DBName:     "sport_events",
+		DBPort:     "5432",
+
+		APIAddress:  ":8000",
+		GRPCAddress: ":9000",
+
+		ShutdownGracePeriod: 5 * time.Second,
+
+		DashboardSnapshotPolicy: "last",
+		DashboardSnapshotSize:   10,
+		DashboardReconnectIn:    5 * time.Second,
+
+		AuthTokenTTL: time.Hour,
+
+		IdempotencyKeyTTL: 24 * time.Hour,
+
+		OutboxInterval:    time.Second,
+		OutboxBatchSize:   100,
+		OutboxMaxAttempts: 10,
+		OutboxRetention:   24 * time.Hour,
+
+		WebhookTimeout:     10 * time.Second,
+		WebhookMaxAttempts: 8,
+		WebhookBackoff:     10 * time.Second,
+		WebhookMaxBackoff:  time.Hour,
+
+		LogEncoding: "json",
+		LogLevel:    "info",
+
+		TracingExporter:    "none",
+		TracingSampleRatio: 1,
+
+		TLSMode:       "tls",
+		TLSDir:        "./srv/tls",
+		TLSHosts:      []string{"localhost", "backend", "127.0.0.1"},
+		TLSMinVersion: "1.2",
+	}
+}
+
+// Load builds the configuration from the layers, each overriding the previous one: the defaults,
+// the configuration file, the SPORTS_* environment variables and the command-line flags.
+// The secrets are read from the files named by the <setting>_file settings when they are given.
+func Load(args []string) (Config, error) {
+	cfg := Config{}
+
+	v := viper.New()
+	v.SetEnvPrefix(EnvPrefix)
+	v.AutomaticEnv()
+
+	flags := pflag.NewFlagSet("backend", pflag.ContinueOnError)
+	file := flags.String("config", DefaultFile, "Path of the configuration file.")
+
+	for _, setting := range settingsOf(Default()) {
+		v.SetDefault(setting.key, setting.value)
+
+		flag := flagName(setting.key)
+		switch value := setting.value.(type) {
+		case string:
+			flags.String(flag, value, "")
+		case int:
+			flags.Int(flag, value, "")
+		case bool:
+			flags.Bool(flag, value, "")
+		case float64:
+			flags.Float64(flag, value, "")
+		case time.Duration:
+			flags.Duration(flag, value, "")
+		case []string:
+			flags.StringSlice(flag, value, "")
+		default:
+			// The structured settings are read from the configuration file only.
+			continue
+		}
+
+		if setting.secret {
+			fileKey := setting.key + FileSuffix
+			flags.String(flagName(fileKey), "", "Path of the file to read "+setting.key+" from.")
+
+			err := bindFlag(v, flags, fileKey)
+			if err != nil {
+				return cfg, err
+			}
+			v.BindEnv(fileKey)
+		}
+
+		err := bindFlag(v, flags, setting.key)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return cfg, err
+	}
+
+	v.SetConfigFile(*file)
+	err = v.ReadInConfig()
+	if err != nil && !(errors.Is(err, os.ErrNotExist) && !flags.Changed("config")) {
+		return cfg, fmt.Errorf("reading the configuration file %s: %w", *file, err)
+	}
+
+	for _, setting := range settingsOf(Default()) {
+		if !setting.secret {
+			continue
+		}
+
+		path := v.GetString(setting.key + FileSuffix)
+		if path == "" {
+			continue
+		}
+
+		secret, err := ioutil.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("reading %s: %w", setting.key, err)
+		}
+		v.Set(setting.key, strings.TrimRight(string(secret), "\r\n"))
+	}
+
+	err = v.Unmarshal(&cfg)
+	if err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+func bindFlag(v *viper.Viper, flags *pflag.FlagSet, key string) error {
+	return v.BindPFlag(key, flags.Lookup(flagName(key)))
+}
+
+// flagName returns the command-line flag of the setting, e.g. --db-host.
+func flagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
+
+// setting is a single setting of the configuration in the order of declaration.
+type setting struct {
+	key        string
+	value      interface{}
+	secret     bool
+	reloadable bool
+}
+
+func settingsOf(cfg Config) []setting {
+	value := reflect.ValueOf(cfg)
+	settings := make([]setting, 0, value.NumField())
+
+	for index := 0; index < value.NumField(); index++ {
+		field := value.Type().Field(index)
+		settings = append(settings, setting{
+			key:        field.Tag.Get("mapstructure"),
+			value:      value.Field(index).Interface(),
+			secret:     field.Tag.Get("secret") == "true",
+			reloadable: field.Tag.Get("reloadable") == "true",
+		})
+	}
+
+	return settings
 }
@@ -0,0 +1,149 @@
+package config_test
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sports/backend/srv/cmd/config"
+	"time"
+)
+
+var _ = Describe("Configuration", func() {
+	var (
+		dir  string
+		file string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "config")
+		Expect(err).To(BeNil())
+
+		file = filepath.Join(dir, "configuration.yaml")
+		Expect(ioutil.WriteFile(file, []byte("db_host: file-host\ndb_port: 5433\napi_address: :8001\nauth_token_ttl: 2h\n"), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("SPORTS_DB_PORT")
+		os.Unsetenv("SPORTS_API_ADDRESS")
+		os.Unsetenv("SPORTS_DB_PASSWORD_FILE")
+		os.RemoveAll(dir)
+	})
+
+	Specify("Layers override each other", func() {
+		os.Setenv("SPORTS_DB_PORT", "5434")
+		os.Setenv("SPORTS_API_ADDRESS", ":8002")
+
+		cfg, err := config.Load([]string{"--config", file, "--api-address", ":8003"})
+		Expect(err).To(BeNil())
+
+		// Default.
+		Expect(cfg.DBName).To(Equal("sport_events"))
+		// File.
+		Expect(cfg.DBHost).To(Equal("file-host"))
+		Expect(cfg.AuthTokenTTL).To(Equal(2 * time.Hour))
+		// Environment.
+		Expect(cfg.DBPort).To(Equal("5434"))
+		// Flag.
+		Expect(cfg.APIAddress).To(Equal(":8003"))
+	})
+
+	Specify("Secret read from the file", func() {
+		secret := filepath.Join(dir, "db_password")
+		Expect(ioutil.WriteFile(secret, []byte("s3cret\n"), 0600)).To(Succeed())
+		os.Setenv("SPORTS_DB_PASSWORD_FILE", secret)
+
+		cfg, err := config.Load([]string{"--config", file})
+		Expect(err).To(BeNil())
+		Expect(cfg.DBPassword).To(Equal("s3cret"))
+	})
+
+	Specify("Snapshot policies of the events read from the file", func() {
+		Expect(ioutil.WriteFile(file, []byte("dashboard_snapshot_events:\n  - event: City Marathon\n    policy: leaderboard\n    size: 20\n"), 0600)).To(Succeed())
+
+		cfg, err := config.Load([]string{"--config", file})
+		Expect(err).To(BeNil())
+		Expect(cfg.DashboardSnapshotEvents).To(Equal([]config.DashboardSnapshotEvent{
+			{Event: "City Marathon", Policy: "leaderboard", Size: 20},
+		}))
+
+		cfg.AuthTokenSecret = "0123456789abcdef"
+		Expect(cfg.Validate()).To(Succeed())
+
+		cfg.DashboardSnapshotEvents[0].Event = ""
+		Expect(cfg.Validate()).To(MatchError(ContainSubstring("dashboard_snapshot_events: (0: (event: cannot be blank.).)")))
+	})
+
+	Specify("Missing configuration file rejected", func() {
+		_, err := config.Load([]string{"--config", filepath.Join(dir, "missing.yaml")})
+		Expect(err).NotTo(BeNil())
+	})
+
+	Specify("Invalid settings listed", func() {
+		cfg, err := config.Load([]string{"--config", file, "--log-encoding", "xml", "--tracing-exporter", "otlp"})
+		Expect(err).To(BeNil())
+
+		err = cfg.Validate()
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("auth_token_secret: cannot be blank"))
+		Expect(err.Error()).To(ContainSubstring("log_encoding: must be a valid value"))
+		Expect(err.Error()).To(ContainSubstring("tracing_otlp_endpoint: cannot be blank"))
+
+		cfg.ShutdownGracePeriod = -time.Second
+		Expect(cfg.Validate()).To(MatchError(ContainSubstring("shutdown_grace_period: must be no less than 0")))
+		cfg.ShutdownGracePeriod = 0
+
+		cfg.AuthTokenSecret = "0123456789abcdef"
+		cfg.LogEncoding = "console"
+		cfg.TracingOTLPEndpoint = "collector:4317"
+		Expect(cfg.Validate()).To(Succeed())
+	})
+
+	Specify("Device certificates require TLS", func() {
+		cfg := config.Default()
+		cfg.AuthTokenSecret = "0123456789abcdef"
+		cfg.TLSMode = "off"
+		cfg.TLSRequireDeviceCerts = true
+		cfg.TLSCertFile = "server.crt"
+
+		err := cfg.Validate()
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring("tls_require_device_certs: requires tls_mode tls"))
+		Expect(err.Error()).To(ContainSubstring("tls_key_file: cannot be blank"))
+	})
+
+	Specify("Changes listed for the reload", func() {
+		current := config.Default()
+		current.DBPassword = "s3cret"
+
+		next := current
+		next.LogLevel = "debug"
+		next.DBHost = "db.example"
+		next.DBPassword = "n3w"
+		next.AuthTokenTTL = 2 * time.Hour
+
+		Expect(config.Changes(current, next)).To(Equal([]config.Change{
+			{Key: "db_host", Old: "localhost", New: "db.example"},
+			{Key: "db_password", Old: config.Redacted, New: config.Redacted},
+			{Key: "auth_token_ttl", Old: "1h0m0s", New: "2h0m0s"},
+			{Key: "log_level", Old: "info", New: "debug", Reloadable: true},
+		}))
+		Expect(config.Changes(current, current)).To(BeEmpty())
+	})
+
+	Specify("Secrets redacted when printed", func() {
+		cfg := config.Default()
+		cfg.DBPassword = "s3cret"
+
+		out := bytes.Buffer{}
+		Expect(config.Print(&out, cfg)).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("db_password: '" + config.Redacted + "'"))
+		Expect(out.String()).To(ContainSubstring("auth_token_secret: \"\""))
+		Expect(out.String()).To(ContainSubstring("auth_token_ttl: 1h0m0s"))
+		Expect(out.String()).NotTo(ContainSubstring("s3cret"))
+	})
+})
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"gopkg.in/yaml.v2"
+	"io"
+)
+
+// Redacted replaces the secrets set in the printed configuration.
+const Redacted = "[REDACTED]"
+
+// Print writes the configuration as YAML in the format of the configuration file, the secrets are redacted.
+func Print(w io.Writer, cfg Config) error {
+	settings := settingsOf(cfg)
+	document := make(yaml.MapSlice, 0, len(settings))
+
+	for _, setting := range settings {
+		value := setting.value
+
+		switch {
+		case setting.secret && value != "":
+			value = Redacted
+		default:
+			value = printable(value)
+		}
+
+		document = append(document, yaml.MapItem{Key: setting.key, Value: value})
+	}
+
+	b, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"reflect"
+	"time"
+)
+
+// Change is the setting changed between the loaded configurations, the values of the secrets are redacted.
+type Change struct {
+	Key        string
+	Old        interface{}
+	New        interface{}
+	Reloadable bool
+}
+
+// Changes lists the settings of the next configuration differing from the current one in the order of declaration.
+func Changes(current, next Config) []Change {
+	var changes []Change
+
+	nextSettings := settingsOf(next)
+	for index, setting := range settingsOf(current) {
+		if reflect.DeepEqual(setting.value, nextSettings[index].value) {
+			continue
+		}
+
+		change := Change{
+			Key:        setting.key,
+			Old:        printable(setting.value),
+			New:        printable(nextSettings[index].value),
+			Reloadable: setting.reloadable,
+		}
+
+		if setting.secret {
+			change.Old, change.New = Redacted, Redacted
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// printable returns the durations as strings, e.g. 1h0m0s.
+func printable(value interface{}) interface{} {
+	if duration, ok := value.(time.Duration); ok {
+		return duration.String()
+	}
+
+	return value
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"net"
+	"time"
+)
+
+// Validate checks the settings, the failures are listed by the setting name.
+func (c Config) Validate() error {
+	var otlpEndpointRules []validation.Rule
+	if c.TracingExporter == "otlp" {
+		otlpEndpointRules = append(otlpEndpointRules, validation.Required)
+	}
+
+	var tlsKeyFileRules, tlsCertFileRules, tlsRequireDeviceCertsRules []validation.Rule
+	if c.TLSCertFile != "" {
+		tlsKeyFileRules = append(tlsKeyFileRules, validation.Required)
+	}
+	if c.TLSKeyFile != "" {
+		tlsCertFileRules = append(tlsCertFileRules, validation.Required)
+	}
+	if c.TLSMode == "off" {
+		tlsRequireDeviceCertsRules = append(tlsRequireDeviceCertsRules, validation.In(false).Error("requires tls_mode tls"))
+	}
+
+	err := validation.Errors{
+		"db_host":     validation.Validate(c.DBHost, validation.Required),
+		"db_driver":   validation.Validate(c.DBDriver, validation.Required, validation.In("postgres")),
+		"db_username": validation.Validate(c.DBUsername, validation.Required),
+		"db_name":     validation.Validate(c.DBName, validation.Required),
+		"db_port":     validation.Validate(c.DBPort, validation.Required, is.Port),
+
+		"api_address":  validation.Validate(c.APIAddress, validation.Required, validation.By(address)),
+		"grpc_address": validation.Validate(c.GRPCAddress, validation.Required, validation.By(address)),
+
+		"shutdown_grace_period": validation.Validate(c.ShutdownGracePeriod, validation.Min(time.Duration(0))),
+
+		"dashboard_snapshot_size":   validation.Validate(c.DashboardSnapshotSize, validation.Min(1)),
+		"dashboard_snapshot_events": validation.Validate(c.DashboardSnapshotEvents),
+		"dashboard_reconnect_in":    validation.Validate(c.DashboardReconnectIn, validation.Min(time.Second)),
+
+		"auth_token_secret": validation.Validate(c.AuthTokenSecret, validation.Required, validation.Length(16, 0)),
+		"auth_token_ttl":    validation.Validate(c.AuthTokenTTL, validation.Min(time.Minute)),
+
+		"idempotency_key_ttl": validation.Validate(c.IdempotencyKeyTTL, validation.Min(time.Minute)),
+
+		"outbox_interval":     validation.Validate(c.OutboxInterval, validation.Min(10*time.Millisecond)),
+		"outbox_batch_size":   validation.Validate(c.OutboxBatchSize, validation.Min(1)),
+		"outbox_max_attempts": validation.Validate(c.OutboxMaxAttempts, validation.Min(1)),
+		"outbox_retention":    validation.Validate(c.OutboxRetention, validation.Min(time.Minute)),
+
+		"webhook_timeout":      validation.Validate(c.WebhookTimeout, validation.Min(100*time.Millisecond)),
+		"webhook_max_attempts": validation.Validate(c.WebhookMaxAttempts, validation.Min(1)),
+		"webhook_backoff":      validation.Validate(c.WebhookBackoff, validation.Min(time.Second)),
+		"webhook_max_backoff":  validation.Validate(c.WebhookMaxBackoff, validation.Min(c.WebhookBackoff)),
+
+		"log_encoding": validation.Validate(c.LogEncoding, validation.Required, validation.In("json", "console")),
+		"log_level":    validation.Validate(c.LogLevel, validation.Required, validation.In("debug", "info", "warn", "error")),
+
+		"tracing_exporter":      validation.Validate(c.TracingExporter, validation.Required, validation.In("none", "stdout", "otlp")),
+		"tracing_otlp_endpoint": validation.Validate(c.TracingOTLPEndpoint, otlpEndpointRules...),
+		"tracing_sample_ratio":  validation.Validate(c.TracingSampleRatio, validation.Min(0.0), validation.Max(1.0)),
+
+		"tls_mode":                 validation.Validate(c.TLSMode, validation.Required, validation.In("tls", "off")),
+		"tls_cert_file":            validation.Validate(c.TLSCertFile, tlsCertFileRules...),
+		"tls_key_file":             validation.Validate(c.TLSKeyFile, tlsKeyFileRules...),
+		"tls_hosts":                validation.Validate(c.TLSHosts, validation.Required),
+		"tls_min_version":          validation.Validate(c.TLSMinVersion, validation.Required, validation.In("1.2", "1.3")),
+		"tls_require_device_certs": validation.Validate(c.TLSRequireDeviceCerts, tlsRequireDeviceCertsRules...),
+	}.Filter()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks the snapshot policy of the event, the policy itself is checked by the dashboard.
+func (e DashboardSnapshotEvent) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Event, validation.Required),
+		validation.Field(&e.Size, validation.Min(1)),
+	)
+}
+
+// address checks the value is the listening address, e.g. ":8000".
+func address(value interface{}) error {
+	if _, _, err := net.SplitHostPort(value.(string)); err != nil {
+		return errors.New("must be the host:port address")
+	}
+
+	return nil
+}
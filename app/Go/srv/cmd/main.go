@@ -4,28 +4,51 @@ import (
 	"context"
 	"crypto/tls"
 	"github.com/gorilla/mux"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sports/backend/domain/models/idempotency"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/certs"
 	"sports/backend/srv/cmd/config"
 	"sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/health"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/middleware"
 	"sports/backend/srv/routes"
+	"sports/backend/srv/rpc"
 	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
 	"sports/backend/srv/utils"
+	"sports/backend/srv/webhooks"
 	"syscall"
 	"time"
 )
 
 var cfg config.Config
 
+// logLevel is the level of the global logger, it is changed on the configuration reload.
+var logLevel = zap.NewAtomicLevel()
+
 // initLogger initializes the zap logger with reasonable
-// defaults and replaces the global logger.
-func initLogger() error {
+// defaults and replaces the global logger, the entries are encoded as JSON unless "console" is configured.
+func initLogger(encoding, level string) error {
+	if encoding == "" {
+		encoding = "json"
+	}
+
+	err := logLevel.UnmarshalText([]byte(level))
+	if err != nil {
+		return err
+	}
+
 	// Initialize the logs encoder.
 	encoder := zap.NewProductionEncoderConfig()
 	encoder.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -33,13 +56,13 @@ func initLogger() error {
 
 	// Initialize the logger.
 	logger, err := zap.Config{
-		Level:       zap.NewAtomicLevelAt(zap.InfoLevel),
+		Level:       logLevel,
 		Development: false,
 		Sampling: &zap.SamplingConfig{
 			Initial:    100,
 			Thereafter: 100,
 		},
-		Encoding:         "console",
+		Encoding:         encoding,
 		EncoderConfig:    encoder,
 		OutputPaths:      []string{"stderr"},
 		ErrorOutputPaths: []string{"stderr"},
@@ -54,22 +77,77 @@ func initLogger() error {
 	return nil
 }
 
-// Load up configuration.
-func loadConfiguration() error {
-	viper.AddConfigPath("./srv/cmd/config")
-	viper.SetConfigName("configuration")
+// Load up configuration from the defaults, the file, the environment and the flags, and validate it.
+func loadConfiguration(args []string) error {
+	var err error
 
-	err := viper.ReadInConfig()
+	cfg, err = config.Load(args)
 	if err != nil {
 		return err
 	}
 
-	err = viper.Unmarshal(&cfg)
+	return cfg.Validate()
+}
+
+// reloadConfiguration loads the configuration again on SIGHUP and applies the reloadable settings,
+// the changes of the other settings are logged and wait for the restart. The invalid configuration is ignored.
+func reloadConfiguration(args []string) {
+	loaded, err := config.Load(args)
+	if err == nil {
+		err = loaded.Validate()
+	}
 	if err != nil {
-		return err
+		zap.S().Errorw("Configuration not reloaded", "error", err)
+		return
 	}
 
-	return nil
+	changes := config.Changes(cfg, loaded)
+	if len(changes) == 0 {
+		zap.S().Info("Configuration reloaded, nothing changed")
+		return
+	}
+
+	for _, change := range changes {
+		if !change.Reloadable {
+			zap.S().Warnw("Setting changed, restart to apply", "setting", change.Key, "old", change.Old, "new", change.New)
+			continue
+		}
+
+		var err error
+		switch change.Key {
+		case "log_level":
+			err = logLevel.UnmarshalText([]byte(loaded.LogLevel))
+			cfg.LogLevel = loaded.LogLevel
+		case "cors_allowed_origins":
+			middleware.SetAllowedOrigins(loaded.CORSAllowedOrigins)
+			cfg.CORSAllowedOrigins = loaded.CORSAllowedOrigins
+		}
+		if err != nil {
+			zap.S().Errorw("Setting not reloaded", "setting", change.Key, "error", err)
+			continue
+		}
+
+		zap.S().Infow("Setting reloaded", "setting", change.Key, "old", change.Old, "new", change.New)
+	}
+}
+
+// printConfiguration prints the effective configuration with the secrets redacted,
+// the configuration is printed even if it is invalid to tell what is wrong.
+func printConfiguration(args []string) {
+	loaded, err := config.Load(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = config.Print(os.Stdout, loaded)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = loaded.Validate()
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 // initialize the database connection and the HTTP router.
@@ -81,6 +159,9 @@ func initializeAPI(server *server.Server, driver, username, password, port, host
 		return err
 	}
 
+	metrics.InstrumentDB(server.DB)
+	tracing.InstrumentDB(server.DB)
+
 	server.Router = mux.NewRouter()
 	routes.InitializeRoutes(server)
 
@@ -92,12 +173,28 @@ func main() {
 	// This ensures the logged data is flushed out of the buffer before program exits.
 	defer zap.S().Sync()
 
-	err := initLogger()
+	// Usage: main [config print] [--config <file>] [--<setting> <value> ...]
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "print" {
+		printConfiguration(os.Args[3:])
+		return
+	}
+
+	err := loadConfiguration(os.Args[1:])
 	if err != nil {
-		zap.S().Fatal(err)
+		log.Fatal(err)
 	}
 
-	err = loadConfiguration()
+	err = initLogger(cfg.LogEncoding, cfg.LogLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	flushTraces, err := tracing.Init(context.Background(), tracing.Settings{
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		OTLPInsecure: cfg.TracingOTLPInsecure,
+		SampleRatio:  cfg.TracingSampleRatio,
+	})
 	if err != nil {
 		zap.S().Fatal(err)
 	}
@@ -109,11 +206,54 @@ func main() {
 		Finish:  make(chan dashboard_controller.FinishedResultMessage),
 		Join:    make(chan *dashboard_controller.Connection),
 		Leave:   make(chan *dashboard_controller.Connection),
+		Refresh: make(chan chan error),
+		Laps:    make(chan dashboard_controller.LapMessage),
+		Teams:   make(chan dashboard_controller.TeamMessage),
+
+		Announcements: make(chan dashboard_controller.AnnouncementMessage),
+		ReconnectIn:   cfg.DashboardReconnectIn,
+		SnapshotPolicies: dashboard_controller.SnapshotPolicies{
+			Default: dashboard_controller.SnapshotPolicy{
+				Mode: cfg.DashboardSnapshotPolicy,
+				Size: cfg.DashboardSnapshotSize,
+			},
+			Events: make(map[string]dashboard_controller.SnapshotPolicy),
+		},
+	}
+
+	for _, event := range cfg.DashboardSnapshotEvents {
+		dashboard.SnapshotPolicies.Events[event.Event] = dashboard_controller.SnapshotPolicy{
+			Mode: event.Policy,
+			Size: event.Size,
+		}
+	}
+
+	err = dashboard.SnapshotPolicies.Validate()
+	if err != nil {
+		zap.S().Fatalf("Invalid dashboard snapshot policy: %v", err)
 	}
 
 	srv := server.Server{}
 	srv.Addr = cfg.APIAddress
 	srv.Dashboard = dashboard
+	srv.Auth = auth.Settings{
+		TokenSecret: []byte(cfg.AuthTokenSecret),
+		TokenTTL:    cfg.AuthTokenTTL,
+
+		RequireDeviceCerts: cfg.TLSRequireDeviceCerts,
+	}
+	srv.IdempotencyKeyTTL = cfg.IdempotencyKeyTTL
+	srv.Health = health.NewChecker()
+
+	srv.Dispatcher = dispatcher.NewDispatcher()
+	srv.Dispatcher.Interval = cfg.OutboxInterval
+	srv.Dispatcher.BatchSize = cfg.OutboxBatchSize
+	srv.Dispatcher.MaxAttempts = uint32(cfg.OutboxMaxAttempts)
+	srv.Dispatcher.Retention = cfg.OutboxRetention
+	srv.Dashboard.Subscribe(srv.Dispatcher)
+	webhooks.Subscribe(srv.Dispatcher)
+
+	middleware.SetAllowedOrigins(cfg.CORSAllowedOrigins)
 
 	err = initializeAPI(
 		&srv,
@@ -128,13 +268,33 @@ func main() {
 		zap.S().Fatal(err)
 	}
 
-	// Disable cert verification to use self-signed certificates for internal service needs.
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	srv.Health.Add("database", health.Database(srv.DB))
+	srv.Health.Add("migrations", health.Migrations(srv.DB, utils.Models()...))
+	srv.Health.Add("dashboard", srv.Dashboard.Check)
 
-	run(&srv)
+	err = auth.EnsureBootstrapCredential(*srv.DB, cfg.AuthBootstrapAPIKey)
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+
+	tlsConfig, err := certs.ServerConfig(certs.Settings{
+		Mode:         cfg.TLSMode,
+		CertFile:     cfg.TLSCertFile,
+		KeyFile:      cfg.TLSKeyFile,
+		Dir:          cfg.TLSDir,
+		Hosts:        cfg.TLSHosts,
+		MinVersion:   cfg.TLSMinVersion,
+		ClientCAFile: cfg.TLSClientCAFile,
+	})
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+
+	run(&srv, tlsConfig, flushTraces)
 }
 
-func run(srv *server.Server) {
+// run serves the API over TLS, or plain HTTP and gRPC behind the proxy when the tlsConfig is nil.
+func run(srv *server.Server, tlsConfig *tls.Config, flushTraces func(context.Context) error) {
 	defer srv.DB.Close()
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -145,56 +305,175 @@ func run(srv *server.Server) {
 		Addr:        srv.Addr,
 		Handler:     srv.Router,
 		BaseContext: func(_ net.Listener) context.Context { return ctx },
+		TLSConfig:   tlsConfig,
 	}
 
 	httpSrv.RegisterOnShutdown(cancel)
 
+	// The dashboard hub is stopped before the servers, so that the clients get the restart notice.
+	hubCtx, stopHub := context.WithCancel(context.Background())
+	defer stopHub()
+
 	// Provide channel for errors from the API goroutines.
 	errors := make(chan error, 0)
 
 	// Start the Websocket module.
 	go func() {
-		err := srv.Dashboard.Run(srv.DB)
+		err := srv.Dashboard.Run(hubCtx, srv.DB)
 		if err != nil {
 			errors <- err
 			return
 		}
 	}()
 
+	// Publish the domain events of the outbox, including the ones left behind by the previous run.
+	go srv.Dispatcher.Run(ctx, srv.DB)
+
+	// Post the events queued for the webhooks.
+	sender := webhooks.NewSender()
+	sender.Client.Timeout = cfg.WebhookTimeout
+	sender.MaxAttempts = uint32(cfg.WebhookMaxAttempts)
+	sender.Backoff = cfg.WebhookBackoff
+	sender.MaxBackoff = cfg.WebhookMaxBackoff
+	go sender.Run(ctx, srv.DB)
+
+	// Remove the expired idempotency keys.
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := idempotency.DeleteExpired(*srv.DB, utils.MakeTimestampInMilliseconds())
+				if err != nil {
+					zap.S().Error(err)
+					continue
+				}
+				zap.S().Infof("Deleted %d expired idempotency keys", deleted)
+			}
+		}
+	}()
+
 	// Start the API.
 	go func() {
-		if err := httpSrv.ListenAndServeTLS(
-			"./srv/rsa.crt",
-			"./srv/rsa.key"); err != nil {
+		var err error
+		if tlsConfig != nil {
+			// The certificate is taken from the TLSConfig.
+			err = httpSrv.ListenAndServeTLS("", "")
+		} else {
+			err = httpSrv.ListenAndServe()
+		}
+		if err != nil {
 			errors <- err
 			return
 		}
 	}()
 
-	// Provide channel for OS process termination signals.
+	// Start the gRPC API on its own port.
+	var grpcOptions []grpc.ServerOption
+	if tlsConfig != nil {
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcSrv := rpc.NewServer(srv, grpcOptions...)
+
+	go func() {
+		listener, err := net.Listen("tcp", cfg.GRPCAddress)
+		if err != nil {
+			errors <- err
+			return
+		}
+
+		zap.S().Infof("gRPC server listening on %s", cfg.GRPCAddress)
+
+		if err := grpcSrv.Serve(listener); err != nil {
+			errors <- err
+			return
+		}
+	}()
+
+	// Provide channels for OS process termination and reload signals.
 	signalChan := make(chan os.Signal, 1)
+	reloadChan := make(chan os.Signal, 1)
 
 	// Listen to the OS termination signals.
 	signal.Notify(
 		signalChan,
-		syscall.SIGHUP,  // kill -SIGHUP XXXX
 		syscall.SIGINT,  // kill -SIGINT XXXX or Ctrl+c
+		syscall.SIGTERM, // kill XXXX, e.g. docker stop
 		syscall.SIGQUIT, // kill -SIGQUIT XXXX
 	)
 
+	// Reload the configuration without dropping the connections.
+	signal.Notify(reloadChan, syscall.SIGHUP) // kill -SIGHUP XXXX
+
 	// Block till err/termination chan comes in.
-	select {
-	case err := <-errors:
-		zap.S().Fatal(err)
-	case <-signalChan:
-		log.Print("os.Interrupt - shutting down...\n")
+wait:
+	for {
+		select {
+		case err := <-errors:
+			zap.S().Fatal(err)
+		case <-reloadChan:
+			zap.S().Info("SIGHUP - reloading configuration...")
+			reloadConfiguration(os.Args[1:])
+		case sig := <-signalChan:
+			log.Printf("%s - shutting down...\n", sig)
+			break wait
+		}
+	}
+
+	// Fail the readiness probe first, so that no new clients are routed to the server.
+	srv.Health.ShutDown()
+
+	// Keep serving till the load balancers notice the failing probe, the repeated signal stops the server right away.
+	if cfg.ShutdownGracePeriod > 0 {
+		log.Printf("serving for the grace period of %s\n", cfg.ShutdownGracePeriod)
+		select {
+		case <-time.After(cfg.ShutdownGracePeriod):
+		case sig := <-signalChan:
+			log.Printf("%s - skipping the grace period\n", sig)
+		}
 	}
 
 	// Gracefully shutdown the server when error/exit happens.
 	gracefullCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancelShutdown()
 
-	if err := httpSrv.Shutdown(gracefullCtx); err != nil {
+	// Stop the dashboard hub, the hijacked WebSocket connections are not closed by the HTTP server.
+	stopHub()
+	select {
+	case <-srv.Dashboard.Done():
+	case <-gracefullCtx.Done():
+		log.Printf("dashboard shutdown error: %v\n", gracefullCtx.Err())
+	}
+
+	// Stop the gRPC API alongside, the watch streams still open after the timeout are closed.
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	err := httpSrv.Shutdown(gracefullCtx)
+
+	select {
+	case <-grpcStopped:
+	case <-gracefullCtx.Done():
+		grpcSrv.Stop()
+	}
+
+	// Export the spans left, the traces of the stopped requests are kept.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+
+	if flushErr := flushTraces(flushCtx); flushErr != nil {
+		log.Printf("trace export error: %v\n", flushErr)
+	}
+
+	if err != nil {
 		log.Printf("shutdown error: %v\n", err)
 		defer os.Exit(1)
 		return
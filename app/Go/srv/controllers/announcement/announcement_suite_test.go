@@ -0,0 +1,13 @@
+package announcement_controller_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestAnnouncement(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Announcement Suite")
+}
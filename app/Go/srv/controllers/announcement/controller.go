@@ -0,0 +1,149 @@
+package announcement_controller
+
+import (
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"sports/backend/srv/utils"
+)
+
+// AddAnnouncement handles the new announcement request and broadcasts it to the dashboards.
+func AddAnnouncement(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := NewAnnouncementRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newAnnouncement := announcement.PendingAnnouncement{
+			ID:        uuid.Must(uuid.NewV4()),
+			Message:   req.Message,
+			Severity:  req.Severity,
+			Event:     req.Event,
+			ExpiresAt: req.ExpiresAt,
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "announcement.Create")
+		announcementCreatedEvent, err := announcement.Create(db, newAnnouncement)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
+
+		etag.SetVersion(w, announcementCreatedEvent.Version)
+		responses.JSON(w, http.StatusOK, CreatedResponse{ID: announcementCreatedEvent.AnnouncementID})
+	}
+}
+
+// RetractAnnouncement handles the announcement retraction request and removes it from the dashboards.
+func RetractAnnouncement(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		announcementID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "announcement.GetAnnouncement")
+		announcementFetched, err := announcement.GetAnnouncement(db, announcementID, version)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "announcement.Retract")
+		announcementRetractedEvent, err := announcement.Retract(db, utils.MakeTimestampInMilliseconds(), *announcementFetched)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
+
+		etag.SetVersion(w, announcementRetractedEvent.Version)
+		responses.JSON(w, http.StatusOK, nil)
+	}
+}
+
+// GetAnnouncement handles the announcement request.
+func GetAnnouncement(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		announcementID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "announcement.GetAnnouncement")
+		announcementFetched, err := announcement.GetAnnouncement(db, announcementID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, announcementFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, announcementFetched)
+	}
+}
+
+// GetActiveAnnouncements handles the active announcements request.
+func GetActiveAnnouncements(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "announcement.GetActiveAnnouncements")
+		announcements, err := announcement.GetActiveAnnouncements(db, utils.MakeTimestampInMilliseconds())
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, announcements)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, announcements)
+	}
+}
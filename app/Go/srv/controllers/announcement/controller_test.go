@@ -0,0 +1,286 @@
+package announcement_controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/srv/cmd/config"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+	"strings"
+)
+
+var _ = Describe("Announcements controller", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	// Set up the dashboard Websocket API module
+	dashboard := &dashboard_controller.Dashboard{
+		ConnHub:       make(map[string]*dashboard_controller.Connection),
+		Results:       make(chan dashboard_controller.UnfinishedResultMessage),
+		Finish:        make(chan dashboard_controller.FinishedResultMessage),
+		Join:          make(chan *dashboard_controller.Connection),
+		Leave:         make(chan *dashboard_controller.Connection),
+		Announcements: make(chan dashboard_controller.AnnouncementMessage),
+	}
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+	srv.Dashboard = dashboard
+	srv.Dispatcher = dispatcher.NewDispatcher()
+	srv.Dashboard.Subscribe(srv.Dispatcher)
+
+	go srv.Dashboard.Run(context.Background(), srv.DB)
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Creating new announcement", func() {
+		When("New announcement request is sent", func() {
+			Specify("The response returned", func() {
+				past := utils.MakeTimestampInMilliseconds() - 1000
+
+				samples := []struct {
+					request      NewAnnouncementRequest
+					statusCode   int
+					errorMessage string
+				}{
+					{
+						request:      NewAnnouncementRequest{Message: "Start delayed 10 minutes", Severity: announcement.SeverityWarning},
+						statusCode:   http.StatusOK,
+						errorMessage: "",
+					},
+					{
+						request:      NewAnnouncementRequest{Severity: announcement.SeverityWarning},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "message: cannot be blank.",
+					},
+					{
+						request:      NewAnnouncementRequest{Message: "Course shortened", Severity: "fatal"},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "severity: must be a valid value.",
+					},
+					{
+						request:      NewAnnouncementRequest{Message: "Course shortened", Severity: announcement.SeverityInfo, ExpiresAt: &past},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "expires_at: must be in the future.",
+					},
+				}
+
+				for _, s := range samples {
+					requestBody, err := json.Marshal(s.request)
+					Expect(err).To(gomega.BeNil())
+
+					req, err := http.NewRequest("POST", "/announcements", bytes.NewBufferString(string(requestBody)))
+					Expect(err).To(gomega.BeNil())
+
+					rr := httptest.NewRecorder()
+					handler := AddAnnouncement(&srv)
+					handler.ServeHTTP(rr, req)
+
+					responseMap := make(map[string]interface{})
+
+					err = json.Unmarshal([]byte(rr.Body.String()), &responseMap)
+					Expect(err).To(gomega.BeNil())
+
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code == 200 {
+						Expect(responseMap["id"]).ToNot(Equal(""))
+					}
+
+					if rr.Code != 200 {
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+	})
+
+	Describe("Retracting announcement", func() {
+		When("Retract request is sent", func() {
+			var announcementID string
+
+			BeforeEach(func() {
+				requestBody, err := json.Marshal(NewAnnouncementRequest{
+					Message:  "Start delayed 10 minutes",
+					Severity: announcement.SeverityWarning,
+				})
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/announcements", bytes.NewBuffer(requestBody))
+				Expect(err).To(BeNil())
+
+				rr := httptest.NewRecorder()
+				AddAnnouncement(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				created := CreatedResponse{}
+				err = json.Unmarshal(rr.Body.Bytes(), &created)
+				Expect(err).To(BeNil())
+				announcementID = created.ID
+			})
+
+			Specify("The response returned", func() {
+				samples := []struct {
+					id           string
+					ifMatch      string
+					statusCode   int
+					errorMessage string
+				}{
+					{
+						id:           announcementID,
+						statusCode:   http.StatusPreconditionRequired,
+						errorMessage: "If-Match: the entity tag of the updated version is required",
+					},
+					{
+						id:           announcementID,
+						ifMatch:      `"2"`,
+						statusCode:   http.StatusPreconditionFailed,
+						errorMessage: "Invalid version tag: Invalid version",
+					},
+					{
+						id:           announcementID,
+						ifMatch:      `"1"`,
+						statusCode:   http.StatusOK,
+						errorMessage: "",
+					},
+					{
+						id:           announcementID,
+						ifMatch:      "*",
+						statusCode:   http.StatusConflict,
+						errorMessage: "Announcement has been retracted already",
+					},
+					{
+						id:           uuid.Must(uuid.NewV4()).String(),
+						ifMatch:      `"1"`,
+						statusCode:   http.StatusNotFound,
+						errorMessage: "Announcement not found: Announcement does not exist",
+					},
+				}
+
+				for _, s := range samples {
+					req, err := http.NewRequest("DELETE", "/announcements/"+s.id, nil)
+					Expect(err).To(gomega.BeNil())
+					req = mux.SetURLVars(req, map[string]string{"id": s.id})
+					if s.ifMatch != "" {
+						req.Header.Set("If-Match", s.ifMatch)
+					}
+
+					rr := httptest.NewRecorder()
+					handler := RetractAnnouncement(&srv)
+					handler.ServeHTTP(rr, req)
+
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code != 200 {
+						responseMap := make(map[string]interface{})
+
+						err = json.Unmarshal([]byte(rr.Body.String()), &responseMap)
+						Expect(err).To(gomega.BeNil())
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+	})
+
+	Describe("Active announcements received on connecting to dashboard", func() {
+		When("There is an active announcement", func() {
+			var announcementID string
+
+			BeforeEach(func() {
+				requestBody, err := json.Marshal(NewAnnouncementRequest{
+					Message:  "Course shortened",
+					Severity: announcement.SeverityCritical,
+				})
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/announcements", bytes.NewBuffer(requestBody))
+				Expect(err).To(BeNil())
+
+				rr := httptest.NewRecorder()
+				AddAnnouncement(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				created := CreatedResponse{}
+				err = json.Unmarshal(rr.Body.Bytes(), &created)
+				Expect(err).To(BeNil())
+				announcementID = created.ID
+			})
+
+			Specify("The announcement is sent after the results", func() {
+				s := httptest.NewServer(http.HandlerFunc(srv.Dashboard.ResultsHandler))
+				// Convert http://127.0.0.1 to ws://127.0.0.
+				u := "ws" + strings.TrimPrefix(s.URL, "http")
+
+				ws, _, err := websocket.DefaultDialer.Dial(u, nil)
+				Expect(err).To(BeNil())
+
+				// Skip the results.
+				_, _, err = ws.ReadMessage()
+				Expect(err).To(BeNil())
+
+				// The hub outlives the test transactions, skip the announcements of the other tests.
+				received := dashboard_controller.AnnouncementMessage{}
+				for received.ID != announcementID {
+					_, msg, err := ws.ReadMessage()
+					Expect(err).To(BeNil())
+
+					err = json.Unmarshal(msg, &received)
+					Expect(err).To(BeNil())
+				}
+
+				Expect(received).To(Equal(dashboard_controller.AnnouncementMessage{
+					Type:     dashboard_controller.EventAnnouncement,
+					ID:       announcementID,
+					Message:  "Course shortened",
+					Severity: announcement.SeverityCritical,
+				}))
+
+				s.Close()
+				ws.Close()
+			})
+		})
+	})
+})
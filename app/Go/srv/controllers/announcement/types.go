@@ -0,0 +1,41 @@
+package announcement_controller
+
+import (
+	"errors"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/srv/utils"
+)
+
+type NewAnnouncementRequest struct {
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+	Event     string `json:"event"`
+	ExpiresAt *int64 `json:"expires_at"`
+}
+
+func (req NewAnnouncementRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Message, validation.Required),
+		validation.Field(&req.Severity, validation.Required, validation.In(
+			announcement.SeverityInfo,
+			announcement.SeverityWarning,
+			announcement.SeverityCritical,
+		)),
+		validation.Field(&req.ExpiresAt, validation.By(inFuture)),
+	)
+}
+
+// inFuture checks the optional expiration time is in the future.
+func inFuture(value interface{}) error {
+	expiresAt, _ := value.(*int64)
+	if expiresAt != nil && *expiresAt <= utils.MakeTimestampInMilliseconds() {
+		return errors.New("must be in the future")
+	}
+
+	return nil
+}
+
+type CreatedResponse struct {
+	ID string `json:"id"`
+}
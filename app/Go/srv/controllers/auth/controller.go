@@ -0,0 +1,31 @@
+package auth_controller
+
+import (
+	"net/http"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+)
+
+// IssueToken exchanges the API key the request is authenticated with for a short-lived bearer token.
+func IssueToken(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := auth.FromContext(r.Context())
+		if !ok || identity.Method != auth.MethodAPIKey {
+			responses.ERROR(w, auth.Unauthenticated{Reason: "API key required"})
+			return
+		}
+
+		token, expiresAt, err := auth.IssueToken(server.Auth.TokenSecret, identity, server.Auth.TokenTTL)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, TokenResponse{
+			Token:     token,
+			TokenType: "Bearer",
+			ExpiresAt: expiresAt.UnixNano() / int64(1e6),
+		})
+	}
+}
@@ -2,13 +2,15 @@ package checkpoint_controller
 
 import (
 	"encoding/json"
-	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
 	"io/ioutil"
 	"net/http"
 	"sports/backend/domain/models/checkpoint"
+	"sports/backend/srv/etag"
 	"sports/backend/srv/responses"
 	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
 )
 
 // AddCheckpoint handles the new checkpoint request.
@@ -16,36 +18,64 @@ func AddCheckpoint(server *server.Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
 			return
 		}
 
 		req := NewCheckpointRequest{}
 		err = json.Unmarshal(body, &req)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
-		err = validation.ValidateStruct(&req,
-			validation.Field(&req.Name, validation.Required),
-		)
+		err = req.Validate()
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
 		newCheckpoint := checkpoint.PendingCheckpoint{
-			ID:   uuid.Must(uuid.NewV4()),
-			Name: req.Name,
+			ID:    uuid.Must(uuid.NewV4()),
+			Name:  req.Name,
+			Event: req.Event,
 		}
 
-		checkpointCreatedEvent, err := checkpoint.Create(*server.DB, newCheckpoint)
+		db, end := tracing.Command(r.Context(), server.DB, "checkpoint.Create")
+		checkpointCreatedEvent, err := checkpoint.Create(db, newCheckpoint)
+		end(err)
 		if err != nil {
-			responses.ERROR(w, http.StatusInternalServerError, err)
+			responses.ERROR(w, err)
 			return
 		}
 
+		etag.SetVersion(w, checkpointCreatedEvent.Version)
 		responses.JSON(w, http.StatusOK, CreatedResponse{ID: checkpointCreatedEvent.CheckpointID})
 	}
 }
+
+// GetCheckpoint handles the checkpoint request.
+func GetCheckpoint(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checkpointID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "checkpoint.GetCheckpoint")
+		checkpointFetched, err := checkpoint.GetCheckpoint(db, checkpointID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, checkpointFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, checkpointFetched)
+	}
+}
@@ -103,7 +103,7 @@ var _ = Describe("Checkpoints controller", func() {
 					}
 
 					if rr.Code != 200 {
-						Expect(responseMap["error"]).To(Equal(s.errorMessage))
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
 					}
 				}
 			})
@@ -1,7 +1,18 @@
 package checkpoint_controller
 
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
 type NewCheckpointRequest struct {
-	Name string `json:"name"`
+	Name  string `json:"name"`
+	Event string `json:"event"`
+}
+
+func (req NewCheckpointRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required),
+	)
 }
 
 type CreatedResponse struct {
@@ -4,12 +4,21 @@ import (
 	"encoding/json"
 	"github.com/gorilla/websocket"
 	"go.uber.org/zap"
+	"sports/backend/srv/metrics"
+	"time"
 )
 
+// Connection is a dashboard client connected either over WebSocket (Conn) or Server-Sent Events (Stream),
+// WebSocket clients which negotiated the protobuf subprotocol receive binary frames, the protobuf
+// Stream clients are the watchers receiving the frames from Watch.
 type Connection struct {
-	Name   string
-	Conn   *websocket.Conn
-	Global *Dashboard
+	Name         string
+	Conn         *websocket.Conn
+	Protobuf     bool
+	Stream       *EventStream
+	Subscription Subscription
+	LastEventID  *uint64
+	Global       *Dashboard
 }
 
 func (c *Connection) Read() {
@@ -22,49 +31,101 @@ func (c *Connection) Read() {
 	}
 
 	c.Conn.Close()
-	c.Global.Leave <- c
+	c.Global.leave(c)
 }
 
-func (c *Connection) WriteAllCurrentResults(message *[]ResultMessage) {
-	b, err := json.Marshal(message)
-	if err != nil {
-		zap.S().Fatal(err)
-	}
+func (c *Connection) WriteAllCurrentResults(id uint64, message *[]ResultMessage) {
+	c.write(id, EventResults, message)
+}
 
-	if err := c.Conn.WriteMessage(websocket.TextMessage, b); err != nil {
-		zap.S().Info("Error on write message:", err.Error())
-	}
+func (c *Connection) WriteResult(id uint64, message *ResultMessage) {
+	c.write(id, EventResults, message)
+}
+
+func (c *Connection) WriteUnfinishedResult(id uint64, message *UnfinishedResultMessage) {
+	c.write(id, EventResult, message)
+}
+
+func (c *Connection) WriteFinishedResult(id uint64, message *FinishedResultMessage) {
+	c.write(id, EventFinish, message)
+}
+
+func (c *Connection) WriteLap(id uint64, message *LapMessage) {
+	c.write(id, message.Type, message)
+}
+
+func (c *Connection) WriteTeam(id uint64, message *TeamMessage) {
+	c.write(id, message.Type, message)
+}
+
+func (c *Connection) WriteAnnouncement(id uint64, message *AnnouncementMessage) {
+	c.write(id, message.Type, message)
+}
+
+func (c *Connection) WriteStatus(id uint64, message *StatusMessage) {
+	c.write(id, message.Type, message)
 }
 
-func (c *Connection) WriteResult(message *ResultMessage) {
-	b, err := json.Marshal(message)
+// write encodes the message and sends it over the client transport.
+func (c *Connection) write(id uint64, event string, message interface{}) {
+	var b []byte
+	var err error
+
+	frameType := websocket.TextMessage
+	if c.Protobuf {
+		b, err = encodeProtobuf(id, message)
+		frameType = websocket.BinaryMessage
+	} else {
+		b, err = json.Marshal(message)
+	}
 	if err != nil {
 		zap.S().Fatal(err)
 	}
 
-	if err := c.Conn.WriteMessage(websocket.TextMessage, b); err != nil {
+	if c.Stream != nil {
+		if !c.Stream.send(sseEvent{ID: id, Event: event, Data: b}) {
+			zap.S().Infof("Event stream of %s is full, message %d dropped", c.Name, id)
+			metrics.DashboardDroppedMessages.WithLabelValues(c.transport()).Inc()
+		}
+		return
+	}
+
+	if err := c.Conn.WriteMessage(frameType, b); err != nil {
 		zap.S().Info("Error on write message:", err.Error())
+		metrics.DashboardDroppedMessages.WithLabelValues(c.transport()).Inc()
 	}
 }
 
-func (c *Connection) WriteUnfinishedResult(message *UnfinishedResultMessage) {
-	b, err := json.Marshal(message)
-	if err != nil {
-		zap.S().Fatal(err)
+// transport names the client transport for the metrics.
+func (c *Connection) transport() string {
+	switch {
+	case c.Stream == nil:
+		return metrics.TransportWebSocket
+	case c.Protobuf:
+		return metrics.TransportGRPC
+	default:
+		return metrics.TransportSSE
 	}
+}
 
-	if err := c.Conn.WriteMessage(websocket.TextMessage, b); err != nil {
-		zap.S().Info("Error on write message:", err.Error())
+// closeGoingAway tells the WebSocket client the server is going away, the client transport is released by close.
+func (c *Connection) closeGoingAway() {
+	if c.Stream != nil {
+		return
 	}
-}
 
-func (c *Connection) WriteFinishedResult(message *FinishedResultMessage) {
-	b, err := json.Marshal(message)
-	if err != nil {
-		zap.S().Fatal(err)
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server restarting")
+	if err := c.Conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second)); err != nil {
+		zap.S().Info("Error on write close message:", err.Error())
 	}
+}
 
-	if err := c.Conn.WriteMessage(websocket.TextMessage, b); err != nil {
-		zap.S().Info("Error on write message:", err.Error())
+// close releases the client transport.
+func (c *Connection) close() {
+	if c.Stream != nil {
+		c.Stream.close()
+		return
 	}
+
+	c.Conn.Close()
 }
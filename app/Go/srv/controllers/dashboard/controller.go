@@ -1,16 +1,31 @@
 package dashboard_controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/jinzhu/gorm"
 	"go.uber.org/zap"
 	"net/http"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/lap"
 	"sports/backend/domain/models/result"
 	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/utils"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// Number of the latest broadcasts kept to resume the Server-Sent Events clients.
+const historySize = 256
+
 type Dashboard struct {
 	LastResults *[]ResultMessage
 	ConnHub     map[string]*Connection
@@ -18,18 +33,88 @@ type Dashboard struct {
 	Finish      chan FinishedResultMessage
 	Join        chan *Connection
 	Leave       chan *Connection
+	Refresh     chan chan error
+
+	// Laps carries the passings of the circuit race checkpoints.
+	Laps chan LapMessage
+
+	// Teams carries the standings of the relay teams after the handovers.
+	Teams chan TeamMessage
+
+	// Announcements carries the race control announcements and their retractions.
+	Announcements chan AnnouncementMessage
+
+	// SnapshotPolicies configure the results sent to the recently joined clients of every event.
+	SnapshotPolicies SnapshotPolicies
+
+	// ReconnectIn is the delay the clients are asked to reconnect after when the server restarts.
+	ReconnectIn time.Duration
+
+	db            *gorm.DB
+	snapshots     map[string]*Snapshot
+	teams         *TeamRankings
+	announcements []AnnouncementMessage
+
+	// eventID is the id of the latest broadcast, history keeps the latest broadcasts in order.
+	eventID uint64
+	history []broadcast
+
+	// running is set while the hub loop is serving the channels.
+	running int32
+
+	// stopped is closed once the hub has stopped and the client connections are closed.
+	stopped     chan struct{}
+	stoppedOnce sync.Once
+}
+
+// ErrStopped is returned to the clients joining the dashboard after the hub has stopped.
+var ErrStopped = errors.New("the dashboard is shutting down")
+
+// broadcast is a message sent to the dashboard clients, TargetEvent is the event of the result or the announcement.
+type broadcast struct {
+	ID          uint64
+	Event       string
+	StartNumber uint32
+	TargetEvent string
+	Message     interface{}
+
+	// StartNumbers are the members of the team the message is about.
+	StartNumbers []uint32
+}
+
+// matches reports whether the message should be delivered to the subscribed client.
+func (b broadcast) matches(subscription Subscription) bool {
+	switch b.Event {
+	case EventAnnouncement, EventAnnouncementRetracted:
+		return subscription.MatchesEvent(b.TargetEvent)
+	case EventTeam:
+		return subscription.MatchesAny(b.StartNumbers)
+	default:
+		return subscription.MatchesResultEvent(b.TargetEvent) && subscription.Matches(b.StartNumber)
+	}
 }
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  512,
 	WriteBufferSize: 512,
+	Subprotocols:    []string{ProtobufSubprotocol, JSONSubprotocol},
 	CheckOrigin: func(r *http.Request) bool {
-		zap.S().Infof("%s %s%s %v", r.Method, r.Host, r.RequestURI, r.Proto)
 		return r.Method == http.MethodGet
 	},
 }
 
 func (d *Dashboard) ResultsHandler(w http.ResponseWriter, r *http.Request) {
+	subscription, err := ParseSubscription(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if d.isStopped() {
+		http.Error(w, ErrStopped.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
 	upgradedConn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		zap.S().Info("Error on websocket connection:", err.Error())
@@ -42,120 +127,671 @@ func (d *Dashboard) ResultsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	conn := &Connection{
-		Name:   fmt.Sprintf("anon-%d", uuid),
-		Conn:   upgradedConn,
-		Global: d,
+		Name:         fmt.Sprintf("anon-%d", uuid),
+		Conn:         upgradedConn,
+		Protobuf:     upgradedConn.Subprotocol() == ProtobufSubprotocol,
+		Subscription: subscription,
+		Global:       d,
 	}
 
-	if *d.LastResults != nil {
-		conn.WriteAllCurrentResults(d.LastResults)
-	} else {
-		conn.WriteResult(nil)
+	// Current results are sent by the hub on join, so that no broadcast is missed in between.
+	if err := d.join(conn); err != nil {
+		conn.closeGoingAway()
+		conn.close()
+		return
 	}
 
-	d.Join <- conn
-
 	conn.Read()
 }
 
-func (d *Dashboard) Run(db *gorm.DB) error {
-	lastResults, err := result.GetLastTenResults(*db)
+// RefreshHandler reloads the dashboard snapshot from the database and sends it to the connected clients.
+func (d *Dashboard) RefreshHandler(w http.ResponseWriter, r *http.Request) {
+	reply := make(chan error, 1)
+
+	select {
+	case d.Refresh <- reply:
+	case <-d.stoppedChan():
+		http.Error(w, ErrStopped.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := <-reply; err != nil {
+		responses.ERROR(w, err)
+		return
+	}
+
+	responses.JSON(w, http.StatusOK, nil)
+}
+
+// Run serves the hub channels until the context is done, then it broadcasts the queued messages,
+// tells the clients the server is restarting and closes their connections, Done is closed after that.
+func (d *Dashboard) Run(ctx context.Context, db *gorm.DB) error {
+	defer close(d.stoppedChan())
+
+	d.db = db
+
+	err := d.loadSnapshot()
+	if err != nil {
+		return err
+	}
+
+	err = d.loadAnnouncements()
 	if err != nil {
 		return err
 	}
 
+	// Start event ids from the current time so that ids stay unique across restarts
+	// and clients resuming with an id from the previous run get the full state instead.
+	d.eventID = uint64(time.Now().UnixNano())
+
+	atomic.StoreInt32(&d.running, 1)
+	defer atomic.StoreInt32(&d.running, 0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.shutDown()
+			return nil
+		case conn := <-d.Join:
+			d.add(conn)
+		case result := <-d.Results:
+			d.broadcastResult(&result)
+		case finish := <-d.Finish:
+			d.broadcastFinish(&finish)
+		case lap := <-d.Laps:
+			d.broadcastLap(&lap)
+		case team := <-d.Teams:
+			d.broadcastTeam(&team)
+		case announcement := <-d.Announcements:
+			d.broadcastAnnouncement(&announcement)
+		case reply := <-d.Refresh:
+			reply <- d.refresh()
+		case conn := <-d.Leave:
+			d.disconnect(conn)
+		}
+	}
+}
+
+// Done returns the channel closed once the hub has stopped and the client connections are closed.
+func (d *Dashboard) Done() <-chan struct{} {
+	return d.stoppedChan()
+}
+
+// PublishResult broadcasts the started result, the message is dropped once the hub has stopped.
+func (d *Dashboard) PublishResult(message UnfinishedResultMessage) {
+	select {
+	case d.Results <- message:
+	case <-d.stoppedChan():
+		zap.S().Infof("Dashboard stopped, result %s not broadcast", message.ID)
+	}
+}
+
+// PublishFinish broadcasts the finish time, the message is dropped once the hub has stopped.
+func (d *Dashboard) PublishFinish(message FinishedResultMessage) {
+	select {
+	case d.Finish <- message:
+	case <-d.stoppedChan():
+		zap.S().Infof("Dashboard stopped, finish of %s not broadcast", message.ID)
+	}
+}
+
+// PublishLap broadcasts the lap, the message is dropped once the hub has stopped.
+func (d *Dashboard) PublishLap(message LapMessage) {
+	select {
+	case d.Laps <- message:
+	case <-d.stoppedChan():
+		zap.S().Infof("Dashboard stopped, lap %d of %s not broadcast", message.Number, message.ID)
+	}
+}
+
+// PublishTeam broadcasts the team standing, the message is dropped once the hub has stopped.
+func (d *Dashboard) PublishTeam(message TeamMessage) {
+	select {
+	case d.Teams <- message:
+	case <-d.stoppedChan():
+		zap.S().Infof("Dashboard stopped, team %s not broadcast", message.ID)
+	}
+}
+
+// PublishAnnouncement broadcasts the announcement or its retraction, the message is dropped once the hub has stopped.
+func (d *Dashboard) PublishAnnouncement(message AnnouncementMessage) {
+	select {
+	case d.Announcements <- message:
+	case <-d.stoppedChan():
+		zap.S().Infof("Dashboard stopped, announcement %s not broadcast", message.ID)
+	}
+}
+
+// Check reports whether the hub loop is running, it is the readiness check of the dashboard.
+func (d *Dashboard) Check(ctx context.Context) error {
+	if atomic.LoadInt32(&d.running) == 0 {
+		return errors.New("the hub loop is not running")
+	}
+
+	return nil
+}
+
+// loadSnapshot loads the snapshot of every event of the checkpoints and of the policies from the database,
+// the snapshot of the event keeps the results of its checkpoints only, the default snapshot keeps the results of every event.
+func (d *Dashboard) loadSnapshot() error {
+	checkpointEvents, err := checkpoint.GetEvents(*d.db)
+	if err != nil {
+		return err
+	}
+
+	events := append([]string{""}, checkpointEvents...)
+	for event := range d.SnapshotPolicies.Events {
+		events = append(events, event)
+	}
+
+	snapshots := make(map[string]*Snapshot, len(events))
+	for _, event := range events {
+		if _, ok := snapshots[event]; ok {
+			continue
+		}
+
+		policy := d.SnapshotPolicies.For(event)
+
+		resultsMessages, err := d.loadResults(policy, event)
+		if err != nil {
+			return err
+		}
+
+		snapshots[event] = NewSnapshot(policy, resultsMessages)
+	}
+
+	d.snapshots = snapshots
+	d.updateLastResults()
+
+	return d.loadTeams(d.SnapshotPolicies.For("").Size)
+}
+
+// loadResults loads the results of the event from the database according to the snapshot policy,
+// the results of every event are loaded for the blank event.
+func (d *Dashboard) loadResults(policy SnapshotPolicy, event string) ([]ResultMessage, error) {
+	var lastResults *[]result.Result
+	var err error
+
+	switch policy.Mode {
+	case SnapshotOnCourse:
+		lastResults, err = result.GetUnfinishedResults(*d.db, event, policy.Size)
+	case SnapshotLeaderboard:
+		lastResults, err = result.GetLeaderboard(*d.db, event, policy.Size)
+	case SnapshotCategory:
+		lastResults, err = result.GetLastResultsPerCategory(*d.db, event, policy.Size)
+	default:
+		lastResults, err = result.GetLastResults(*d.db, event, policy.Size)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert domain results into application level results.
-	// Load results from DB on app startup.
 	var resultsMessages []ResultMessage
+	checkpointEvents := make(map[uuid.UUID]string)
 
-	// Serve stored results in an reverse order so that the latest result will come the last
-	// the last result will be placed on top of table then.
 	for _, result := range *lastResults {
-		version := uint32(1)
-		sportsmenFetched, err := sportsmen.GetSportsmen(*db, result.SportsmenID, &version)
+		sportsmenFetched, err := sportsmen.GetSportsmen(*d.db, result.SportsmenID, nil)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		resultEvent, ok := checkpointEvents[result.CheckpointID]
+		if !ok {
+			checkpointFetched, err := checkpoint.GetCheckpoint(*d.db, result.CheckpointID, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			resultEvent = checkpointFetched.Event
+			checkpointEvents[result.CheckpointID] = resultEvent
 		}
 
 		msg := ResultMessage{
 			ID:                   result.ID.String(),
 			SportsmenStartNumber: sportsmenFetched.StartNumber,
 			SportsmenName:        fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
+			Category:             sportsmenFetched.Category,
 			TimeStart:            result.TimeStart,
 			TimeFinish:           nil,
+			Event:                resultEvent,
 		}
 
 		if result.TimeFinish != nil {
 			msg.TimeFinish = result.TimeFinish
 		}
 
+		standing, err := lap.GetStanding(*d.db, result.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if standing != nil {
+			msg.Laps = standing.Laps
+			msg.Distance = standing.Distance
+			msg.LastPassing = standing.LastPassing
+		}
+
 		resultsMessages = append(resultsMessages, msg)
 	}
 
-	d.LastResults = &resultsMessages
+	return resultsMessages, nil
+}
 
-	for {
-		select {
-		case conn := <-d.Join:
-			d.add(conn)
-		case result := <-d.Results:
-			d.broadcastResult(&result)
-		case finish := <-d.Finish:
-			d.broadcastFinish(&finish)
-		case conn := <-d.Leave:
-			d.disconnect(conn)
-		}
+// loadTeams loads the rankings of the relay teams with the start numbers of their members.
+func (d *Dashboard) loadTeams(size int) error {
+	standings, err := team.GetStandings(*d.db, size)
+	if err != nil {
+		return err
+	}
+
+	var teamIDs []uuid.UUID
+	for _, standing := range *standings {
+		teamIDs = append(teamIDs, standing.TeamID)
+	}
+
+	legResults, err := team.GetLegResults(*d.db, teamIDs)
+	if err != nil {
+		return err
 	}
+
+	var teamsMessages []TeamMessage
+	for _, standing := range *standings {
+		teamsMessages = append(teamsMessages, newTeamMessage(standing, *legResults))
+	}
+
+	d.teams = NewTeamRankings(size, teamsMessages)
+
+	return nil
+}
+
+// loadAnnouncements loads the active announcements for the recently joined clients.
+func (d *Dashboard) loadAnnouncements() error {
+	activeAnnouncements, err := announcement.GetActiveAnnouncements(*d.db, utils.MakeTimestampInMilliseconds())
+	if err != nil {
+		return err
+	}
+
+	d.announcements = nil
+	for _, active := range *activeAnnouncements {
+		d.announcements = append(d.announcements, AnnouncementMessage{
+			Type:      EventAnnouncement,
+			ID:        active.ID.String(),
+			Message:   active.Message,
+			Severity:  active.Severity,
+			Event:     active.Event,
+			ExpiresAt: active.ExpiresAt,
+		})
+	}
+
+	return nil
+}
+
+// refresh reloads the snapshot and sends it to every client, the history is dropped
+// so that the resuming clients receive the refreshed snapshot as well.
+func (d *Dashboard) refresh() error {
+	err := d.loadSnapshot()
+	if err != nil {
+		return err
+	}
+
+	err = d.loadAnnouncements()
+	if err != nil {
+		return err
+	}
+
+	d.eventID++
+	d.history = nil
+
+	zap.S().Infof("Dashboard snapshot refreshed, %d results", len(*d.LastResults))
+	for _, conn := range d.ConnHub {
+		d.writeSnapshot(conn)
+	}
+
+	return nil
+}
+
+// updateLastResults publishes the results of the default snapshot.
+func (d *Dashboard) updateLastResults() {
+	results := d.snapshots[""].Results()
+	d.LastResults = &results
+}
+
+// snapshotOf returns the snapshot of the event, the empty snapshot is returned for the event without results
+// and the default snapshot is returned for the blank event.
+func (d *Dashboard) snapshotOf(event string) *Snapshot {
+	if snapshot, ok := d.snapshots[event]; ok {
+		return snapshot
+	}
+
+	return NewSnapshot(d.SnapshotPolicies.For(event), nil)
+}
+
+// updateSnapshots applies the update of the result to the default snapshot and to the snapshot of its event,
+// the snapshot of the event is created on its first result, e.g. of the checkpoint created after the load.
+func (d *Dashboard) updateSnapshots(event string, update func(snapshot *Snapshot)) {
+	if _, ok := d.snapshots[event]; !ok {
+		d.snapshots[event] = NewSnapshot(d.SnapshotPolicies.For(event), nil)
+	}
+
+	update(d.snapshots[""])
+	if event != "" {
+		update(d.snapshots[event])
+	}
+
+	d.updateLastResults()
 }
 
 func (d *Dashboard) add(conn *Connection) {
 	if _, usr := d.ConnHub[conn.Name]; !usr {
 		d.ConnHub[conn.Name] = conn
+		metrics.DashboardClients.WithLabelValues(conn.transport()).Inc()
+		d.writeCurrentState(conn)
 		zap.S().Infof("%s joined the dashboard", conn.Name)
 	}
 }
 
+// writeCurrentState sends the missed broadcasts to the resuming client,
+// or the latest results when the client can't be resumed from the history.
+func (d *Dashboard) writeCurrentState(conn *Connection) {
+	if conn.LastEventID != nil && d.canResume(*conn.LastEventID) {
+		for _, b := range d.history {
+			if b.ID > *conn.LastEventID && b.matches(conn.Subscription) {
+				conn.write(b.ID, b.Event, b.Message)
+			}
+		}
+		return
+	}
+
+	d.writeSnapshot(conn)
+}
+
+// writeSnapshot sends the latest results of the subscribed event, the team rankings and the active announcements
+// matching the client subscription.
+func (d *Dashboard) writeSnapshot(conn *Connection) {
+	results := conn.Subscription.Filter(d.snapshotOf(conn.Subscription.Event).Results())
+	if results != nil {
+		conn.WriteAllCurrentResults(d.eventID, &results)
+	} else {
+		conn.WriteResult(d.eventID, nil)
+	}
+
+	for _, ranked := range d.teams.Teams() {
+		if conn.Subscription.MatchesAny(ranked.StartNumbers) {
+			conn.WriteTeam(d.eventID, &ranked)
+		}
+	}
+
+	d.expireAnnouncements()
+	for index := range d.announcements {
+		if conn.Subscription.MatchesEvent(d.announcements[index].Event) {
+			conn.WriteAnnouncement(d.eventID, &d.announcements[index])
+		}
+	}
+}
+
+// expireAnnouncements drops the announcements which are not active anymore.
+func (d *Dashboard) expireAnnouncements() {
+	now := utils.MakeTimestampInMilliseconds()
+
+	active := d.announcements[:0]
+	for _, announcement := range d.announcements {
+		if announcement.ExpiresAt == nil || *announcement.ExpiresAt > now {
+			active = append(active, announcement)
+		}
+	}
+	d.announcements = active
+}
+
+// canResume reports whether all the broadcasts after the given id are still in the history.
+func (d *Dashboard) canResume(lastEventID uint64) bool {
+	if lastEventID > d.eventID {
+		return false
+	} else if lastEventID == d.eventID {
+		return true
+	}
+
+	return len(d.history) > 0 && d.history[0].ID <= lastEventID+1
+}
+
 func (d *Dashboard) disconnect(conn *Connection) {
 	if _, usr := d.ConnHub[conn.Name]; usr {
-		defer conn.Conn.Close()
+		defer conn.close()
 		delete(d.ConnHub, conn.Name)
+		metrics.DashboardClients.WithLabelValues(conn.transport()).Dec()
+	}
+}
+
+// stoppedChan returns the channel closed once the hub has stopped, it is created on the first use
+// so that the dashboard may be declared as the struct literal.
+func (d *Dashboard) stoppedChan() chan struct{} {
+	d.stoppedOnce.Do(func() {
+		d.stopped = make(chan struct{})
+	})
+
+	return d.stopped
+}
+
+func (d *Dashboard) isStopped() bool {
+	select {
+	case <-d.stoppedChan():
+		return true
+	default:
+		return false
 	}
 }
 
+// join passes the client to the hub, the clients are refused once the hub has stopped.
+func (d *Dashboard) join(conn *Connection) error {
+	select {
+	case d.Join <- conn:
+		return nil
+	case <-d.stoppedChan():
+		return ErrStopped
+	}
+}
+
+// leave passes the leaving client to the hub, the clients are disconnected by the hub when it stops.
+func (d *Dashboard) leave(conn *Connection) {
+	select {
+	case d.Leave <- conn:
+	case <-d.stoppedChan():
+	}
+}
+
+// shutDown broadcasts the queued messages, sends the restart notice with the close frame
+// to every client and closes the connections.
+func (d *Dashboard) shutDown() {
+	d.drain()
+
+	seconds := int64(d.ReconnectIn / time.Second)
+	status := &StatusMessage{
+		Type:        EventStatus,
+		Code:        StatusRestarting,
+		Message:     fmt.Sprintf("server restarting, reconnect in %d s", seconds),
+		ReconnectIn: seconds,
+	}
+
+	closed := len(d.ConnHub)
+	for _, conn := range d.ConnHub {
+		conn.WriteStatus(d.eventID, status)
+		conn.closeGoingAway()
+		d.disconnect(conn)
+	}
+
+	zap.S().Infof("Dashboard stopped, %d connections closed", closed)
+}
+
+// drain serves the messages the handlers are already waiting to send, the joining clients are refused
+// once the hub has stopped.
+func (d *Dashboard) drain() {
+	for {
+		select {
+		case result := <-d.Results:
+			d.broadcastResult(&result)
+		case finish := <-d.Finish:
+			d.broadcastFinish(&finish)
+		case lap := <-d.Laps:
+			d.broadcastLap(&lap)
+		case team := <-d.Teams:
+			d.broadcastTeam(&team)
+		case announcement := <-d.Announcements:
+			d.broadcastAnnouncement(&announcement)
+		case reply := <-d.Refresh:
+			reply <- ErrStopped
+		case conn := <-d.Leave:
+			d.disconnect(conn)
+		default:
+			return
+		}
+	}
+}
+
+// record assigns the next event id to the message and keeps it in the history.
+func (d *Dashboard) record(b broadcast) broadcast {
+	d.eventID++
+	b.ID = d.eventID
+
+	d.history = append(d.history, b)
+	if len(d.history) > historySize {
+		d.history = d.history[len(d.history)-historySize:]
+	}
+
+	return b
+}
+
 func (d *Dashboard) broadcastResult(result *UnfinishedResultMessage) {
 	// Update stored results to return latest data to recently joined customers.
-	resultMessage := ResultMessage{
-		ID:                   result.ID,
-		SportsmenStartNumber: result.SportsmenStartNumber,
-		SportsmenName:        result.SportsmenName,
-		TimeStart:            result.TimeStart,
-	}
-	updatedResults := append(*d.LastResults, resultMessage)
-	d.LastResults = &updatedResults
+	d.updateSnapshots(result.Event, func(snapshot *Snapshot) {
+		snapshot.Add(ResultMessage{
+			ID:                   result.ID,
+			SportsmenStartNumber: result.SportsmenStartNumber,
+			SportsmenName:        result.SportsmenName,
+			Category:             result.Category,
+			TimeStart:            result.TimeStart,
+			Event:                result.Event,
+		})
+	})
+
+	b := d.record(broadcast{
+		Event:       EventResult,
+		StartNumber: result.SportsmenStartNumber,
+		TargetEvent: result.Event,
+		Message:     *result,
+	})
 
 	zap.S().Infof("Broadcast result: %d, %s, %d",
 		result.SportsmenStartNumber,
 		result.SportsmenName,
 		result.TimeStart)
-	for _, conn := range d.ConnHub {
-		conn.WriteUnfinishedResult(result)
-	}
+	d.fanOut(b, func(conn *Connection) {
+		conn.WriteUnfinishedResult(b.ID, result)
+	})
 }
 
 func (d *Dashboard) broadcastFinish(finish *FinishedResultMessage) {
 	// Update stored results to return latest data to recently joined customers.
-	for index, result := range *d.LastResults {
-		if result.ID == finish.ID {
-			time := finish.TimeFinish
-			(*d.LastResults)[index].TimeFinish = &time
-		}
-	}
+	d.updateSnapshots(finish.Event, func(snapshot *Snapshot) {
+		snapshot.Finish(*finish)
+	})
+
+	b := d.record(broadcast{
+		Event:       EventFinish,
+		StartNumber: finish.SportsmenStartNumber,
+		TargetEvent: finish.Event,
+		Message:     *finish,
+	})
 
 	zap.S().Infof("Broadcast result: %d, %s, %d",
 		finish.SportsmenStartNumber,
 		finish.SportsmenName,
 		finish.TimeFinish)
+	d.fanOut(b, func(conn *Connection) {
+		conn.WriteFinishedResult(b.ID, finish)
+	})
+}
+
+func (d *Dashboard) broadcastLap(lap *LapMessage) {
+	// Update stored results to return latest data to recently joined customers.
+	d.updateSnapshots(lap.Event, func(snapshot *Snapshot) {
+		snapshot.Lap(*lap)
+	})
+
+	b := d.record(broadcast{
+		Event:       EventLap,
+		StartNumber: lap.SportsmenStartNumber,
+		TargetEvent: lap.Event,
+		Message:     *lap,
+	})
+
+	zap.S().Infof("Broadcast lap: %d, %s, %d, %d",
+		lap.SportsmenStartNumber,
+		lap.SportsmenName,
+		lap.Number,
+		lap.LapTime)
+	d.fanOut(b, func(conn *Connection) {
+		conn.WriteLap(b.ID, lap)
+	})
+}
+
+func (d *Dashboard) broadcastTeam(team *TeamMessage) {
+	// Update team rankings to return latest data to recently joined customers.
+	d.teams.Update(*team)
+
+	b := d.record(broadcast{
+		Event:        EventTeam,
+		StartNumbers: team.StartNumbers,
+		Message:      *team,
+	})
+
+	zap.S().Infof("Broadcast team: %s, %s, %d, %d",
+		team.ID,
+		team.Name,
+		team.LegsFinished,
+		team.TotalTime)
+	d.fanOut(b, func(conn *Connection) {
+		conn.WriteTeam(b.ID, team)
+	})
+}
+
+func (d *Dashboard) broadcastAnnouncement(announcement *AnnouncementMessage) {
+	// Update active announcements to return them to recently joined customers.
+	for index, active := range d.announcements {
+		if active.ID == announcement.ID {
+			d.announcements = append(d.announcements[:index], d.announcements[index+1:]...)
+			break
+		}
+	}
+
+	if announcement.Type == EventAnnouncement {
+		d.announcements = append(d.announcements, *announcement)
+	}
+
+	b := d.record(broadcast{
+		Event:       announcement.Type,
+		TargetEvent: announcement.Event,
+		Message:     *announcement,
+	})
+
+	zap.S().Infof("Broadcast %s: %s, %s, %s",
+		announcement.Type,
+		announcement.ID,
+		announcement.Severity,
+		announcement.Message)
+	d.fanOut(b, func(conn *Connection) {
+		conn.WriteAnnouncement(b.ID, announcement)
+	})
+}
+
+// fanOut writes the broadcast to the subscribed clients and observes the time it takes.
+func (d *Dashboard) fanOut(b broadcast, write func(conn *Connection)) {
+	start := time.Now()
+
 	for _, conn := range d.ConnHub {
-		conn.WriteFinishedResult(finish)
+		if b.matches(conn.Subscription) {
+			write(conn)
+		}
 	}
+
+	metrics.DashboardBroadcastDuration.WithLabelValues(b.Event).Observe(time.Since(start).Seconds())
 }
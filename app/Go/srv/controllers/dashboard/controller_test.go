@@ -2,6 +2,7 @@ package dashboard_controller_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/gofrs/uuid"
@@ -20,6 +21,7 @@ import (
 	"sports/backend/srv/cmd/config"
 	dashboard_controller "sports/backend/srv/controllers/dashboard"
 	result_controller "sports/backend/srv/controllers/result"
+	"sports/backend/srv/dispatcher"
 	"sports/backend/srv/server"
 	"sports/backend/srv/utils"
 	"strings"
@@ -65,12 +67,14 @@ var _ = Describe("Results controller", func() {
 		srv.DB = conn
 		srv.Router = mux.NewRouter()
 		srv.Dashboard = dashboard
+		srv.Dispatcher = dispatcher.NewDispatcher()
+		srv.Dashboard.Subscribe(srv.Dispatcher)
 
 		db := conn.Begin()
 		srv.DB = db
 
 		// Run the server when the database has been set up.
-		go srv.Dashboard.Run(srv.DB)
+		go srv.Dashboard.Run(context.Background(), srv.DB)
 
 		for srv.Dashboard.LastResults == nil {
 			time.Sleep(1 * time.Second)
@@ -102,6 +106,73 @@ var _ = Describe("Results controller", func() {
 		})
 	})
 
+	Describe("Dashboard hub shut down", func() {
+		conn, err := utils.GetDBConnection(
+			cfg.DBDriver,
+			cfg.DBUsername,
+			cfg.DBPassword,
+			cfg.DBPort,
+			cfg.DBHost,
+			cfg.DBName,
+		)
+		Expect(err).To(BeNil())
+
+		dashboard := &dashboard_controller.Dashboard{
+			ConnHub:     make(map[string]*dashboard_controller.Connection),
+			Results:     make(chan dashboard_controller.UnfinishedResultMessage),
+			Finish:      make(chan dashboard_controller.FinishedResultMessage),
+			Join:        make(chan *dashboard_controller.Connection),
+			Leave:       make(chan *dashboard_controller.Connection),
+			ReconnectIn: 5 * time.Second,
+		}
+
+		db := conn.Begin()
+
+		AfterEach(func() {
+			_ = db.Rollback()
+		})
+
+		Specify("Clients told to reconnect and closed", func() {
+			ctx, stop := context.WithCancel(context.Background())
+			go dashboard.Run(ctx, db)
+
+			s := httptest.NewServer(http.HandlerFunc(dashboard.ResultsHandler))
+			defer s.Close()
+
+			u := "ws" + strings.TrimPrefix(s.URL, "http")
+			ws, _, err := websocket.DefaultDialer.Dial(u, nil)
+			Expect(err).To(BeNil())
+			defer ws.Close()
+
+			// Wait for the current results so that the client has joined.
+			_, _, err = ws.ReadMessage()
+			Expect(err).To(BeNil())
+
+			stop()
+			Eventually(dashboard.Done(), 5*time.Second).Should(BeClosed())
+
+			_, msg, err := ws.ReadMessage()
+			Expect(err).To(BeNil())
+
+			status := dashboard_controller.StatusMessage{}
+			Expect(json.Unmarshal(msg, &status)).To(Succeed())
+			Expect(status).To(Equal(dashboard_controller.StatusMessage{
+				Type:        dashboard_controller.EventStatus,
+				Code:        dashboard_controller.StatusRestarting,
+				Message:     "server restarting, reconnect in 5 s",
+				ReconnectIn: 5,
+			}))
+
+			_, _, err = ws.ReadMessage()
+			Expect(websocket.IsCloseError(err, websocket.CloseGoingAway)).To(BeTrue())
+
+			// New clients are refused once the hub has stopped.
+			_, resp, err := websocket.DefaultDialer.Dial(u, nil)
+			Expect(err).NotTo(BeNil())
+			Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		})
+	})
+
 	Describe("Results received on connecting to dashboard", func() {
 		conn, err := utils.GetDBConnection(
 			cfg.DBDriver,
@@ -127,6 +198,8 @@ var _ = Describe("Results controller", func() {
 		srv.DB = conn
 		srv.Router = mux.NewRouter()
 		srv.Dashboard = dashboard
+		srv.Dispatcher = dispatcher.NewDispatcher()
+		srv.Dashboard.Subscribe(srv.Dispatcher)
 
 		db := conn.Begin()
 		srv.DB = db
@@ -212,7 +285,7 @@ var _ = Describe("Results controller", func() {
 				Expect(err).To(BeNil())
 
 				// Start server after data has been created, that way server will load existing data on start.
-				go srv.Dashboard.Run(srv.DB)
+				go srv.Dashboard.Run(context.Background(), srv.DB)
 
 				for srv.Dashboard.LastResults == nil {
 					time.Sleep(1 * time.Second)
@@ -287,6 +360,8 @@ var _ = Describe("Results controller", func() {
 		srv.DB = conn
 		srv.Router = mux.NewRouter()
 		srv.Dashboard = dashboard
+		srv.Dispatcher = dispatcher.NewDispatcher()
+		srv.Dashboard.Subscribe(srv.Dispatcher)
 
 		db := conn.Begin()
 		srv.DB = db
@@ -331,7 +406,7 @@ var _ = Describe("Results controller", func() {
 				resultToFinish.TimeFinish = &timeFinish
 
 				// Start server after data has been created, that way server will load existing data on start.
-				go srv.Dashboard.Run(srv.DB)
+				go srv.Dashboard.Run(context.Background(), srv.DB)
 
 				for srv.Dashboard.LastResults == nil {
 					time.Sleep(1 * time.Second)
@@ -441,6 +516,7 @@ var _ = Describe("Results controller", func() {
 
 				req, err = http.NewRequest("POST", "/finish", bytes.NewBufferString(string(requestBody)))
 				Expect(err).To(BeNil())
+				req.Header.Set("If-Match", `"1"`)
 
 				rr = httptest.NewRecorder()
 				handler = result_controller.AddFinishTime(&srv)
@@ -474,19 +550,222 @@ var _ = Describe("Results controller", func() {
 				err = json.Unmarshal(msg, &resultsArrReceived)
 				Expect(err).To(BeNil())
 
-				// Make sure results and ORDER of results is correct too.
-				Expect(resultsArrReceived[0].SportsmenStartNumber).To(Equal(pendingSportsmen.StartNumber))
-				Expect(resultsArrReceived[0].SportsmenName).To(Equal(fmt.Sprintf("%s %s", pendingSportsmen.FirstName, pendingSportsmen.LastName)))
-				Expect(resultsArrReceived[0].TimeStart).To(Equal(newReq.Time))
+				// Make sure results and ORDER of results is correct too, the latest result comes first as on startup.
+				Expect(resultsArrReceived[0].SportsmenStartNumber).To(Equal(pendingSportsmen2.StartNumber))
+				Expect(resultsArrReceived[0].SportsmenName).To(Equal(fmt.Sprintf("%s %s", pendingSportsmen2.FirstName, pendingSportsmen2.LastName)))
+				Expect(resultsArrReceived[0].TimeStart).To(Equal(resultToFinish.TimeStart))
+				Expect(resultsArrReceived[0].TimeFinish).To(Equal(resultToFinish.TimeFinish))
 
-				Expect(resultsArrReceived[1].SportsmenStartNumber).To(Equal(pendingSportsmen2.StartNumber))
-				Expect(resultsArrReceived[1].SportsmenName).To(Equal(fmt.Sprintf("%s %s", pendingSportsmen2.FirstName, pendingSportsmen2.LastName)))
-				Expect(resultsArrReceived[1].TimeStart).To(Equal(resultToFinish.TimeStart))
-				Expect(resultsArrReceived[1].TimeFinish).To(Equal(resultToFinish.TimeFinish))
+				Expect(resultsArrReceived[1].SportsmenStartNumber).To(Equal(pendingSportsmen.StartNumber))
+				Expect(resultsArrReceived[1].SportsmenName).To(Equal(fmt.Sprintf("%s %s", pendingSportsmen.FirstName, pendingSportsmen.LastName)))
+				Expect(resultsArrReceived[1].TimeStart).To(Equal(newReq.Time))
 
 				s.Close()
 				ws.Close()
 			})
 		})
 	})
+
+	Describe("Results of the events kept apart", func() {
+		conn, err := utils.GetDBConnection(
+			cfg.DBDriver,
+			cfg.DBUsername,
+			cfg.DBPassword,
+			cfg.DBPort,
+			cfg.DBHost,
+			cfg.DBName,
+		)
+		Expect(err).To(BeNil())
+
+		// Set up the dashboard Websocket API module
+		dashboard := &dashboard_controller.Dashboard{
+			ConnHub: make(map[string]*dashboard_controller.Connection),
+			Results: make(chan dashboard_controller.UnfinishedResultMessage),
+			Finish:  make(chan dashboard_controller.FinishedResultMessage),
+			Join:    make(chan *dashboard_controller.Connection),
+			Leave:   make(chan *dashboard_controller.Connection),
+		}
+
+		srv := server.Server{}
+		srv.Addr = cfg.APIAddress
+		srv.DB = conn
+		srv.Router = mux.NewRouter()
+		srv.Dashboard = dashboard
+		srv.Dispatcher = dispatcher.NewDispatcher()
+		srv.Dashboard.Subscribe(srv.Dispatcher)
+
+		db := conn.Begin()
+		srv.DB = db
+
+		AfterEach(func() {
+			_ = db.Rollback()
+		})
+
+		When("There are results of two events", func() {
+			marathonCheckpoint := checkpoint.PendingCheckpoint{
+				ID:    uuid.Must(uuid.NewV4()),
+				Name:  "Corridor1",
+				Event: "City Marathon",
+			}
+
+			nightRunCheckpoint := checkpoint.PendingCheckpoint{
+				ID:    uuid.Must(uuid.NewV4()),
+				Name:  "Corridor1",
+				Event: "Night Run",
+			}
+
+			marathonSportsmen := sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+			}
+
+			nightRunSportsmen := sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Name2",
+				LastName:    "Lastname2",
+				StartNumber: 201,
+			}
+
+			marathonResult := result.PendingResult{
+				ID:           uuid.Must(uuid.NewV4()),
+				CheckpointID: marathonCheckpoint.ID,
+				SportsmenID:  marathonSportsmen.ID,
+			}
+
+			nightRunResult := result.PendingResult{
+				ID:           uuid.Must(uuid.NewV4()),
+				CheckpointID: nightRunCheckpoint.ID,
+				SportsmenID:  nightRunSportsmen.ID,
+			}
+
+			// dial connects the client subscribed to the query and reads the snapshot.
+			dial := func(u string) (*websocket.Conn, []dashboard_controller.ResultMessage) {
+				ws, _, err := websocket.DefaultDialer.Dial(u, nil)
+				Expect(err).To(BeNil())
+
+				_, msg, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+
+				var resultsReceived []dashboard_controller.ResultMessage
+				err = json.Unmarshal(msg, &resultsReceived)
+				Expect(err).To(BeNil())
+
+				return ws, resultsReceived
+			}
+
+			idsOf := func(results []dashboard_controller.ResultMessage) []string {
+				var ids []string
+				for _, result := range results {
+					ids = append(ids, result.ID)
+				}
+
+				return ids
+			}
+
+			addResult := func(checkpointID, sportsmenID uuid.UUID) {
+				requestBody, err := json.Marshal(result_controller.NewResultRequest{
+					CheckpointID: checkpointID.String(),
+					SportsmenID:  sportsmenID.String(),
+					Time:         utils.MakeTimestampInMilliseconds(),
+				})
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/results", bytes.NewBufferString(string(requestBody)))
+				Expect(err).To(BeNil())
+
+				rr := httptest.NewRecorder()
+				result_controller.AddResult(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+			}
+
+			BeforeEach(func() {
+				for _, pendingCheckpoint := range []checkpoint.PendingCheckpoint{marathonCheckpoint, nightRunCheckpoint} {
+					_, err := checkpoint.Create(*db, pendingCheckpoint)
+					Expect(err).To(BeNil())
+				}
+
+				for _, pendingSportsmen := range []sportsmen.PendingSportsmen{marathonSportsmen, nightRunSportsmen} {
+					_, err := sportsmen.Create(*db, pendingSportsmen)
+					Expect(err).To(BeNil())
+				}
+
+				for _, pendingResult := range []result.PendingResult{marathonResult, nightRunResult} {
+					pendingResult.TimeStart = utils.MakeTimestampInMilliseconds()
+
+					_, err := result.Create(*db, pendingResult)
+					Expect(err).To(BeNil())
+				}
+
+				// Start server after data has been created, that way server will load existing data on start.
+				go srv.Dashboard.Run(context.Background(), srv.DB)
+
+				for srv.Dashboard.LastResults == nil {
+					time.Sleep(1 * time.Second)
+					log.Print("Waiting for the srv to load the data")
+				}
+			})
+
+			Specify("No results of the other event returned", func() {
+				s := httptest.NewServer(http.HandlerFunc(srv.Dashboard.ResultsHandler))
+				// Convert http://127.0.0.1 to ws://127.0.0.
+				u := "ws" + strings.TrimPrefix(s.URL, "http")
+
+				// The snapshot of the event keeps its own results only.
+				marathonWs, resultsReceived := dial(u + "?event=City%20Marathon")
+				Expect(idsOf(resultsReceived)).To(Equal([]string{marathonResult.ID.String()}))
+				Expect(resultsReceived[0].Event).To(Equal(marathonCheckpoint.Event))
+
+				nightRunWs, resultsReceived := dial(u + "?event=Night%20Run")
+				Expect(idsOf(resultsReceived)).To(Equal([]string{nightRunResult.ID.String()}))
+				Expect(resultsReceived[0].Event).To(Equal(nightRunCheckpoint.Event))
+
+				// The client not subscribed to any event gets the results of every event.
+				ws, resultsReceived := dial(u)
+				Expect(idsOf(resultsReceived)).To(ConsistOf(marathonResult.ID.String(), nightRunResult.ID.String()))
+				ws.Close()
+
+				// The result of the other event is not delivered, the next message is the result of the event.
+				addResult(nightRunCheckpoint.ID, marathonSportsmen.ID)
+				addResult(marathonCheckpoint.ID, nightRunSportsmen.ID)
+
+				_, msg, err := marathonWs.ReadMessage()
+				Expect(err).To(BeNil())
+
+				msgNewResultReceived := dashboard_controller.UnfinishedResultMessage{}
+				err = json.Unmarshal(msg, &msgNewResultReceived)
+				Expect(err).To(BeNil())
+				Expect(msgNewResultReceived.SportsmenStartNumber).To(Equal(nightRunSportsmen.StartNumber))
+				Expect(msgNewResultReceived.Event).To(Equal(marathonCheckpoint.Event))
+
+				_, msg, err = nightRunWs.ReadMessage()
+				Expect(err).To(BeNil())
+
+				err = json.Unmarshal(msg, &msgNewResultReceived)
+				Expect(err).To(BeNil())
+				Expect(msgNewResultReceived.SportsmenStartNumber).To(Equal(marathonSportsmen.StartNumber))
+				Expect(msgNewResultReceived.Event).To(Equal(nightRunCheckpoint.Event))
+
+				// The snapshots updated by the broadcasts keep the results of their event only.
+				ws, resultsReceived = dial(u + "?event=City%20Marathon")
+				Expect(resultsReceived).To(HaveLen(2))
+				for _, received := range resultsReceived {
+					Expect(received.Event).To(Equal(marathonCheckpoint.Event))
+				}
+				ws.Close()
+
+				ws, resultsReceived = dial(u + "?event=Night%20Run")
+				Expect(resultsReceived).To(HaveLen(2))
+				for _, received := range resultsReceived {
+					Expect(received.Event).To(Equal(nightRunCheckpoint.Event))
+				}
+				ws.Close()
+
+				s.Close()
+				marathonWs.Close()
+				nightRunWs.Close()
+			})
+		})
+	})
 })
@@ -0,0 +1,170 @@
+package dashboard_controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Number of messages buffered per Server-Sent Events client before dropping.
+	eventStreamBufferSize = 64
+
+	// Interval of the keep-alive comments so that proxies won't close an idle stream.
+	eventStreamKeepAlive = 15 * time.Second
+)
+
+// Server-Sent Events names of the dashboard messages, the payload is the same JSON the WebSocket clients receive.
+const (
+	EventResults = "results"
+	EventResult  = "result"
+	EventFinish  = "finish"
+	EventLap     = "lap"
+	EventTeam    = "team"
+
+	EventAnnouncement          = "announcement"
+	EventAnnouncementRetracted = "announcement_retracted"
+
+	EventStatus = "status"
+)
+
+// Codes of the status messages.
+const (
+	StatusRestarting = "restarting"
+)
+
+// sseEvent is a single encoded event waiting to be written to the stream.
+type sseEvent struct {
+	ID    uint64
+	Event string
+	Data  []byte
+}
+
+// EventStream delivers dashboard messages over the Server-Sent Events connection.
+type EventStream struct {
+	events chan sseEvent
+}
+
+func newEventStream() *EventStream {
+	return &EventStream{
+		events: make(chan sseEvent, eventStreamBufferSize),
+	}
+}
+
+// send queues the event without blocking the dashboard hub, slow clients lose messages
+// and are expected to reconnect with Last-Event-ID.
+func (s *EventStream) send(event sseEvent) bool {
+	select {
+	case s.events <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops the stream, must be called by the dashboard hub only.
+func (s *EventStream) close() {
+	close(s.events)
+}
+
+// serve writes queued events to the client until the request is cancelled or the stream is closed.
+func (s *EventStream) serve(ctx context.Context, w http.ResponseWriter, flusher http.Flusher) {
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-s.events:
+			if !ok {
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, event.Data); err != nil {
+				zap.S().Info("Error on write event:", err.Error())
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// EventsHandler streams the dashboard messages as Server-Sent Events for clients
+// which can't use WebSocket, reconnecting clients are resumed from the Last-Event-ID.
+func (d *Dashboard) EventsHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	subscription, err := ParseSubscription(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	lastEventID, err := parseLastEventID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if d.isStopped() {
+		http.Error(w, ErrStopped.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	conn := &Connection{
+		Name:         fmt.Sprintf("anon-%s", uuid.Must(uuid.NewV4())),
+		Stream:       newEventStream(),
+		Subscription: subscription,
+		LastEventID:  lastEventID,
+		Global:       d,
+	}
+
+	if err := d.join(conn); err != nil {
+		return
+	}
+
+	conn.Stream.serve(r.Context(), w, flusher)
+
+	d.leave(conn)
+}
+
+// parseLastEventID reads the id of the last event received by the client, browsers send it
+// in the Last-Event-ID header, the query parameter is supported for polyfills.
+func parseLastEventID(r *http.Request) (*uint64, error) {
+	value := strings.TrimSpace(r.Header.Get("Last-Event-ID"))
+	if value == "" {
+		value = strings.TrimSpace(r.URL.Query().Get("last_event_id"))
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Last-Event-ID: invalid value %q", value)
+	}
+
+	return &id, nil
+}
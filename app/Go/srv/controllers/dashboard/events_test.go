@@ -0,0 +1,203 @@
+package dashboard_controller_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/cmd/config"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	result_controller "sports/backend/srv/controllers/result"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+	"strings"
+	"time"
+)
+
+// readEvent reads a single Server-Sent Event from the stream.
+func readEvent(reader *bufio.Reader) (id, event, data string) {
+	for {
+		line, err := reader.ReadString('\n')
+		Expect(err).To(BeNil())
+
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			return id, event, data
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+var _ = Describe("Dashboard events stream", func() {
+	// To change the flags on the default logger to show the code line for better understanding.
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+
+	Describe("Messages streamed, filtered and resumed", func() {
+		conn, err := utils.GetDBConnection(
+			cfg.DBDriver,
+			cfg.DBUsername,
+			cfg.DBPassword,
+			cfg.DBPort,
+			cfg.DBHost,
+			cfg.DBName,
+		)
+		Expect(err).To(BeNil())
+
+		// Set up the dashboard Websocket API module
+		dashboard := &dashboard_controller.Dashboard{
+			ConnHub: make(map[string]*dashboard_controller.Connection),
+			Results: make(chan dashboard_controller.UnfinishedResultMessage),
+			Finish:  make(chan dashboard_controller.FinishedResultMessage),
+			Join:    make(chan *dashboard_controller.Connection),
+			Leave:   make(chan *dashboard_controller.Connection),
+		}
+
+		srv := server.Server{}
+		srv.Addr = cfg.APIAddress
+		srv.DB = conn
+		srv.Router = mux.NewRouter()
+		srv.Dashboard = dashboard
+		srv.Dispatcher = dispatcher.NewDispatcher()
+		srv.Dashboard.Subscribe(srv.Dispatcher)
+
+		db := conn.Begin()
+		srv.DB = db
+
+		AfterEach(func() {
+			_ = db.Rollback()
+		})
+
+		When("The stream is subscribed to a sportsmen", func() {
+			pendingCheckpoint := checkpoint.PendingCheckpoint{
+				ID:   uuid.Must(uuid.NewV4()),
+				Name: "Corridor1",
+			}
+
+			pendingSportsmen := sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+			}
+
+			pendingSportsmen2 := sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Name2",
+				LastName:    "Lastname2",
+				StartNumber: 102,
+			}
+
+			BeforeEach(func() {
+				_, err := checkpoint.Create(*db, pendingCheckpoint)
+				Expect(err).To(BeNil())
+
+				_, err = sportsmen.Create(*db, pendingSportsmen)
+				Expect(err).To(BeNil())
+
+				_, err = sportsmen.Create(*db, pendingSportsmen2)
+				Expect(err).To(BeNil())
+
+				go srv.Dashboard.Run(context.Background(), srv.DB)
+
+				for srv.Dashboard.LastResults == nil {
+					time.Sleep(1 * time.Second)
+					log.Print("Waiting for the srv to load the data")
+				}
+			})
+
+			Specify("Only subscribed messages streamed and missed messages replayed on reconnect", func() {
+				s := httptest.NewServer(http.HandlerFunc(srv.Dashboard.EventsHandler))
+
+				res, err := http.Get(fmt.Sprintf("%s?start_number=%d", s.URL, pendingSportsmen2.StartNumber))
+				Expect(err).To(BeNil())
+				Expect(res.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+				reader := bufio.NewReader(res.Body)
+
+				// Ensure that state returned from server has no results.
+				lastEventID, event, data := readEvent(reader)
+				Expect(event).To(Equal(dashboard_controller.EventResults))
+				Expect(data).To(Equal("null"))
+
+				addResult := func(sportsmenID uuid.UUID) {
+					requestBody, err := json.Marshal(result_controller.NewResultRequest{
+						CheckpointID: pendingCheckpoint.ID.String(),
+						SportsmenID:  sportsmenID.String(),
+						Time:         utils.MakeTimestampInMilliseconds(),
+					})
+					Expect(err).To(BeNil())
+
+					req, err := http.NewRequest("POST", "/results", bytes.NewBuffer(requestBody))
+					Expect(err).To(BeNil())
+
+					rr := httptest.NewRecorder()
+					result_controller.AddResult(&srv).ServeHTTP(rr, req)
+					Expect(rr.Code).To(Equal(http.StatusOK))
+				}
+
+				// Result of the sportsmen not subscribed to is not streamed.
+				addResult(pendingSportsmen.ID)
+				addResult(pendingSportsmen2.ID)
+
+				id, event, data := readEvent(reader)
+				Expect(event).To(Equal(dashboard_controller.EventResult))
+
+				msg := dashboard_controller.UnfinishedResultMessage{}
+				err = json.Unmarshal([]byte(data), &msg)
+				Expect(err).To(BeNil())
+				Expect(msg.SportsmenStartNumber).To(Equal(pendingSportsmen2.StartNumber))
+				Expect(id).ToNot(Equal(lastEventID))
+
+				res.Body.Close()
+
+				// Reconnect with the id of the initial state, the missed result is replayed.
+				req, err := http.NewRequest("GET", fmt.Sprintf("%s?start_number=%d", s.URL, pendingSportsmen2.StartNumber), nil)
+				Expect(err).To(BeNil())
+				req.Header.Set("Last-Event-ID", lastEventID)
+
+				res, err = http.DefaultClient.Do(req)
+				Expect(err).To(BeNil())
+
+				reader = bufio.NewReader(res.Body)
+				replayedID, event, data := readEvent(reader)
+				Expect(replayedID).To(Equal(id))
+				Expect(event).To(Equal(dashboard_controller.EventResult))
+
+				replayed := dashboard_controller.UnfinishedResultMessage{}
+				err = json.Unmarshal([]byte(data), &replayed)
+				Expect(err).To(BeNil())
+				Expect(replayed).To(Equal(msg))
+
+				res.Body.Close()
+				s.Close()
+			})
+		})
+	})
+})
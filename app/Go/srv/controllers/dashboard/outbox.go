@@ -0,0 +1,223 @@
+package dashboard_controller
+
+import (
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/srv/dispatcher"
+)
+
+// SubscriberName names the hub among the outbox subscribers.
+const SubscriberName = "dashboard"
+
+// Subscribe the hub to the result, the lap, the team and the announcement events of the outbox, the events are
+// broadcast once their dispatch has committed, so the dispatch rolled back and retried does not broadcast them twice.
+func (d *Dashboard) Subscribe(outboxDispatcher *dispatcher.Dispatcher) {
+	outboxDispatcher.Notify(result.TopicCreated, SubscriberName, d.publishResultCreated)
+	outboxDispatcher.Notify(result.TopicFinished, SubscriberName, d.publishResultFinished)
+	outboxDispatcher.Notify(lap.TopicRecorded, SubscriberName, d.publishLapRecorded)
+	outboxDispatcher.Notify(team.TopicHandedOver, SubscriberName, d.publishTeamHandedOver)
+	outboxDispatcher.Notify(announcement.TopicCreated, SubscriberName, d.publishAnnouncementCreated)
+	outboxDispatcher.Notify(announcement.TopicRetracted, SubscriberName, d.publishAnnouncementRetracted)
+}
+
+func (d *Dashboard) publishResultCreated(tx gorm.DB, message outbox.Message) (func(), error) {
+	event := result.ResultCreatedEvent{}
+	if err := event.Unmarshal(message.Payload); err != nil {
+		return nil, err
+	}
+
+	sportsmenFetched, err := sportsmen.GetSportsmen(tx, uuid.FromStringOrNil(event.SportsmenID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointFetched, err := checkpoint.GetCheckpoint(tx, uuid.FromStringOrNil(event.CheckpointID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := UnfinishedResultMessage{
+		ID:                   event.ResultID,
+		SportsmenName:        fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
+		SportsmenStartNumber: sportsmenFetched.StartNumber,
+		Category:             sportsmenFetched.Category,
+		TimeStart:            event.TimeStart,
+		Event:                checkpointFetched.Event,
+	}
+
+	return func() {
+		d.PublishResult(msg)
+	}, nil
+}
+
+func (d *Dashboard) publishResultFinished(tx gorm.DB, message outbox.Message) (func(), error) {
+	event := result.ResultFinishedEvent{}
+	if err := event.Unmarshal(message.Payload); err != nil {
+		return nil, err
+	}
+
+	resultFetched, err := result.GetResult(tx, uuid.FromStringOrNil(event.ResultID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sportsmenFetched, err := sportsmen.GetSportsmen(tx, resultFetched.SportsmenID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointFetched, err := checkpoint.GetCheckpoint(tx, resultFetched.CheckpointID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := FinishedResultMessage{
+		ID:                   event.ResultID,
+		SportsmenName:        fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
+		SportsmenStartNumber: sportsmenFetched.StartNumber,
+		Category:             sportsmenFetched.Category,
+		TimeStart:            resultFetched.TimeStart,
+		TimeFinish:           event.TimeFinish,
+		Event:                checkpointFetched.Event,
+	}
+
+	return func() {
+		d.PublishFinish(msg)
+	}, nil
+}
+
+func (d *Dashboard) publishLapRecorded(tx gorm.DB, message outbox.Message) (func(), error) {
+	event := lap.LapRecordedEvent{}
+	if err := event.Unmarshal(message.Payload); err != nil {
+		return nil, err
+	}
+
+	resultFetched, err := result.GetResult(tx, uuid.FromStringOrNil(event.ResultID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sportsmenFetched, err := sportsmen.GetSportsmen(tx, resultFetched.SportsmenID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointFetched, err := checkpoint.GetCheckpoint(tx, resultFetched.CheckpointID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	laps, err := lap.GetLaps(tx, resultFetched.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := LapMessage{
+		Type:                 EventLap,
+		ID:                   event.ResultID,
+		SportsmenName:        fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
+		SportsmenStartNumber: sportsmenFetched.StartNumber,
+		Category:             sportsmenFetched.Category,
+		TimeStart:            resultFetched.TimeStart,
+		Number:               event.Number,
+		LapTime:              event.LapTime,
+		Partial:              event.Partial,
+		LastPassing:          event.Time,
+		Event:                checkpointFetched.Event,
+	}
+
+	// The progress is summed up to the lap, the later laps may have been recorded before the dispatch.
+	for _, recorded := range *laps {
+		if recorded.Number > event.Number {
+			break
+		}
+		if !recorded.Partial {
+			msg.Laps++
+		}
+		msg.Distance += recorded.Distance
+	}
+
+	return func() {
+		d.PublishLap(msg)
+	}, nil
+}
+
+func (d *Dashboard) publishTeamHandedOver(tx gorm.DB, message outbox.Message) (func(), error) {
+	event := team.TeamHandedOverEvent{}
+	if err := event.Unmarshal(message.Payload); err != nil {
+		return nil, err
+	}
+
+	teamID := uuid.FromStringOrNil(event.TeamID)
+
+	standing, err := team.GetStanding(tx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	legResults, err := team.GetLegResults(tx, []uuid.UUID{teamID})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := newTeamMessage(*standing, *legResults)
+
+	return func() {
+		d.PublishTeam(msg)
+	}, nil
+}
+
+func (d *Dashboard) publishAnnouncementCreated(tx gorm.DB, message outbox.Message) (func(), error) {
+	event := announcement.AnnouncementCreatedEvent{}
+	if err := event.Unmarshal(message.Payload); err != nil {
+		return nil, err
+	}
+
+	var expiresAt *int64
+	if event.ExpiresAt != 0 {
+		expiresAt = &event.ExpiresAt
+	}
+
+	msg := AnnouncementMessage{
+		Type:      EventAnnouncement,
+		ID:        event.AnnouncementID,
+		Message:   event.Message,
+		Severity:  event.Severity,
+		Event:     event.Event,
+		ExpiresAt: expiresAt,
+	}
+
+	return func() {
+		d.PublishAnnouncement(msg)
+	}, nil
+}
+
+func (d *Dashboard) publishAnnouncementRetracted(tx gorm.DB, message outbox.Message) (func(), error) {
+	event := announcement.AnnouncementRetractedEvent{}
+	if err := event.Unmarshal(message.Payload); err != nil {
+		return nil, err
+	}
+
+	announcementFetched, err := announcement.GetAnnouncement(tx, uuid.FromStringOrNil(event.AnnouncementID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := AnnouncementMessage{
+		Type:  EventAnnouncementRetracted,
+		ID:    event.AnnouncementID,
+		Event: announcementFetched.Event,
+	}
+
+	return func() {
+		d.PublishAnnouncement(msg)
+	}, nil
+}
@@ -0,0 +1,165 @@
+package dashboard_controller
+
+import (
+	"fmt"
+	"sports/backend/srv/controllers/dashboard/messages"
+)
+
+// WebSocket subprotocols of the dashboard, clients not asking for any get the JSON text frames.
+const (
+	JSONSubprotocol     = "dashboard.v1.json"
+	ProtobufSubprotocol = "dashboard.v1.protobuf"
+)
+
+// encodeProtobuf wraps the dashboard message into the binary frame sent to the protobuf clients.
+func encodeProtobuf(id uint64, message interface{}) ([]byte, error) {
+	frame := dashboard_messages.DashboardMessage{ID: id}
+
+	switch m := message.(type) {
+	case *[]ResultMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Snapshot{Snapshot: snapshotToProtobuf(m)}
+	case *ResultMessage:
+		// Single result is only sent as the empty state.
+		frame.Payload = &dashboard_messages.DashboardMessage_Snapshot{Snapshot: &dashboard_messages.Snapshot{}}
+	case *UnfinishedResultMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Start{Start: startToProtobuf(m)}
+	case UnfinishedResultMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Start{Start: startToProtobuf(&m)}
+	case *FinishedResultMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Finish{Finish: finishToProtobuf(m)}
+	case FinishedResultMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Finish{Finish: finishToProtobuf(&m)}
+	case *LapMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Lap{Lap: lapToProtobuf(m)}
+	case LapMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Lap{Lap: lapToProtobuf(&m)}
+	case *TeamMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Team{Team: teamToProtobuf(m)}
+	case TeamMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Team{Team: teamToProtobuf(&m)}
+	case *AnnouncementMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Announcement{Announcement: announcementToProtobuf(m)}
+	case AnnouncementMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Announcement{Announcement: announcementToProtobuf(&m)}
+	case *StatusMessage:
+		frame.Payload = &dashboard_messages.DashboardMessage_Status{Status: &dashboard_messages.Status{
+			Code:        m.Code,
+			Message:     m.Message,
+			ReconnectIn: m.ReconnectIn,
+		}}
+	default:
+		return nil, fmt.Errorf("Unsupported dashboard message %T", message)
+	}
+
+	return frame.Marshal()
+}
+
+func snapshotToProtobuf(results *[]ResultMessage) *dashboard_messages.Snapshot {
+	snapshot := &dashboard_messages.Snapshot{}
+	if results == nil {
+		return snapshot
+	}
+
+	for _, result := range *results {
+		r := &dashboard_messages.Result{
+			ID:          result.ID,
+			StartNumber: result.SportsmenStartNumber,
+			Name:        result.SportsmenName,
+			Category:    result.Category,
+			TimeStart:   result.TimeStart,
+			Laps:        result.Laps,
+			Distance:    result.Distance,
+			Event:       result.Event,
+		}
+
+		if result.TimeFinish != nil {
+			r.TimeFinish = *result.TimeFinish
+		}
+
+		if result.LastPassing != nil {
+			r.LastPassing = *result.LastPassing
+		}
+
+		snapshot.Results = append(snapshot.Results, r)
+	}
+
+	return snapshot
+}
+
+func startToProtobuf(result *UnfinishedResultMessage) *dashboard_messages.Start {
+	return &dashboard_messages.Start{
+		ID:          result.ID,
+		StartNumber: result.SportsmenStartNumber,
+		Name:        result.SportsmenName,
+		Category:    result.Category,
+		TimeStart:   result.TimeStart,
+		Event:       result.Event,
+	}
+}
+
+func finishToProtobuf(finish *FinishedResultMessage) *dashboard_messages.Finish {
+	return &dashboard_messages.Finish{
+		ID:          finish.ID,
+		StartNumber: finish.SportsmenStartNumber,
+		Name:        finish.SportsmenName,
+		Category:    finish.Category,
+		TimeStart:   finish.TimeStart,
+		TimeFinish:  finish.TimeFinish,
+		Event:       finish.Event,
+	}
+}
+
+func lapToProtobuf(lap *LapMessage) *dashboard_messages.Lap {
+	return &dashboard_messages.Lap{
+		ID:          lap.ID,
+		StartNumber: lap.SportsmenStartNumber,
+		Name:        lap.SportsmenName,
+		Category:    lap.Category,
+		TimeStart:   lap.TimeStart,
+		Number:      lap.Number,
+		LapTime:     lap.LapTime,
+		Partial:     lap.Partial,
+		Laps:        lap.Laps,
+		Distance:    lap.Distance,
+		LastPassing: lap.LastPassing,
+		Event:       lap.Event,
+	}
+}
+
+func teamToProtobuf(team *TeamMessage) *dashboard_messages.Team {
+	t := &dashboard_messages.Team{
+		ID:           team.ID,
+		Name:         team.Name,
+		Category:     team.Category,
+		Legs:         team.Legs,
+		LegsFinished: team.LegsFinished,
+		TotalTime:    team.TotalTime,
+		StartNumbers: team.StartNumbers,
+	}
+
+	if team.TimeStart != nil {
+		t.TimeStart = *team.TimeStart
+	}
+
+	if team.TimeFinish != nil {
+		t.TimeFinish = *team.TimeFinish
+	}
+
+	return t
+}
+
+func announcementToProtobuf(announcement *AnnouncementMessage) *dashboard_messages.Announcement {
+	a := &dashboard_messages.Announcement{
+		ID:        announcement.ID,
+		Message:   announcement.Message,
+		Severity:  announcement.Severity,
+		Event:     announcement.Event,
+		Retracted: announcement.Type == EventAnnouncementRetracted,
+	}
+
+	if announcement.ExpiresAt != nil {
+		a.ExpiresAt = *announcement.ExpiresAt
+	}
+
+	return a
+}
@@ -0,0 +1,161 @@
+package dashboard_controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/cmd/config"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/controllers/dashboard/messages"
+	result_controller "sports/backend/srv/controllers/result"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Dashboard protobuf subprotocol", func() {
+	// To change the flags on the default logger to show the code line for better understanding.
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+
+	Describe("Binary frames sent to the protobuf clients", func() {
+		conn, err := utils.GetDBConnection(
+			cfg.DBDriver,
+			cfg.DBUsername,
+			cfg.DBPassword,
+			cfg.DBPort,
+			cfg.DBHost,
+			cfg.DBName,
+		)
+		Expect(err).To(BeNil())
+
+		// Set up the dashboard Websocket API module
+		dashboard := &dashboard_controller.Dashboard{
+			ConnHub: make(map[string]*dashboard_controller.Connection),
+			Results: make(chan dashboard_controller.UnfinishedResultMessage),
+			Finish:  make(chan dashboard_controller.FinishedResultMessage),
+			Join:    make(chan *dashboard_controller.Connection),
+			Leave:   make(chan *dashboard_controller.Connection),
+		}
+
+		srv := server.Server{}
+		srv.Addr = cfg.APIAddress
+		srv.DB = conn
+		srv.Router = mux.NewRouter()
+		srv.Dashboard = dashboard
+		srv.Dispatcher = dispatcher.NewDispatcher()
+		srv.Dashboard.Subscribe(srv.Dispatcher)
+
+		db := conn.Begin()
+		srv.DB = db
+
+		AfterEach(func() {
+			_ = db.Rollback()
+		})
+
+		When("The client negotiates the protobuf subprotocol", func() {
+			pendingCheckpoint := checkpoint.PendingCheckpoint{
+				ID:   uuid.Must(uuid.NewV4()),
+				Name: "Corridor1",
+			}
+
+			pendingSportsmen := sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+			}
+
+			BeforeEach(func() {
+				_, err := checkpoint.Create(*db, pendingCheckpoint)
+				Expect(err).To(BeNil())
+
+				_, err = sportsmen.Create(*db, pendingSportsmen)
+				Expect(err).To(BeNil())
+
+				go srv.Dashboard.Run(context.Background(), srv.DB)
+
+				for srv.Dashboard.LastResults == nil {
+					time.Sleep(1 * time.Second)
+					log.Print("Waiting for the srv to load the data")
+				}
+			})
+
+			Specify("Snapshot and start messages received as binary frames", func() {
+				s := httptest.NewServer(http.HandlerFunc(srv.Dashboard.ResultsHandler))
+				// Convert http://127.0.0.1 to ws://127.0.0.
+				u := "ws" + strings.TrimPrefix(s.URL, "http")
+
+				dialer := websocket.Dialer{Subprotocols: []string{dashboard_controller.ProtobufSubprotocol}}
+				ws, _, err := dialer.Dial(u, nil)
+				Expect(err).To(BeNil())
+				Expect(ws.Subprotocol()).To(Equal(dashboard_controller.ProtobufSubprotocol))
+
+				// Ensure that state returned from server has no results.
+				messageType, msg, err := ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(messageType).To(Equal(websocket.BinaryMessage))
+
+				frame := dashboard_messages.DashboardMessage{}
+				err = frame.Unmarshal(msg)
+				Expect(err).To(BeNil())
+				Expect(frame.GetSnapshot()).ToNot(BeNil())
+				Expect(frame.GetSnapshot().Results).To(BeEmpty())
+
+				newReq := result_controller.NewResultRequest{
+					CheckpointID: pendingCheckpoint.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Time:         utils.MakeTimestampInMilliseconds(),
+				}
+
+				requestBody, err := json.Marshal(newReq)
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/results", bytes.NewBuffer(requestBody))
+				Expect(err).To(BeNil())
+
+				rr := httptest.NewRecorder()
+				result_controller.AddResult(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				messageType, msg, err = ws.ReadMessage()
+				Expect(err).To(BeNil())
+				Expect(messageType).To(Equal(websocket.BinaryMessage))
+
+				frame = dashboard_messages.DashboardMessage{}
+				err = frame.Unmarshal(msg)
+				Expect(err).To(BeNil())
+				Expect(frame.ID).To(BeNumerically(">", 0))
+				Expect(frame.GetStart()).ToNot(BeNil())
+				Expect(frame.GetStart().StartNumber).To(Equal(pendingSportsmen.StartNumber))
+				Expect(frame.GetStart().Name).To(Equal(fmt.Sprintf("%s %s", pendingSportsmen.FirstName, pendingSportsmen.LastName)))
+				Expect(frame.GetStart().TimeStart).To(Equal(newReq.Time))
+
+				s.Close()
+				ws.Close()
+			})
+		})
+	})
+})
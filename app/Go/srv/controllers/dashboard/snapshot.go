@@ -0,0 +1,260 @@
+package dashboard_controller
+
+import (
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"sort"
+)
+
+// Snapshot policies define which results are sent to the recently joined clients.
+const (
+	// SnapshotLast keeps the latest started results.
+	SnapshotLast = "last"
+	// SnapshotOnCourse keeps the started results without finish time.
+	SnapshotOnCourse = "on_course"
+	// SnapshotLeaderboard keeps the finished results with the best time.
+	SnapshotLeaderboard = "leaderboard"
+	// SnapshotCategory keeps the latest started results of every sportsmen category.
+	SnapshotCategory = "category"
+)
+
+// DefaultSnapshotSize is the number of results kept when the size is not configured.
+const DefaultSnapshotSize = 10
+
+// SnapshotPolicy configures the dashboard snapshot, zero values fall back to the last 10 results.
+type SnapshotPolicy struct {
+	Mode string `json:"mode"`
+	Size int    `json:"size"`
+}
+
+// Validate checks the snapshot policy configuration.
+func (p SnapshotPolicy) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Mode, validation.In(SnapshotLast, SnapshotOnCourse, SnapshotLeaderboard, SnapshotCategory)),
+		validation.Field(&p.Size, validation.Min(1)),
+	)
+}
+
+func (p SnapshotPolicy) withDefaults() SnapshotPolicy {
+	if p.Mode == "" {
+		p.Mode = SnapshotLast
+	}
+	if p.Size == 0 {
+		p.Size = DefaultSnapshotSize
+	}
+
+	return p
+}
+
+// SnapshotPolicies configures the snapshot of every event, the clients subscribed to the event
+// without its own policy and the clients not subscribed to any event get the default policy.
+type SnapshotPolicies struct {
+	Default SnapshotPolicy
+	Events  map[string]SnapshotPolicy
+}
+
+// Validate checks the default policy and the policies of the events.
+func (p SnapshotPolicies) Validate() error {
+	err := p.Default.Validate()
+	if err != nil {
+		return err
+	}
+
+	for event, policy := range p.Events {
+		if event == "" {
+			return errors.New("the event of the snapshot policy is blank")
+		}
+
+		err = policy.Validate()
+		if err != nil {
+			return fmt.Errorf("event %q: %w", event, err)
+		}
+	}
+
+	return nil
+}
+
+// For returns the policy of the event, the default policy is returned for the event without its own one
+// and the zero values of the event policy fall back to the default policy.
+func (p SnapshotPolicies) For(event string) SnapshotPolicy {
+	policy, ok := p.Events[event]
+	if !ok {
+		return p.Default.withDefaults()
+	}
+
+	if policy.Mode == "" {
+		policy.Mode = p.Default.Mode
+	}
+	if policy.Size == 0 {
+		policy.Size = p.Default.Size
+	}
+
+	return policy.withDefaults()
+}
+
+// Snapshot keeps the dashboard results bounded and ordered according to the policy,
+// the latest started results come first, the leaderboard is ordered by the distance, the laps and the best time.
+type Snapshot struct {
+	policy  SnapshotPolicy
+	results []ResultMessage
+}
+
+// NewSnapshot creates a snapshot from the results loaded from the database.
+func NewSnapshot(policy SnapshotPolicy, results []ResultMessage) *Snapshot {
+	s := &Snapshot{policy: policy.withDefaults()}
+
+	for _, result := range results {
+		s.insert(result)
+	}
+
+	return s
+}
+
+// Policy returns the policy in effect.
+func (s *Snapshot) Policy() SnapshotPolicy {
+	return s.policy
+}
+
+// Add puts the new started result into the snapshot.
+func (s *Snapshot) Add(result ResultMessage) {
+	s.insert(result)
+}
+
+// Finish updates the result with the finish time, the result is added when the policy
+// includes it now and removed when it does not include finished results any more.
+func (s *Snapshot) Finish(finish FinishedResultMessage) {
+	timeFinish := finish.TimeFinish
+
+	finished := ResultMessage{
+		ID:                   finish.ID,
+		SportsmenStartNumber: finish.SportsmenStartNumber,
+		SportsmenName:        finish.SportsmenName,
+		Category:             finish.Category,
+		TimeStart:            finish.TimeStart,
+		TimeFinish:           &timeFinish,
+		Event:                finish.Event,
+	}
+
+	// Keep the progress of the circuit race result.
+	if current, ok := s.find(finish.ID); ok {
+		finished.Laps = current.Laps
+		finished.Distance = current.Distance
+		finished.LastPassing = current.LastPassing
+	}
+
+	s.remove(finish.ID)
+	s.insert(finished)
+}
+
+// Lap updates the progress of the result kept in the snapshot, the results not kept are ignored.
+func (s *Snapshot) Lap(lap LapMessage) {
+	current, ok := s.find(lap.ID)
+	if !ok {
+		return
+	}
+
+	lastPassing := lap.LastPassing
+	current.Laps = lap.Laps
+	current.Distance = lap.Distance
+	current.LastPassing = &lastPassing
+
+	s.insert(current)
+}
+
+// Results returns a copy of the snapshot results, nil is returned when the snapshot is empty.
+func (s *Snapshot) Results() []ResultMessage {
+	if len(s.results) == 0 {
+		return nil
+	}
+
+	results := make([]ResultMessage, len(s.results))
+	copy(results, s.results)
+
+	return results
+}
+
+func (s *Snapshot) insert(result ResultMessage) {
+	if !s.includes(result) {
+		return
+	}
+
+	// Put the result first so that it precedes the results started at the same time.
+	s.remove(result.ID)
+	s.results = append([]ResultMessage{result}, s.results...)
+	sort.SliceStable(s.results, func(i, j int) bool {
+		return s.less(s.results[i], s.results[j])
+	})
+	s.trim()
+}
+
+func (s *Snapshot) find(id string) (ResultMessage, bool) {
+	for _, result := range s.results {
+		if result.ID == id {
+			return result, true
+		}
+	}
+
+	return ResultMessage{}, false
+}
+
+func (s *Snapshot) remove(id string) {
+	for index, result := range s.results {
+		if result.ID == id {
+			s.results = append(s.results[:index], s.results[index+1:]...)
+			return
+		}
+	}
+}
+
+// includes reports whether the result belongs to the snapshot according to the policy.
+func (s *Snapshot) includes(result ResultMessage) bool {
+	switch s.policy.Mode {
+	case SnapshotOnCourse:
+		return result.TimeFinish == nil
+	case SnapshotLeaderboard:
+		return result.TimeFinish != nil
+	default:
+		return true
+	}
+}
+
+func (s *Snapshot) less(a, b ResultMessage) bool {
+	if s.policy.Mode == SnapshotLeaderboard {
+		if a.Distance != b.Distance {
+			return a.Distance > b.Distance
+		}
+		if a.Laps != b.Laps {
+			return a.Laps > b.Laps
+		}
+
+		elapsedA, elapsedB := *a.TimeFinish-a.TimeStart, *b.TimeFinish-b.TimeStart
+		if elapsedA != elapsedB {
+			return elapsedA < elapsedB
+		}
+
+		return *a.TimeFinish < *b.TimeFinish
+	}
+
+	return a.TimeStart > b.TimeStart
+}
+
+// trim drops the results exceeding the size, per category for the category policy.
+func (s *Snapshot) trim() {
+	if s.policy.Mode != SnapshotCategory {
+		if len(s.results) > s.policy.Size {
+			s.results = s.results[:s.policy.Size]
+		}
+		return
+	}
+
+	perCategory := make(map[string]int)
+	kept := s.results[:0]
+	for _, result := range s.results {
+		if perCategory[result.Category] < s.policy.Size {
+			perCategory[result.Category]++
+			kept = append(kept, result)
+		}
+	}
+	s.results = kept
+}
@@ -0,0 +1,216 @@
+package dashboard_controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+)
+
+var _ = Describe("Dashboard snapshot", func() {
+	started := func(id string, startNumber uint32, category string, timeStart int64) dashboard_controller.ResultMessage {
+		return dashboard_controller.ResultMessage{
+			ID:                   id,
+			SportsmenStartNumber: startNumber,
+			SportsmenName:        "Name Lastname",
+			Category:             category,
+			TimeStart:            timeStart,
+		}
+	}
+
+	finished := func(result dashboard_controller.ResultMessage, timeFinish int64) dashboard_controller.FinishedResultMessage {
+		return dashboard_controller.FinishedResultMessage{
+			ID:                   result.ID,
+			SportsmenStartNumber: result.SportsmenStartNumber,
+			SportsmenName:        result.SportsmenName,
+			Category:             result.Category,
+			TimeStart:            result.TimeStart,
+			TimeFinish:           timeFinish,
+		}
+	}
+
+	ids := func(results []dashboard_controller.ResultMessage) []string {
+		var ids []string
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+		return ids
+	}
+
+	Describe("Validating the policy", func() {
+		Specify("Empty policy is valid and unknown mode is not", func() {
+			Expect(dashboard_controller.SnapshotPolicy{}.Validate()).To(BeNil())
+			Expect(dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotLeaderboard, Size: 3}.Validate()).To(BeNil())
+			Expect(dashboard_controller.SnapshotPolicy{Mode: "first"}.Validate()).ToNot(BeNil())
+			Expect(dashboard_controller.SnapshotPolicy{Size: -1}.Validate()).ToNot(BeNil())
+		})
+
+		Specify("Policies of the events are checked with the default one", func() {
+			policies := dashboard_controller.SnapshotPolicies{
+				Events: map[string]dashboard_controller.SnapshotPolicy{"City Marathon": {Mode: dashboard_controller.SnapshotLeaderboard}},
+			}
+			Expect(policies.Validate()).To(BeNil())
+
+			policies.Events["Criterium"] = dashboard_controller.SnapshotPolicy{Mode: "first"}
+			Expect(policies.Validate()).ToNot(BeNil())
+
+			delete(policies.Events, "Criterium")
+			policies.Events[""] = dashboard_controller.SnapshotPolicy{}
+			Expect(policies.Validate()).ToNot(BeNil())
+		})
+	})
+
+	Describe("Selecting the policy of the event", func() {
+		Specify("The event policy falls back to the default one", func() {
+			policies := dashboard_controller.SnapshotPolicies{
+				Default: dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotOnCourse, Size: 5},
+				Events: map[string]dashboard_controller.SnapshotPolicy{
+					"City Marathon": {Mode: dashboard_controller.SnapshotLeaderboard},
+					"Criterium":     {Size: 20},
+				},
+			}
+
+			Expect(policies.For("City Marathon")).To(Equal(dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotLeaderboard, Size: 5}))
+			Expect(policies.For("Criterium")).To(Equal(dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotOnCourse, Size: 20}))
+			Expect(policies.For("Fun Run")).To(Equal(policies.Default))
+			Expect(policies.For("")).To(Equal(policies.Default))
+			Expect(dashboard_controller.SnapshotPolicies{}.For("")).To(Equal(dashboard_controller.SnapshotPolicy{
+				Mode: dashboard_controller.SnapshotLast,
+				Size: dashboard_controller.DefaultSnapshotSize,
+			}))
+		})
+	})
+
+	Describe("Last results", func() {
+		When("More results are added than the size", func() {
+			Specify("Only the latest results are kept with the latest result first", func() {
+				snapshot := dashboard_controller.NewSnapshot(dashboard_controller.SnapshotPolicy{Size: 2}, nil)
+				Expect(snapshot.Results()).To(BeNil())
+				Expect(snapshot.Policy()).To(Equal(dashboard_controller.SnapshotPolicy{
+					Mode: dashboard_controller.SnapshotLast,
+					Size: 2,
+				}))
+
+				first := started("1", 101, "", 1)
+				snapshot.Add(first)
+				snapshot.Add(started("3", 103, "", 3))
+				snapshot.Add(started("2", 102, "", 2))
+				Expect(ids(snapshot.Results())).To(Equal([]string{"3", "2"}))
+
+				// Finish of the result not in the snapshot anymore does not bring it back.
+				snapshot.Finish(finished(first, 10))
+				Expect(ids(snapshot.Results())).To(Equal([]string{"3", "2"}))
+
+				snapshot.Finish(finished(started("2", 102, "", 2), 12))
+				results := snapshot.Results()
+				Expect(ids(results)).To(Equal([]string{"3", "2"}))
+				Expect(*results[1].TimeFinish).To(Equal(int64(12)))
+			})
+		})
+	})
+
+	Describe("Results on course", func() {
+		Specify("Finished results are removed", func() {
+			first := started("1", 101, "", 1)
+			snapshot := dashboard_controller.NewSnapshot(
+				dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotOnCourse, Size: 5},
+				[]dashboard_controller.ResultMessage{first, started("2", 102, "", 2)},
+			)
+			Expect(ids(snapshot.Results())).To(Equal([]string{"2", "1"}))
+
+			snapshot.Finish(finished(first, 10))
+			Expect(ids(snapshot.Results())).To(Equal([]string{"2"}))
+		})
+	})
+
+	Describe("Leaderboard", func() {
+		Specify("Only finished results are kept ordered by the best time", func() {
+			snapshot := dashboard_controller.NewSnapshot(
+				dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotLeaderboard, Size: 2},
+				nil,
+			)
+
+			slow := started("1", 101, "", 1)
+			fast := started("2", 102, "", 2)
+			average := started("3", 103, "", 3)
+
+			snapshot.Add(slow)
+			Expect(snapshot.Results()).To(BeNil())
+
+			snapshot.Finish(finished(slow, 100))
+			snapshot.Finish(finished(fast, 10))
+			snapshot.Finish(finished(average, 50))
+			Expect(ids(snapshot.Results())).To(Equal([]string{"2", "3"}))
+		})
+
+		Specify("Results of the circuit race are ordered by the distance and the laps before the time", func() {
+			snapshot := dashboard_controller.NewSnapshot(
+				dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotLeaderboard, Size: 3},
+				nil,
+			)
+
+			lap := func(result dashboard_controller.ResultMessage, laps uint32, distance, lastPassing int64) dashboard_controller.LapMessage {
+				return dashboard_controller.LapMessage{
+					Type:        dashboard_controller.EventLap,
+					ID:          result.ID,
+					TimeStart:   result.TimeStart,
+					Number:      laps,
+					Laps:        laps,
+					Distance:    distance,
+					LastPassing: lastPassing,
+				}
+			}
+
+			short := started("1", 101, "", 1)
+			long := started("2", 102, "", 1)
+			quick := started("3", 103, "", 1)
+
+			snapshot.Finish(finished(short, 50))
+			snapshot.Finish(finished(long, 100))
+			snapshot.Finish(finished(quick, 60))
+
+			snapshot.Lap(lap(short, 2, 2000, 50))
+			snapshot.Lap(lap(long, 3, 3000, 100))
+			snapshot.Lap(lap(quick, 3, 3000, 60))
+			Expect(ids(snapshot.Results())).To(Equal([]string{"3", "2", "1"}))
+
+			results := snapshot.Results()
+			Expect(results[0].Laps).To(Equal(uint32(3)))
+			Expect(results[0].Distance).To(Equal(int64(3000)))
+			Expect(*results[0].LastPassing).To(Equal(int64(60)))
+
+			// Lap of the result not in the snapshot is ignored.
+			snapshot.Lap(lap(started("4", 104, "", 1), 5, 5000, 70))
+			Expect(ids(snapshot.Results())).To(Equal([]string{"3", "2", "1"}))
+		})
+
+		Specify("Finish keeps the progress of the circuit race result", func() {
+			snapshot := dashboard_controller.NewSnapshot(dashboard_controller.SnapshotPolicy{Size: 2}, nil)
+
+			result := started("1", 101, "", 1)
+			snapshot.Add(result)
+			snapshot.Lap(dashboard_controller.LapMessage{ID: "1", Number: 1, Laps: 1, Distance: 1000, LastPassing: 40})
+			snapshot.Finish(finished(result, 40))
+
+			results := snapshot.Results()
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Laps).To(Equal(uint32(1)))
+			Expect(results[0].Distance).To(Equal(int64(1000)))
+			Expect(*results[0].TimeFinish).To(Equal(int64(40)))
+		})
+	})
+
+	Describe("Last results per category", func() {
+		Specify("The size is applied to every category", func() {
+			snapshot := dashboard_controller.NewSnapshot(
+				dashboard_controller.SnapshotPolicy{Mode: dashboard_controller.SnapshotCategory, Size: 1},
+				[]dashboard_controller.ResultMessage{
+					started("1", 101, "M", 1),
+					started("2", 102, "W", 2),
+				},
+			)
+
+			snapshot.Add(started("3", 103, "M", 3))
+			Expect(ids(snapshot.Results())).To(Equal([]string{"3", "2"}))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package dashboard_controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Subscription narrows down the messages a dashboard client receives.
+// Empty subscription matches every message.
+type Subscription struct {
+	StartNumbers map[uint32]bool
+	Event        string
+}
+
+// ParseSubscription reads the subscription filter from the request query,
+// start numbers are accepted both as repeated and comma separated values, e.g.
+// /dashboard?start_number=101&start_number=102 or /dashboard?start_number=101,102 ,
+// the event narrows down the results and the announcements, e.g. /dashboard?event=City%20Marathon .
+func ParseSubscription(r *http.Request) (Subscription, error) {
+	subscription := Subscription{
+		Event: strings.TrimSpace(r.URL.Query().Get("event")),
+	}
+
+	for _, values := range r.URL.Query()["start_number"] {
+		for _, value := range strings.Split(values, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+
+			startNumber, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return Subscription{}, fmt.Errorf("start_number: invalid value %q", value)
+			}
+
+			if subscription.StartNumbers == nil {
+				subscription.StartNumbers = make(map[uint32]bool)
+			}
+			subscription.StartNumbers[uint32(startNumber)] = true
+		}
+	}
+
+	return subscription, nil
+}
+
+// Matches reports whether the message about the given sportsmen should be delivered.
+func (s Subscription) Matches(startNumber uint32) bool {
+	if len(s.StartNumbers) == 0 {
+		return true
+	}
+
+	return s.StartNumbers[startNumber]
+}
+
+// MatchesAny reports whether the message about the given sportsmen, e.g. the members of the team, should be delivered.
+func (s Subscription) MatchesAny(startNumbers []uint32) bool {
+	if len(s.StartNumbers) == 0 {
+		return true
+	}
+
+	for _, startNumber := range startNumbers {
+		if s.StartNumbers[startNumber] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesEvent reports whether the announcement targeting the given event should be delivered,
+// announcements without event target all the clients.
+func (s Subscription) MatchesEvent(event string) bool {
+	return event == "" || s.Event == "" || s.Event == event
+}
+
+// MatchesResultEvent reports whether the result recorded at the checkpoint of the given event should be delivered,
+// the clients not subscribed to any event receive the results of every event.
+func (s Subscription) MatchesResultEvent(event string) bool {
+	return s.Event == "" || s.Event == event
+}
+
+// Filter returns the results matching the subscription, nil is returned when nothing matches
+// so that the client receives the same "null" as on the empty dashboard.
+func (s Subscription) Filter(results []ResultMessage) []ResultMessage {
+	if len(s.StartNumbers) == 0 && s.Event == "" {
+		return results
+	}
+
+	var filtered []ResultMessage
+	for _, result := range results {
+		if s.MatchesResultEvent(result.Event) && s.Matches(result.SportsmenStartNumber) {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,56 @@
+package dashboard_controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http/httptest"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+)
+
+var _ = Describe("Dashboard subscription", func() {
+	results := []dashboard_controller.ResultMessage{
+		{ID: "1", SportsmenStartNumber: 101, Event: "City Marathon", TimeStart: 4},
+		{ID: "2", SportsmenStartNumber: 201, Event: "Night Run", TimeStart: 3},
+		{ID: "3", SportsmenStartNumber: 102, Event: "City Marathon", TimeStart: 2},
+		{ID: "4", SportsmenStartNumber: 202, Event: "Night Run", TimeStart: 1},
+	}
+
+	idsOf := func(results []dashboard_controller.ResultMessage) []string {
+		var ids []string
+		for _, result := range results {
+			ids = append(ids, result.ID)
+		}
+
+		return ids
+	}
+
+	parse := func(query string) dashboard_controller.Subscription {
+		subscription, err := dashboard_controller.ParseSubscription(httptest.NewRequest("GET", "/dashboard"+query, nil))
+		Expect(err).To(BeNil())
+
+		return subscription
+	}
+
+	Specify("the results of the subscribed event kept only", func() {
+		Expect(idsOf(parse("?event=City%20Marathon").Filter(results))).To(Equal([]string{"1", "3"}))
+		Expect(idsOf(parse("?event=Night%20Run").Filter(results))).To(Equal([]string{"2", "4"}))
+	})
+
+	Specify("the results of every event kept without the event", func() {
+		Expect(idsOf(parse("").Filter(results))).To(Equal([]string{"1", "2", "3", "4"}))
+	})
+
+	Specify("the start numbers narrow down the results of the event", func() {
+		Expect(idsOf(parse("?event=Night%20Run&start_number=101,202").Filter(results))).To(Equal([]string{"4"}))
+		Expect(parse("?event=Night%20Run&start_number=101").Filter(results)).To(BeNil())
+	})
+
+	Specify("the results of the other event not delivered", func() {
+		subscription := parse("?event=City%20Marathon")
+
+		Expect(subscription.MatchesResultEvent("City Marathon")).To(BeTrue())
+		Expect(subscription.MatchesResultEvent("Night Run")).To(BeFalse())
+		Expect(subscription.MatchesResultEvent("")).To(BeFalse())
+		Expect(parse("").MatchesResultEvent("Night Run")).To(BeTrue())
+	})
+})
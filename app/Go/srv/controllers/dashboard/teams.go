@@ -0,0 +1,91 @@
+package dashboard_controller
+
+import (
+	"sort"
+	"sports/backend/domain/models/team"
+)
+
+// TeamRankings keeps the relay teams bounded and ranked for the recently joined clients,
+// the team finishing more legs comes first, the less total time breaks the tie.
+type TeamRankings struct {
+	size  int
+	teams []TeamMessage
+}
+
+// NewTeamRankings creates the rankings of the given size from the teams loaded from the database.
+func NewTeamRankings(size int, teams []TeamMessage) *TeamRankings {
+	t := &TeamRankings{size: size}
+
+	for _, team := range teams {
+		t.Update(team)
+	}
+
+	return t
+}
+
+// Update replaces the team with its latest standing, the team is added when it is not ranked yet.
+func (t *TeamRankings) Update(team TeamMessage) {
+	for index, ranked := range t.teams {
+		if ranked.ID == team.ID {
+			t.teams = append(t.teams[:index], t.teams[index+1:]...)
+			break
+		}
+	}
+
+	t.teams = append(t.teams, team)
+	sort.SliceStable(t.teams, func(i, j int) bool {
+		return teamLess(t.teams[i], t.teams[j])
+	})
+
+	if t.size > 0 && len(t.teams) > t.size {
+		t.teams = t.teams[:t.size]
+	}
+}
+
+// Teams returns the copy of the ranked teams, nil is returned when there are no teams.
+func (t *TeamRankings) Teams() []TeamMessage {
+	if len(t.teams) == 0 {
+		return nil
+	}
+
+	teams := make([]TeamMessage, len(t.teams))
+	copy(teams, t.teams)
+
+	return teams
+}
+
+// teamLess reports whether the team a is ranked above the team b.
+func teamLess(a, b TeamMessage) bool {
+	if a.LegsFinished != b.LegsFinished {
+		return a.LegsFinished > b.LegsFinished
+	}
+	if a.TotalTime != b.TotalTime {
+		return a.TotalTime < b.TotalTime
+	}
+
+	return a.Name < b.Name
+}
+
+// newTeamMessage converts the team standing into the dashboard message, the start numbers are taken
+// from the leg results of the team.
+func newTeamMessage(standing team.Standing, legResults []team.LegResult) TeamMessage {
+	msg := TeamMessage{
+		Type:         EventTeam,
+		ID:           standing.TeamID.String(),
+		Name:         standing.Name,
+		Category:     standing.Category,
+		Legs:         standing.Legs,
+		LegsFinished: standing.LegsFinished,
+		TotalTime:    standing.TotalTime,
+		TimeStart:    standing.TimeStart,
+		TimeFinish:   standing.TimeFinish,
+	}
+
+	for _, legResult := range legResults {
+		if legResult.TeamID == standing.TeamID {
+			msg.StartNumbers = append(msg.StartNumbers, legResult.StartNumber)
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,59 @@
+package dashboard_controller_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+)
+
+var _ = Describe("Dashboard team rankings", func() {
+	team := func(id, name string, legsFinished uint32, totalTime int64) dashboard_controller.TeamMessage {
+		return dashboard_controller.TeamMessage{
+			Type:         dashboard_controller.EventTeam,
+			ID:           id,
+			Name:         name,
+			Legs:         3,
+			LegsFinished: legsFinished,
+			TotalTime:    totalTime,
+		}
+	}
+
+	ids := func(teams []dashboard_controller.TeamMessage) []string {
+		var ids []string
+		for _, team := range teams {
+			ids = append(ids, team.ID)
+		}
+		return ids
+	}
+
+	Specify("Teams finishing more legs come first and the less total time breaks the tie", func() {
+		rankings := dashboard_controller.NewTeamRankings(10, []dashboard_controller.TeamMessage{
+			team("1", "Harriers", 1, 600),
+			team("2", "Joggers", 2, 1300),
+			team("3", "Athletes", 1, 600),
+		})
+		Expect(ids(rankings.Teams())).To(Equal([]string{"2", "3", "1"}))
+
+		// The team is moved up by its latest standing after the handover.
+		rankings.Update(team("1", "Harriers", 2, 1200))
+		Expect(ids(rankings.Teams())).To(Equal([]string{"1", "2", "3"}))
+		Expect(rankings.Teams()[0].TotalTime).To(Equal(int64(1200)))
+	})
+
+	Specify("Only the best teams of the size are kept", func() {
+		rankings := dashboard_controller.NewTeamRankings(2, nil)
+		Expect(rankings.Teams()).To(BeNil())
+
+		rankings.Update(team("1", "Harriers", 1, 600))
+		rankings.Update(team("2", "Joggers", 1, 500))
+		rankings.Update(team("3", "Athletes", 1, 700))
+		Expect(ids(rankings.Teams())).To(Equal([]string{"2", "1"}))
+	})
+
+	Specify("Team messages are delivered to the clients subscribed to any of the members", func() {
+		subscription := dashboard_controller.Subscription{StartNumbers: map[uint32]bool{102: true}}
+		Expect(subscription.MatchesAny([]uint32{101, 102})).To(BeTrue())
+		Expect(subscription.MatchesAny([]uint32{101, 103})).To(BeFalse())
+		Expect(dashboard_controller.Subscription{}.MatchesAny(nil)).To(BeTrue())
+	})
+})
@@ -4,20 +4,87 @@ type ResultMessage struct {
 	ID                   string `json:"id"`
 	SportsmenStartNumber uint32 `json:"start_number"`
 	SportsmenName        string `json:"name"`
+	Category             string `json:"category,omitempty"`
 	TimeStart            int64  `json:"time_start"`
 	TimeFinish           *int64 `json:"time_finish"`
+
+	// Event is the event of the checkpoint the result is recorded at.
+	Event string `json:"event,omitempty"`
+
+	// Laps, Distance and LastPassing are the progress of the circuit race result.
+	Laps        uint32 `json:"laps,omitempty"`
+	Distance    int64  `json:"distance,omitempty"`
+	LastPassing *int64 `json:"last_passing,omitempty"`
 }
 
 type UnfinishedResultMessage struct {
 	ID                   string `json:"id"`
 	SportsmenStartNumber uint32 `json:"start_number"`
 	SportsmenName        string `json:"name"`
+	Category             string `json:"category,omitempty"`
 	TimeStart            int64  `json:"time_start"`
+	Event                string `json:"event,omitempty"`
 }
 
 type FinishedResultMessage struct {
 	ID                   string `json:"id"`
 	SportsmenStartNumber uint32 `json:"start_number"`
 	SportsmenName        string `json:"name"`
+	Category             string `json:"category,omitempty"`
+	TimeStart            int64  `json:"time_start"`
 	TimeFinish           int64  `json:"time_finish"`
+	Event                string `json:"event,omitempty"`
+}
+
+// LapMessage is the passing of the circuit race checkpoint, Type is EventLap, ID is the id of the result,
+// Laps, Distance and LastPassing are the progress of the result after the lap.
+type LapMessage struct {
+	Type                 string `json:"type"`
+	ID                   string `json:"id"`
+	SportsmenStartNumber uint32 `json:"start_number"`
+	SportsmenName        string `json:"name"`
+	Category             string `json:"category,omitempty"`
+	TimeStart            int64  `json:"time_start"`
+	Number               uint32 `json:"number"`
+	LapTime              int64  `json:"lap_time"`
+	Partial              bool   `json:"partial,omitempty"`
+	Laps                 uint32 `json:"laps"`
+	Distance             int64  `json:"distance"`
+	LastPassing          int64  `json:"last_passing"`
+	Event                string `json:"event,omitempty"`
+}
+
+// AnnouncementMessage is a race control announcement, Type tells it from the results
+// for the WebSocket clients and is either EventAnnouncement or EventAnnouncementRetracted.
+type AnnouncementMessage struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Message   string `json:"message,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Event     string `json:"event,omitempty"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+}
+
+// StatusMessage tells the clients about the state of the dashboard server, Type is EventStatus,
+// e.g. the StatusRestarting notice asking to reconnect in ReconnectIn seconds.
+type StatusMessage struct {
+	Type        string `json:"type"`
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	ReconnectIn int64  `json:"reconnect_in"`
+}
+
+// TeamMessage is the relay team standing after the handover, Type is EventTeam, StartNumbers are
+// the members in the order of the legs, the total time is the sum of the finished legs.
+type TeamMessage struct {
+	Type         string   `json:"type"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Category     string   `json:"category,omitempty"`
+	Legs         uint32   `json:"legs"`
+	LegsFinished uint32   `json:"legs_finished"`
+	TotalTime    int64    `json:"total_time"`
+	TimeStart    *int64   `json:"time_start"`
+	TimeFinish   *int64   `json:"time_finish"`
+	StartNumbers []uint32 `json:"start_numbers"`
 }
@@ -0,0 +1,48 @@
+package dashboard_controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"sports/backend/srv/controllers/dashboard/messages"
+)
+
+// Watch passes the dashboard messages matching the subscription to send as the protobuf frames until
+// the context is done, e.g. to the gRPC streams. Like the Server-Sent Events clients, slow watchers lose
+// messages and are expected to watch again from the ID of the last received frame. The watch ends
+// with the restart status frame when the hub stops, ErrStopped is returned once it has stopped.
+func (d *Dashboard) Watch(ctx context.Context, subscription Subscription, lastEventID *uint64, send func(*dashboard_messages.DashboardMessage) error) error {
+	conn := &Connection{
+		Name:         fmt.Sprintf("anon-%s", uuid.Must(uuid.NewV4())),
+		Protobuf:     true,
+		Stream:       newEventStream(),
+		Subscription: subscription,
+		LastEventID:  lastEventID,
+		Global:       d,
+	}
+
+	if err := d.join(conn); err != nil {
+		return err
+	}
+	defer d.leave(conn)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-conn.Stream.events:
+			if !ok {
+				return nil
+			}
+
+			frame := &dashboard_messages.DashboardMessage{}
+			if err := frame.Unmarshal(event.Data); err != nil {
+				return err
+			}
+
+			if err := send(frame); err != nil {
+				return err
+			}
+		}
+	}
+}
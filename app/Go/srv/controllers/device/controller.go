@@ -0,0 +1,211 @@
+package device_controller
+
+import (
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/device"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"sports/backend/srv/utils"
+)
+
+// RegisterDevice handles the new device request, the one-time enrollment code is returned only once.
+func RegisterDevice(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := NewDeviceRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		code, codeHash, err := auth.GenerateEnrollmentCode()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newDevice := device.PendingDevice{
+			ID:                  uuid.Must(uuid.NewV4()),
+			Name:                req.Name,
+			Event:               req.Event,
+			CheckpointID:        uuid.Must(uuid.FromString(req.CheckpointID)),
+			EnrollmentCodeHash:  codeHash,
+			EnrollmentExpiresAt: utils.MakeTimestampInMilliseconds() + device.EnrollmentCodeTTL.Milliseconds(),
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "device.Register")
+		deviceRegisteredEvent, err := device.Register(db, newDevice)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, deviceRegisteredEvent.Version)
+		responses.JSON(w, http.StatusOK, DeviceRegisteredResponse{
+			ID:                  deviceRegisteredEvent.DeviceID,
+			EnrollmentCode:      code,
+			EnrollmentExpiresAt: deviceRegisteredEvent.EnrollmentExpiresAt,
+		})
+	}
+}
+
+// EnrollDevice handles the device enrollment request, the device exchanges the enrollment code
+// for the API key of the timekeeper credential bound to its checkpoint.
+func EnrollDevice(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := EnrollRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "device.GetDeviceByEnrollmentCodeHash")
+		deviceFetched, err := device.GetDeviceByEnrollmentCodeHash(db, auth.HashEnrollmentCode(req.EnrollmentCode))
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		key, keyHash, err := auth.GenerateAPIKey()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "device.Enroll")
+		_, err = device.Enroll(db, utils.MakeTimestampInMilliseconds(), keyHash, *deviceFetched)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, DeviceEnrolledResponse{
+			DeviceID:     deviceFetched.ID.String(),
+			Event:        deviceFetched.Event,
+			CheckpointID: deviceFetched.CheckpointID.String(),
+			APIKey:       key,
+		})
+	}
+}
+
+// RevokeDevice handles the device revocation request, the device credential stops working immediately.
+func RevokeDevice(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deviceID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "device.GetDevice")
+		deviceFetched, err := device.GetDevice(db, deviceID, version)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "device.Revoke")
+		deviceRevokedEvent, err := device.Revoke(db, utils.MakeTimestampInMilliseconds(), *deviceFetched)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, deviceRevokedEvent.Version)
+		responses.JSON(w, http.StatusOK, nil)
+	}
+}
+
+// GetDevice handles the device request.
+func GetDevice(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deviceID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "device.GetDevice")
+		deviceFetched, err := device.GetDevice(db, deviceID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, deviceFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, deviceFetched)
+	}
+}
+
+// GetDevices handles the device registry request.
+func GetDevices(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "device.GetDevices")
+		devices, err := device.GetDevices(db)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, devices)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, devices)
+	}
+}
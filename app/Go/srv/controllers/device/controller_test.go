@@ -0,0 +1,242 @@
+package device_controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/device"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/cmd/config"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	result_controller "sports/backend/srv/controllers/result"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/middleware"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Devices controller", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	// Set up the dashboard Websocket API module
+	dashboard := &dashboard_controller.Dashboard{
+		ConnHub: make(map[string]*dashboard_controller.Connection),
+		Results: make(chan dashboard_controller.UnfinishedResultMessage),
+		Finish:  make(chan dashboard_controller.FinishedResultMessage),
+		Join:    make(chan *dashboard_controller.Connection),
+		Leave:   make(chan *dashboard_controller.Connection),
+	}
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+	srv.Dashboard = dashboard
+	srv.Dispatcher = dispatcher.NewDispatcher()
+	srv.Dashboard.Subscribe(srv.Dispatcher)
+
+	go srv.Dashboard.Run(context.Background(), srv.DB)
+
+	var pendingCheckpoint1 checkpoint.PendingCheckpoint
+	var pendingCheckpoint2 checkpoint.PendingCheckpoint
+	var pendingSportsmen sportsmen.PendingSportsmen
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+
+		pendingCheckpoint1 = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Start",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint1)
+		Expect(err).To(BeNil())
+
+		pendingCheckpoint2 = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "10 km",
+		}
+
+		_, err = checkpoint.Create(*db, pendingCheckpoint2)
+		Expect(err).To(BeNil())
+
+		pendingSportsmen = sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			StartNumber: 101,
+		}
+
+		_, err = sportsmen.Create(*db, pendingSportsmen)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	// enroll registers the device for the checkpoint and returns its API key.
+	enroll := func(checkpointID uuid.UUID) (DeviceRegisteredResponse, DeviceEnrolledResponse) {
+		requestBody, err := json.Marshal(NewDeviceRequest{
+			Name:         "Laptop",
+			Event:        "City Marathon",
+			CheckpointID: checkpointID.String(),
+		})
+		Expect(err).To(BeNil())
+
+		req, err := http.NewRequest("POST", "/devices", bytes.NewBuffer(requestBody))
+		Expect(err).To(BeNil())
+
+		rr := httptest.NewRecorder()
+		RegisterDevice(&srv).ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		registered := DeviceRegisteredResponse{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &registered)).To(BeNil())
+
+		requestBody, err = json.Marshal(EnrollRequest{EnrollmentCode: registered.EnrollmentCode})
+		Expect(err).To(BeNil())
+
+		req, err = http.NewRequest("POST", "/devices/enroll", bytes.NewBuffer(requestBody))
+		Expect(err).To(BeNil())
+
+		rr = httptest.NewRecorder()
+		EnrollDevice(&srv).ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		enrolled := DeviceEnrolledResponse{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &enrolled)).To(BeNil())
+
+		return registered, enrolled
+	}
+
+	// submitStart sends the start time authenticated with the device API key.
+	submitStart := func(apiKey string, checkpointID uuid.UUID) int {
+		requestBody, err := json.Marshal(result_controller.NewResultRequest{
+			CheckpointID: checkpointID.String(),
+			SportsmenID:  pendingSportsmen.ID.String(),
+			Time:         utils.MakeTimestampInMilliseconds(),
+		})
+		Expect(err).To(BeNil())
+
+		req, err := http.NewRequest("POST", "/results", bytes.NewBuffer(requestBody))
+		Expect(err).To(BeNil())
+		req.Header.Set("X-API-Key", apiKey)
+
+		rr := httptest.NewRecorder()
+		handler := middleware.SetMiddlewareAuth(&srv, result_controller.AddResult(&srv), "timekeeper")
+		handler.ServeHTTP(rr, req)
+
+		return rr.Code
+	}
+
+	Describe("Enrolling a device", func() {
+		When("the enrollment code is valid", func() {
+			Specify("the device receives its API key", func() {
+				registered, enrolled := enroll(pendingCheckpoint1.ID)
+
+				Expect(enrolled.DeviceID).To(Equal(registered.ID))
+				Expect(enrolled.CheckpointID).To(Equal(pendingCheckpoint1.ID.String()))
+				Expect(enrolled.Event).To(Equal("City Marathon"))
+				Expect(enrolled.APIKey).ToNot(BeEmpty())
+			})
+		})
+
+		When("the enrollment code is used again", func() {
+			Specify("the response returned", func() {
+				registered, _ := enroll(pendingCheckpoint1.ID)
+
+				requestBody, err := json.Marshal(EnrollRequest{EnrollmentCode: registered.EnrollmentCode})
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/devices/enroll", bytes.NewBuffer(requestBody))
+				Expect(err).To(BeNil())
+
+				rr := httptest.NewRecorder()
+				EnrollDevice(&srv).ServeHTTP(rr, req)
+
+				responseMap := make(map[string]interface{})
+				Expect(json.Unmarshal(rr.Body.Bytes(), &responseMap)).To(BeNil())
+
+				Expect(rr.Code).To(Equal(http.StatusConflict))
+				Expect(responseMap["detail"]).To(Equal("Device has been enrolled already"))
+				Expect(responseMap["code"]).To(Equal("device_already_enrolled"))
+			})
+		})
+	})
+
+	Describe("Submitting times by the device", func() {
+		When("the time of the device checkpoint is submitted", func() {
+			Specify("the result records the device", func() {
+				_, enrolled := enroll(pendingCheckpoint1.ID)
+
+				Expect(submitStart(enrolled.APIKey, pendingCheckpoint1.ID)).To(Equal(http.StatusOK))
+
+				submitted := result.Result{}
+				err := db.Where("checkpoint_id = ? AND sportsmen_id = ?", pendingCheckpoint1.ID, pendingSportsmen.ID).Take(&submitted).Error
+				Expect(err).To(BeNil())
+				Expect(submitted.DeviceID.String()).To(Equal(enrolled.DeviceID))
+			})
+		})
+
+		When("the time of another checkpoint is submitted", func() {
+			Specify("the request is forbidden", func() {
+				_, enrolled := enroll(pendingCheckpoint1.ID)
+
+				Expect(submitStart(enrolled.APIKey, pendingCheckpoint2.ID)).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		When("the device has been revoked", func() {
+			Specify("the request is unauthorized", func() {
+				_, enrolled := enroll(pendingCheckpoint1.ID)
+
+				req, err := http.NewRequest("DELETE", "/devices/"+enrolled.DeviceID, nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": enrolled.DeviceID})
+
+				deviceFetched, err := device.GetDevice(*db, uuid.Must(uuid.FromString(enrolled.DeviceID)), nil)
+				Expect(err).To(BeNil())
+				req.Header.Set("If-Match", etag.Format(deviceFetched.Version))
+
+				rr := httptest.NewRecorder()
+				RevokeDevice(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				Expect(submitStart(enrolled.APIKey, pendingCheckpoint1.ID)).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
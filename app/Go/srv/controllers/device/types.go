@@ -0,0 +1,42 @@
+package device_controller
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+type NewDeviceRequest struct {
+	Name         string `json:"name"`
+	Event        string `json:"event"`
+	CheckpointID string `json:"checkpoint_id"`
+}
+
+func (req NewDeviceRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required),
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+	)
+}
+
+type DeviceRegisteredResponse struct {
+	ID                  string `json:"id"`
+	EnrollmentCode      string `json:"enrollment_code"`
+	EnrollmentExpiresAt int64  `json:"enrollment_expires_at"`
+}
+
+type EnrollRequest struct {
+	EnrollmentCode string `json:"enrollment_code"`
+}
+
+func (req EnrollRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.EnrollmentCode, validation.Required),
+	)
+}
+
+type DeviceEnrolledResponse struct {
+	DeviceID     string `json:"device_id"`
+	Event        string `json:"event"`
+	CheckpointID string `json:"checkpoint_id"`
+	APIKey       string `json:"api_key"`
+}
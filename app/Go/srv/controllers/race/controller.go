@@ -0,0 +1,257 @@
+package race_controller
+
+import (
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/result"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+)
+
+// Maximum number of the results in the standings.
+const standingsLimit = 1000
+
+// AddRace handles the new race request.
+func AddRace(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := NewRaceRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newRace := race.PendingRace{
+			ID:           uuid.Must(uuid.NewV4()),
+			Name:         req.Name,
+			CheckpointID: uuid.Must(uuid.FromString(req.CheckpointID)),
+			Format:       req.Format,
+			TargetLaps:   req.TargetLaps,
+			TimeLimit:    req.TimeLimit,
+			MinLapTime:   req.MinLapTime,
+			StartTime:    req.StartTime,
+			LapDistance:  req.LapDistance,
+			PartialLap:   req.PartialLap,
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "race.Create")
+		raceCreatedEvent, err := race.Create(db, newRace)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, raceCreatedEvent.Version)
+		responses.JSON(w, http.StatusOK, CreatedResponse{ID: raceCreatedEvent.RaceID})
+	}
+}
+
+// GetRace handles the race request.
+func GetRace(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raceID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "race.GetRace")
+		raceFetched, err := race.GetRace(db, raceID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, raceFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, raceFetched)
+	}
+}
+
+// GetRaces handles the races request.
+func GetRaces(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "race.GetRaces")
+		races, err := race.GetRaces(db)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, races)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, races)
+	}
+}
+
+// GetStandings handles the standings request of the race, the longest distance and the most laps completed come first.
+func GetStandings(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		raceID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "race.GetRace")
+		_, err = race.GetRace(db, raceID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "lap.GetStandings")
+		standings, err := lap.GetStandings(db, raceID, standingsLimit)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, standings)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, standings)
+	}
+}
+
+// AddLap handles the passing of the race checkpoint, the passing completing the race finishes the result.
+func AddLap(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := NewLapRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		checkpointID := uuid.Must(uuid.FromString(req.CheckpointID))
+
+		identity, err := auth.RequireCheckpoint(r.Context(), checkpointID)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newLap := lap.PendingLap{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: checkpointID,
+			SportsmenID:  uuid.Must(uuid.FromString(req.SportsmenID)),
+			Time:         req.Time,
+			DeviceID:     identity.DeviceID,
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "lap.Record")
+		lapRecordedEvent, err := lap.Record(db, newLap)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
+
+		etag.SetVersion(w, lapRecordedEvent.Version)
+		responses.JSON(w, http.StatusOK, LapResponse{
+			ID:       lapRecordedEvent.LapID,
+			Number:   lapRecordedEvent.Number,
+			LapTime:  lapRecordedEvent.LapTime,
+			Distance: lapRecordedEvent.Distance,
+			Partial:  lapRecordedEvent.Partial,
+			Finished: lapRecordedEvent.Finished,
+		})
+	}
+}
+
+// GetLaps handles the laps request of the result, the first lap comes first.
+func GetLaps(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resultID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "result.GetResult")
+		_, err = result.GetResult(db, resultID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "lap.GetLaps")
+		laps, err := lap.GetLaps(db, resultID)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, laps)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, laps)
+	}
+}
@@ -0,0 +1,301 @@
+package race_controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+)
+
+// asAdmin authenticates the request as the admin the way the auth middleware of the routes does.
+func asAdmin(req *http.Request) *http.Request {
+	return req.WithContext(auth.WithIdentity(req.Context(), auth.Identity{Role: credential.RoleAdmin}))
+}
+
+var _ = Describe("Races controller", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+	srv.Dispatcher = dispatcher.NewDispatcher()
+
+	var pendingCheckpoint checkpoint.PendingCheckpoint
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+
+		pendingCheckpoint = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Start/finish line",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Creating new race", func() {
+		When("New race request is sent", func() {
+			Specify("The response returned", func() {
+				samples := []struct {
+					request      NewRaceRequest
+					statusCode   int
+					errorMessage string
+				}{
+					{
+						request:      NewRaceRequest{Name: "Criterium", CheckpointID: pendingCheckpoint.ID.String(), Format: race.FormatLaps, TargetLaps: 20},
+						statusCode:   http.StatusOK,
+						errorMessage: "",
+					},
+					{
+						request:      NewRaceRequest{Name: "Criterium", CheckpointID: pendingCheckpoint.ID.String(), Format: race.FormatLaps, TimeLimit: 3600000},
+						statusCode:   http.StatusConflict,
+						errorMessage: "Race of the checkpoint already exists",
+					},
+					{
+						request:      NewRaceRequest{CheckpointID: pendingCheckpoint.ID.String(), Format: race.FormatLaps, TargetLaps: 20},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "name: cannot be blank.",
+					},
+					{
+						request:      NewRaceRequest{Name: "Criterium", CheckpointID: pendingCheckpoint.ID.String(), Format: "relay", TargetLaps: 20},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "format: must be a valid value.",
+					},
+					{
+						request:      NewRaceRequest{Name: "Criterium", CheckpointID: pendingCheckpoint.ID.String(), Format: race.FormatLaps},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "target_laps: either target laps or time limit is required.",
+					},
+					{
+						request:      NewRaceRequest{Name: "6 hours", CheckpointID: pendingCheckpoint.ID.String(), Format: race.FormatTimed, TimeLimit: 21600000},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "start_time: cannot be blank.",
+					},
+					{
+						request:      NewRaceRequest{Name: "Criterium", CheckpointID: pendingCheckpoint.ID.String(), Format: race.FormatLaps, TargetLaps: 20, PartialLap: race.PartialLapDiscard},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "partial_lap: must be a valid value.",
+					},
+				}
+
+				for _, s := range samples {
+					requestBody, err := json.Marshal(s.request)
+					Expect(err).To(gomega.BeNil())
+
+					req, err := http.NewRequest("POST", "/races", bytes.NewBufferString(string(requestBody)))
+					Expect(err).To(gomega.BeNil())
+					req = asAdmin(req)
+
+					rr := httptest.NewRecorder()
+					handler := AddRace(&srv)
+					handler.ServeHTTP(rr, req)
+
+					responseMap := make(map[string]interface{})
+
+					err = json.Unmarshal([]byte(rr.Body.String()), &responseMap)
+					Expect(err).To(gomega.BeNil())
+
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code == 200 {
+						Expect(responseMap["id"]).ToNot(Equal(""))
+					}
+
+					if rr.Code != 200 {
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+	})
+
+	Describe("Recording laps", func() {
+		var raceID uuid.UUID
+		var pendingSportsmen sportsmen.PendingSportsmen
+		timeStart := utils.MakeTimestampInMilliseconds()
+
+		BeforeEach(func() {
+			raceID = uuid.Must(uuid.NewV4())
+			_, err := race.Create(*db, race.PendingRace{
+				ID:           raceID,
+				Name:         "Criterium",
+				CheckpointID: pendingCheckpoint.ID,
+				Format:       race.FormatLaps,
+				TargetLaps:   2,
+				MinLapTime:   60000,
+			})
+			Expect(err).To(BeNil())
+
+			pendingSportsmen = sportsmen.PendingSportsmen{
+				ID:          uuid.Must(uuid.NewV4()),
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+				StartNumber: 101,
+			}
+
+			_, err = sportsmen.Create(*db, pendingSportsmen)
+			Expect(err).To(BeNil())
+
+			_, err = result.Create(*db, result.PendingResult{
+				ID:           uuid.Must(uuid.NewV4()),
+				CheckpointID: pendingCheckpoint.ID,
+				SportsmenID:  pendingSportsmen.ID,
+				TimeStart:    timeStart,
+			})
+			Expect(err).To(BeNil())
+		})
+
+		When("New lap request is sent", func() {
+			Specify("The response returned", func() {
+				samples := []struct {
+					request      NewLapRequest
+					statusCode   int
+					errorMessage string
+					response     LapResponse
+				}{
+					{
+						request:    NewLapRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: pendingSportsmen.ID.String(), Time: timeStart + 300000},
+						statusCode: http.StatusOK,
+						response:   LapResponse{Number: 1, LapTime: 300000},
+					},
+					{
+						request:      NewLapRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: pendingSportsmen.ID.String(), Time: timeStart + 301000},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "Lap is shorter than the minimum lap time",
+					},
+					{
+						request:    NewLapRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: pendingSportsmen.ID.String(), Time: timeStart + 600000},
+						statusCode: http.StatusOK,
+						response:   LapResponse{Number: 2, LapTime: 300000, Finished: true},
+					},
+					{
+						request:      NewLapRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: pendingSportsmen.ID.String(), Time: timeStart + 900000},
+						statusCode:   http.StatusConflict,
+						errorMessage: "Result has finish time already",
+					},
+					{
+						request:      NewLapRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: uuid.Must(uuid.NewV4()).String(), Time: timeStart + 300000},
+						statusCode:   http.StatusNotFound,
+						errorMessage: "Result does not exist",
+					},
+					{
+						request:      NewLapRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: pendingSportsmen.ID.String()},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "time: cannot be blank.",
+					},
+				}
+
+				for _, s := range samples {
+					requestBody, err := json.Marshal(s.request)
+					Expect(err).To(gomega.BeNil())
+
+					req, err := http.NewRequest("POST", "/laps", bytes.NewBuffer(requestBody))
+					Expect(err).To(gomega.BeNil())
+					req = asAdmin(req)
+
+					rr := httptest.NewRecorder()
+					AddLap(&srv).ServeHTTP(rr, req)
+
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code == 200 {
+						res := LapResponse{}
+						err = json.Unmarshal(rr.Body.Bytes(), &res)
+						Expect(err).To(gomega.BeNil())
+
+						s.response.ID = res.ID
+						Expect(res).To(Equal(s.response))
+					}
+
+					if rr.Code != 200 {
+						responseMap := make(map[string]interface{})
+
+						err = json.Unmarshal([]byte(rr.Body.String()), &responseMap)
+						Expect(err).To(gomega.BeNil())
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+
+		When("Standings request is sent", func() {
+			Specify("The laps completed are returned", func() {
+				requestBody, err := json.Marshal(NewLapRequest{
+					CheckpointID: pendingCheckpoint.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Time:         timeStart + 300000,
+				})
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/laps", bytes.NewBuffer(requestBody))
+				Expect(err).To(BeNil())
+				req = asAdmin(req)
+
+				rr := httptest.NewRecorder()
+				AddLap(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				req, err = http.NewRequest("GET", "/races/"+raceID.String()+"/standings", nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": raceID.String()})
+
+				rr = httptest.NewRecorder()
+				GetStandings(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				standings := []lap.Standing{}
+				err = json.Unmarshal(rr.Body.Bytes(), &standings)
+				Expect(err).To(BeNil())
+				Expect(standings).To(HaveLen(1))
+				Expect(standings[0].SportsmenID).To(Equal(pendingSportsmen.ID))
+				Expect(standings[0].Laps).To(Equal(uint32(1)))
+				Expect(standings[0].RaceTime).To(Equal(int64(300000)))
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package race_controller_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestRace(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Race Suite")
+}
@@ -0,0 +1,64 @@
+package race_controller
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"sports/backend/domain/models/race"
+)
+
+// NewRaceRequest is the race held on the circuit of the checkpoint, the time limit
+// and the minimum lap time are in milliseconds. The timed race runs the race clock from the start time
+// for the time limit and credits the lap completed after it by the partial lap rule.
+type NewRaceRequest struct {
+	Name         string `json:"name"`
+	CheckpointID string `json:"checkpoint_id"`
+	Format       string `json:"format"`
+	TargetLaps   uint32 `json:"target_laps"`
+	TimeLimit    int64  `json:"time_limit"`
+	MinLapTime   int64  `json:"min_lap_time"`
+	StartTime    int64  `json:"start_time"`
+	LapDistance  int64  `json:"lap_distance"`
+	PartialLap   string `json:"partial_lap"`
+}
+
+func (req NewRaceRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required),
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&req.Format, validation.Required, validation.In(race.FormatLaps, race.FormatTimed)),
+		validation.Field(&req.TimeLimit, validation.Min(int64(0))),
+		validation.Field(&req.MinLapTime, validation.Min(int64(0))),
+		validation.Field(&req.StartTime, validation.Min(int64(0))),
+		validation.Field(&req.LapDistance, validation.Min(int64(0))),
+		validation.Field(&req.PartialLap, validation.In(race.PartialLapCount, race.PartialLapDiscard, race.PartialLapProrate)),
+	)
+}
+
+type NewLapRequest struct {
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	Time         int64  `json:"time"`
+}
+
+func (req NewLapRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&req.Time, validation.Required),
+	)
+}
+
+type CreatedResponse struct {
+	ID string `json:"id"`
+}
+
+// LapResponse is the recorded lap, finished tells the lap has completed the race for the sportsmen,
+// partial tells the lap was completed after the race clock of the timed race has run out.
+type LapResponse struct {
+	ID       string `json:"id"`
+	Number   uint32 `json:"number"`
+	LapTime  int64  `json:"lap_time"`
+	Distance int64  `json:"distance"`
+	Partial  bool   `json:"partial"`
+	Finished bool   `json:"finished"`
+}
@@ -2,20 +2,16 @@ package result_controller
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
-	validation "github.com/go-ozzo/ozzo-validation"
-	"github.com/go-ozzo/ozzo-validation/is"
 	"github.com/gofrs/uuid"
-	"go.uber.org/zap"
+	"github.com/gorilla/mux"
 	"io/ioutil"
 	"net/http"
-	"sports/backend/domain/models/checkpoint"
 	"sports/backend/domain/models/result"
-	"sports/backend/domain/models/sportsmen"
-	"sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/etag"
 	"sports/backend/srv/responses"
 	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
 )
 
 // AddResult handles the new result request.
@@ -23,24 +19,26 @@ func AddResult(server *server.Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
 			return
 		}
 
 		req := NewResultRequest{}
 		err = json.Unmarshal(body, &req)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
-		err = validation.ValidateStruct(&req,
-			validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
-			validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
-			validation.Field(&req.Time, validation.Required),
-		)
+		err = req.Validate()
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
+			return
+		}
+
+		identity, err := auth.RequireCheckpoint(r.Context(), uuid.Must(uuid.FromString(req.CheckpointID)))
+		if err != nil {
+			responses.ERROR(w, err)
 			return
 		}
 
@@ -49,32 +47,22 @@ func AddResult(server *server.Server) http.HandlerFunc {
 			CheckpointID: uuid.Must(uuid.FromString(req.CheckpointID)),
 			SportsmenID:  uuid.Must(uuid.FromString(req.SportsmenID)),
 			TimeStart:    req.Time,
+			DeviceID:     identity.DeviceID,
 		}
 
-		_, err = result.Create(*server.DB, newResult)
-		if err != nil {
-			if (errors.As(err, &result.AlreadyExists{})) || (errors.As(err, &checkpoint.NotFound{})) || (errors.As(err, &sportsmen.NotFound{})) {
-				responses.ERROR(w, http.StatusUnprocessableEntity, err)
-				return
-			} else {
-				responses.ERROR(w, http.StatusInternalServerError, err)
-				return
-			}
-		}
-
-		version := uint32(1)
-		sportsmenFetched, err := sportsmen.GetSportsmen(*server.DB, newResult.SportsmenID, &version)
+		db, end := tracing.Command(r.Context(), server.DB, "result.Create")
+		resultCreatedEvent, err := result.Create(db, newResult)
+		end(err)
 		if err != nil {
-			zap.S().Fatal(err)
+			responses.ERROR(w, err)
+			return
 		}
 
-		server.Dashboard.Results <- dashboard_controller.UnfinishedResultMessage{
-			ID:                   newResult.ID.String(),
-			SportsmenName:        fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
-			SportsmenStartNumber: sportsmenFetched.StartNumber,
-			TimeStart:            newResult.TimeStart,
-		}
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
 
+		// The finish time is added to this version of the result.
+		w.Header().Set("Location", "/results/"+newResult.ID.String())
+		etag.SetVersion(w, resultCreatedEvent.Version)
 		responses.JSON(w, http.StatusOK, nil)
 	}
 }
@@ -84,80 +72,114 @@ func AddFinishTime(server *server.Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
 			return
 		}
 
 		req := FinishRequest{}
 		err = json.Unmarshal(body, &req)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
-		err = validation.ValidateStruct(&req,
-			validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
-			validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
-			validation.Field(&req.Time, validation.Required),
-		)
+		err = req.Validate()
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
 		checkPointID, err := uuid.FromString(req.CheckpointID)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
 			return
 		}
 
-		SportsmenID, err := uuid.FromString(req.SportsmenID)
+		identity, err := auth.RequireCheckpoint(r.Context(), checkPointID)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
-		version := uint32(1)
-		resultUnfinished, err := result.GetUnfinishedResult(*server.DB, checkPointID, SportsmenID, &version)
+		SportsmenID, err := uuid.FromString(req.SportsmenID)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
 			return
 		}
 
-		_, err = result.AddFinishTime(*server.DB, req.Time, *resultUnfinished)
+		version, err := etag.IfMatch(r)
 		if err != nil {
-			if errors.As(err, &result.AlreadyExists{}) {
-				responses.ERROR(w, http.StatusUnprocessableEntity, err)
-				return
-			} else {
-				responses.ERROR(w, http.StatusInternalServerError, err)
-				return
-			}
+			responses.ERROR(w, err)
+			return
 		}
 
-		version = uint32(1)
-		sportsmenFetched, err := sportsmen.GetSportsmen(*server.DB, resultUnfinished.SportsmenID, &version)
+		db, end := tracing.Command(r.Context(), server.DB, "result.GetUnfinishedResult")
+		resultUnfinished, err := result.GetUnfinishedResult(db, checkPointID, SportsmenID, version)
+		end(err)
 		if err != nil {
-			zap.S().Fatal(err)
+			responses.ERROR(w, err)
+			return
 		}
 
-		server.Dashboard.Finish <- dashboard_controller.FinishedResultMessage{
-			ID:                   resultUnfinished.ID.String(),
-			SportsmenName:        fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
-			SportsmenStartNumber: sportsmenFetched.StartNumber,
-			TimeFinish:           req.Time,
+		db, end = tracing.Command(r.Context(), server.DB, "result.AddFinishTimeByDevice")
+		resultFinishedEvent, err := result.AddFinishTimeByDevice(db, req.Time, identity.DeviceID, *resultUnfinished)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
 		}
 
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
+
+		etag.SetVersion(w, resultFinishedEvent.Version)
 		responses.JSON(w, http.StatusOK, nil)
 	}
 }
 
+// GetResult handles the result request.
+func GetResult(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resultID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "result.GetResult")
+		resultFetched, err := result.GetResult(db, resultID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, resultFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, resultFetched)
+	}
+}
+
 // GetLastTenResults handles the latest results request.
 func GetLastTenResults(server *server.Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		results, err := result.GetLastTenResults(*server.DB)
+		db, end := tracing.Command(r.Context(), server.DB, "result.GetLastTenResults")
+		results, err := result.GetLastTenResults(db)
+		end(err)
 		if err != nil {
-			responses.ERROR(w, http.StatusInternalServerError, nil)
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, results)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
 			return
 		}
 
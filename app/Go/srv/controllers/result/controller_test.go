@@ -2,6 +2,7 @@ package result_controller
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/mux"
@@ -14,14 +15,24 @@ import (
 	"net/http/httptest"
 	"path/filepath"
 	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/idempotency"
 	"sports/backend/domain/models/result"
 	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/auth"
 	"sports/backend/srv/cmd/config"
 	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/middleware"
 	"sports/backend/srv/server"
 	"sports/backend/srv/utils"
 )
 
+// asAdmin authenticates the request as the admin the way the auth middleware of the routes does.
+func asAdmin(req *http.Request) *http.Request {
+	return req.WithContext(auth.WithIdentity(req.Context(), auth.Identity{Role: credential.RoleAdmin}))
+}
+
 var _ = Describe("Results controller", func() {
 	var (
 		db *gorm.DB
@@ -58,8 +69,10 @@ var _ = Describe("Results controller", func() {
 	srv.DB = conn
 	srv.Router = mux.NewRouter()
 	srv.Dashboard = dashboard
+	srv.Dispatcher = dispatcher.NewDispatcher()
+	srv.Dashboard.Subscribe(srv.Dispatcher)
 
-	go srv.Dashboard.Run(srv.DB)
+	go srv.Dashboard.Run(context.Background(), srv.DB)
 
 	BeforeEach(func() {
 		db = conn.Begin()
@@ -139,6 +152,7 @@ var _ = Describe("Results controller", func() {
 					Time         int64  `json:"time_start"`
 					statusCode   int
 					errorMessage string
+					errorCode    string
 				}{
 					{
 						CheckpointID: pendingCheckpoint2.ID.String(),
@@ -146,20 +160,23 @@ var _ = Describe("Results controller", func() {
 						Time:         pendingResult.TimeStart,
 						statusCode:   http.StatusOK,
 						errorMessage: "",
+						errorCode:    "",
 					},
 					{
 						CheckpointID: uuid.Must(uuid.NewV4()).String(),
 						SportsmenID:  pendingSportsmen2.ID.String(),
 						Time:         pendingResult.TimeStart,
-						statusCode:   http.StatusUnprocessableEntity,
+						statusCode:   http.StatusNotFound,
 						errorMessage: "Checkpoint does not exist",
+						errorCode:    "checkpoint_not_found",
 					},
 					{
 						CheckpointID: pendingCheckpoint2.ID.String(),
 						SportsmenID:  uuid.Must(uuid.NewV4()).String(),
 						Time:         pendingResult.TimeStart,
-						statusCode:   http.StatusUnprocessableEntity,
+						statusCode:   http.StatusNotFound,
 						errorMessage: "Sportsmen does not exist",
+						errorCode:    "sportsmen_not_found",
 					},
 					{
 						CheckpointID: "",
@@ -167,6 +184,7 @@ var _ = Describe("Results controller", func() {
 						Time:         pendingResult.TimeStart,
 						statusCode:   http.StatusUnprocessableEntity,
 						errorMessage: "checkpoint_id: cannot be blank.",
+						errorCode:    "validation_failed",
 					},
 					{
 						CheckpointID: pendingCheckpoint2.ID.String(),
@@ -174,19 +192,22 @@ var _ = Describe("Results controller", func() {
 						Time:         pendingResult.TimeStart,
 						statusCode:   http.StatusUnprocessableEntity,
 						errorMessage: "sportsmen_id: cannot be blank.",
+						errorCode:    "validation_failed",
 					},
 					{
 						CheckpointID: pendingCheckpoint2.ID.String(),
 						SportsmenID:  pendingSportsmen2.ID.String(),
 						statusCode:   http.StatusUnprocessableEntity,
 						errorMessage: "time_start: cannot be blank.",
+						errorCode:    "validation_failed",
 					},
 					{
 						CheckpointID: pendingCheckpoint1.ID.String(),
 						SportsmenID:  pendingResult.SportsmenID.String(),
 						Time:         pendingResult.TimeStart,
-						statusCode:   http.StatusUnprocessableEntity,
+						statusCode:   http.StatusConflict,
 						errorMessage: "Result already exists",
+						errorCode:    "result_already_exists",
 					},
 				}
 
@@ -202,6 +223,7 @@ var _ = Describe("Results controller", func() {
 
 					req, err := http.NewRequest("POST", "/results", bytes.NewBufferString(string(requestBody)))
 					Expect(err).To(gomega.BeNil())
+					req = asAdmin(req)
 
 					rr := httptest.NewRecorder()
 					handler := AddResult(&srv)
@@ -219,7 +241,8 @@ var _ = Describe("Results controller", func() {
 					}
 
 					if rr.Code != 200 {
-						Expect(responseMap["error"]).To(Equal(s.errorMessage))
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+						Expect(responseMap["code"]).To(Equal(s.errorCode))
 					}
 				}
 			})
@@ -275,6 +298,7 @@ var _ = Describe("Results controller", func() {
 					Time         int64  `json:"time_finish"`
 					statusCode   int
 					errorMessage string
+					errorCode    string
 				}{
 					{
 						CheckpointID: pendingCheckpoint.ID.String(),
@@ -282,20 +306,23 @@ var _ = Describe("Results controller", func() {
 						Time:         pendingResult.TimeStart,
 						statusCode:   http.StatusOK,
 						errorMessage: "",
+						errorCode:    "",
 					},
 					{
 						CheckpointID: uuid.Must(uuid.NewV4()).String(),
 						SportsmenID:  pendingSportsmen.ID.String(),
 						Time:         pendingResult.TimeStart,
-						statusCode:   http.StatusUnprocessableEntity,
+						statusCode:   http.StatusNotFound,
 						errorMessage: "Result not found: Result does not exist",
+						errorCode:    "result_not_found",
 					},
 					{
 						CheckpointID: pendingCheckpoint.ID.String(),
 						SportsmenID:  uuid.Must(uuid.NewV4()).String(),
 						Time:         pendingResult.TimeStart,
-						statusCode:   http.StatusUnprocessableEntity,
+						statusCode:   http.StatusNotFound,
 						errorMessage: "Result not found: Result does not exist",
+						errorCode:    "result_not_found",
 					},
 					{
 						CheckpointID: "",
@@ -303,6 +330,7 @@ var _ = Describe("Results controller", func() {
 						Time:         pendingResult.TimeStart,
 						statusCode:   http.StatusUnprocessableEntity,
 						errorMessage: "checkpoint_id: cannot be blank.",
+						errorCode:    "validation_failed",
 					},
 					{
 						CheckpointID: pendingCheckpoint.ID.String(),
@@ -310,19 +338,22 @@ var _ = Describe("Results controller", func() {
 						Time:         pendingResult.TimeStart,
 						statusCode:   http.StatusUnprocessableEntity,
 						errorMessage: "sportsmen_id: cannot be blank.",
+						errorCode:    "validation_failed",
 					},
 					{
 						CheckpointID: pendingCheckpoint.ID.String(),
 						SportsmenID:  pendingSportsmen.ID.String(),
 						statusCode:   http.StatusUnprocessableEntity,
 						errorMessage: "time_finish: cannot be blank.",
+						errorCode:    "validation_failed",
 					},
 					{
 						CheckpointID: pendingCheckpoint.ID.String(),
 						SportsmenID:  pendingResult.SportsmenID.String(),
 						Time:         pendingResult.TimeStart,
-						statusCode:   http.StatusUnprocessableEntity,
+						statusCode:   http.StatusConflict,
 						errorMessage: "Result has finish time already",
+						errorCode:    "result_already_finished",
 					},
 				}
 
@@ -338,6 +369,8 @@ var _ = Describe("Results controller", func() {
 
 					req, err := http.NewRequest("POST", "/results", bytes.NewBufferString(string(requestBody)))
 					Expect(err).To(gomega.BeNil())
+					req = asAdmin(req)
+					req.Header.Set("If-Match", `"1"`)
 
 					rr := httptest.NewRecorder()
 					handler := AddFinishTime(&srv)
@@ -355,10 +388,197 @@ var _ = Describe("Results controller", func() {
 					}
 
 					if rr.Code != 200 {
-						Expect(responseMap["error"]).To(Equal(s.errorMessage))
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+						Expect(responseMap["code"]).To(Equal(s.errorCode))
 					}
 				}
 			})
+
+			Specify("The version precondition checked", func() {
+				samples := []struct {
+					ifMatch    string
+					statusCode int
+				}{
+					{ifMatch: "", statusCode: http.StatusPreconditionRequired},
+					{ifMatch: "1", statusCode: http.StatusBadRequest},
+					{ifMatch: `"2"`, statusCode: http.StatusPreconditionFailed},
+					{ifMatch: `"1"`, statusCode: http.StatusOK},
+				}
+
+				for _, s := range samples {
+					requestBody, err := json.Marshal(FinishRequest{
+						CheckpointID: pendingCheckpoint.ID.String(),
+						SportsmenID:  pendingSportsmen.ID.String(),
+						Time:         utils.MakeTimestampInMilliseconds(),
+					})
+					Expect(err).To(BeNil())
+
+					req, err := http.NewRequest("POST", "/finish", bytes.NewBuffer(requestBody))
+					Expect(err).To(BeNil())
+					req = asAdmin(req)
+					if s.ifMatch != "" {
+						req.Header.Set("If-Match", s.ifMatch)
+					}
+
+					rr := httptest.NewRecorder()
+					AddFinishTime(&srv).ServeHTTP(rr, req)
+
+					Expect(rr.Code).To(Equal(s.statusCode), s.ifMatch)
+				}
+
+				finished := result.Result{}
+				err := db.Where("checkpoint_id = ? AND sportsmen_id = ?", pendingCheckpoint.ID, pendingSportsmen.ID).Take(&finished).Error
+				Expect(err).To(BeNil())
+				Expect(finished.TimeFinish).ToNot(BeNil())
+				Expect(finished.Version).To(Equal(uint32(2)))
+			})
+		})
+	})
+
+	Describe("Creating new result by timekeeper", func() {
+		When("Timekeeper submits time of another checkpoint", func() {
+			var pendingCheckpoint1 checkpoint.PendingCheckpoint
+			var pendingCheckpoint2 checkpoint.PendingCheckpoint
+			var pendingSportsmen sportsmen.PendingSportsmen
+
+			BeforeEach(func() {
+				pendingCheckpoint1 = checkpoint.PendingCheckpoint{
+					ID:   uuid.Must(uuid.NewV4()),
+					Name: "Corridor1",
+				}
+
+				_, err := checkpoint.Create(*db, pendingCheckpoint1)
+				Expect(err).To(BeNil())
+
+				pendingCheckpoint2 = checkpoint.PendingCheckpoint{
+					ID:   uuid.Must(uuid.NewV4()),
+					Name: "Corridor2",
+				}
+
+				_, err = checkpoint.Create(*db, pendingCheckpoint2)
+				Expect(err).To(BeNil())
+
+				pendingSportsmen = sportsmen.PendingSportsmen{
+					ID:          uuid.Must(uuid.NewV4()),
+					FirstName:   "Vladimir",
+					LastName:    "Andrianov",
+					StartNumber: 101,
+				}
+
+				_, err = sportsmen.Create(*db, pendingSportsmen)
+				Expect(err).To(BeNil())
+			})
+
+			Specify("The request is forbidden", func() {
+				identity := auth.Identity{
+					CredentialID:  uuid.Must(uuid.NewV4()),
+					Role:          credential.RoleTimekeeper,
+					CheckpointIDs: []uuid.UUID{pendingCheckpoint1.ID},
+				}
+
+				requestBody, err := json.Marshal(NewResultRequest{
+					CheckpointID: pendingCheckpoint2.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Time:         utils.MakeTimestampInMilliseconds(),
+				})
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("POST", "/results", bytes.NewBuffer(requestBody))
+				Expect(err).To(BeNil())
+				req = req.WithContext(auth.WithIdentity(req.Context(), identity))
+
+				rr := httptest.NewRecorder()
+				handler := AddResult(&srv)
+				handler.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusForbidden))
+
+				_, err = result.GetUnfinishedResult(*db, pendingCheckpoint2.ID, pendingSportsmen.ID, nil)
+				Expect(err).ToNot(BeNil())
+			})
+		})
+	})
+
+	Describe("Retrying new result with Idempotency-Key", func() {
+		When("New result request is repeated", func() {
+			var pendingCheckpoint checkpoint.PendingCheckpoint
+			var pendingSportsmen sportsmen.PendingSportsmen
+
+			BeforeEach(func() {
+				pendingCheckpoint = checkpoint.PendingCheckpoint{
+					ID:   uuid.Must(uuid.NewV4()),
+					Name: "Corridor1",
+				}
+
+				_, err := checkpoint.Create(*db, pendingCheckpoint)
+				Expect(err).To(BeNil())
+
+				pendingSportsmen = sportsmen.PendingSportsmen{
+					ID:          uuid.Must(uuid.NewV4()),
+					FirstName:   "Vladimir",
+					LastName:    "Andrianov",
+					StartNumber: 101,
+				}
+
+				_, err = sportsmen.Create(*db, pendingSportsmen)
+				Expect(err).To(BeNil())
+			})
+
+			Specify("The original response replayed", func() {
+				key := uuid.Must(uuid.NewV4()).String()
+				handler := middleware.SetMiddlewareIdempotency(&srv, AddResult(&srv))
+
+				send := func(time int64) *httptest.ResponseRecorder {
+					requestBody, err := json.Marshal(NewResultRequest{
+						CheckpointID: pendingCheckpoint.ID.String(),
+						SportsmenID:  pendingSportsmen.ID.String(),
+						Time:         time,
+					})
+					Expect(err).To(BeNil())
+
+					req, err := http.NewRequest("POST", "/results", bytes.NewBuffer(requestBody))
+					Expect(err).To(BeNil())
+					req = asAdmin(req)
+					req.Header.Set("Idempotency-Key", key)
+
+					rr := httptest.NewRecorder()
+					handler.ServeHTTP(rr, req)
+					return rr
+				}
+
+				timeStart := utils.MakeTimestampInMilliseconds()
+
+				first := send(timeStart)
+				Expect(first.Code).To(Equal(http.StatusOK))
+				Expect(first.Header().Get("Idempotent-Replayed")).To(BeEmpty())
+
+				repeated := send(timeStart)
+				Expect(repeated.Code).To(Equal(http.StatusOK))
+				Expect(repeated.Header().Get("Idempotent-Replayed")).To(Equal("true"))
+				Expect(repeated.Body.String()).To(Equal(first.Body.String()))
+
+				another := send(timeStart + 1)
+				Expect(another.Code).To(Equal(http.StatusUnprocessableEntity))
+			})
+
+			Specify("The key released when the handler panics", func() {
+				key := uuid.Must(uuid.NewV4()).String()
+				handler := middleware.SetMiddlewareIdempotency(&srv, func(w http.ResponseWriter, r *http.Request) {
+					panic("handler failed")
+				})
+
+				req, err := http.NewRequest("POST", "/results", bytes.NewBufferString("{}"))
+				Expect(err).To(BeNil())
+				req = asAdmin(req)
+				req.Header.Set("Idempotency-Key", key)
+
+				Expect(func() {
+					handler.ServeHTTP(httptest.NewRecorder(), req)
+				}).To(PanicWith("handler failed"))
+
+				_, err = idempotency.GetKey(*db, uuid.Nil.String(), key)
+				Expect(err).To(MatchError(idempotency.NotFound{}))
+			})
 		})
 	})
 })
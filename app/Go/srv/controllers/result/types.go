@@ -1,13 +1,34 @@
 package result_controller
 
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
 type NewResultRequest struct {
 	CheckpointID string `json:"checkpoint_id"`
 	SportsmenID  string `json:"sportsmen_id"`
 	Time         int64  `json:"time_start"`
 }
 
+func (req NewResultRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&req.Time, validation.Required),
+	)
+}
+
 type FinishRequest struct {
 	CheckpointID string `json:"checkpoint_id"`
 	SportsmenID  string `json:"sportsmen_id"`
 	Time         int64  `json:"time_finish"`
 }
+
+func (req FinishRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&req.Time, validation.Required),
+	)
+}
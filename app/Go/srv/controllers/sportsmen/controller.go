@@ -2,13 +2,15 @@ package sportsmen_controller
 
 import (
 	"encoding/json"
-	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
 	"io/ioutil"
 	"net/http"
 	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/etag"
 	"sports/backend/srv/responses"
 	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
 )
 
 // AddSportsmen handles the new sportsmen request.
@@ -16,24 +18,20 @@ func AddSportsmen(server *server.Server) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		body, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
 			return
 		}
 
 		req := NewSportsmenRequest{}
 		err = json.Unmarshal(body, &req)
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
-		err = validation.ValidateStruct(&req,
-			validation.Field(&req.StartNumber, validation.Required),
-			validation.Field(&req.FirstName, validation.Required),
-			validation.Field(&req.LastName, validation.Required),
-		)
+		err = req.Validate()
 		if err != nil {
-			responses.ERROR(w, http.StatusUnprocessableEntity, err)
+			responses.ERROR(w, err)
 			return
 		}
 
@@ -42,14 +40,44 @@ func AddSportsmen(server *server.Server) http.HandlerFunc {
 			StartNumber: req.StartNumber,
 			FirstName:   req.FirstName,
 			LastName:    req.LastName,
+			Category:    req.Category,
 		}
 
-		sportsmenCreatedEvent, err := sportsmen.Create(*server.DB, newSportsmen)
+		db, end := tracing.Command(r.Context(), server.DB, "sportsmen.Create")
+		sportsmenCreatedEvent, err := sportsmen.Create(db, newSportsmen)
+		end(err)
 		if err != nil {
-			responses.ERROR(w, http.StatusInternalServerError, err)
+			responses.ERROR(w, err)
 			return
 		}
 
+		etag.SetVersion(w, sportsmenCreatedEvent.Version)
 		responses.JSON(w, http.StatusOK, CreatedResponse{ID: sportsmenCreatedEvent.SportsmenID})
 	}
 }
+
+// GetSportsmen handles the sportsmen request.
+func GetSportsmen(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sportsmenID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "sportsmen.GetSportsmen")
+		sportsmenFetched, err := sportsmen.GetSportsmen(db, sportsmenID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, sportsmenFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, sportsmenFetched)
+	}
+}
@@ -126,7 +126,7 @@ var _ = Describe("Sportsmens controller", func() {
 					}
 
 					if rr.Code != 200 {
-						Expect(responseMap["error"]).To(Equal(s.errorMessage))
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
 					}
 				}
 			})
@@ -1,9 +1,22 @@
 package sportsmen_controller
 
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
 type NewSportsmenRequest struct {
 	StartNumber uint32 `json:"start_number"`
 	FirstName   string `json:"first_name"`
 	LastName    string `json:"last_name"`
+	Category    string `json:"category"`
+}
+
+func (req NewSportsmenRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.StartNumber, validation.Required),
+		validation.Field(&req.FirstName, validation.Required),
+		validation.Field(&req.LastName, validation.Required),
+	)
 }
 
 type CreatedResponse struct {
@@ -0,0 +1,305 @@
+package sync_controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/record"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/split"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/domain/transaction"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"sports/backend/srv/utils"
+)
+
+// Maximum number of records accepted in a single batch.
+const maxBatchSize = 500
+
+// Outcomes of the synchronized records.
+const (
+	// OutcomeApplied signifies the record has been applied now.
+	OutcomeApplied = "applied"
+	// OutcomeDuplicate signifies the record has been applied by the earlier sync already.
+	OutcomeDuplicate = "duplicate"
+	// OutcomeRejected signifies the record can't be applied, the device may fix and resend it.
+	OutcomeRejected = "rejected"
+)
+
+// Sync handles the batch of timing records collected by the device while offline. Records are applied
+// in the given order, every record either is applied completely or is rejected without affecting the others,
+// the already applied records are reported as duplicates so that the batch is safe to retry after partial failures.
+func Sync(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.FromContext(r.Context()); !ok {
+			responses.ERROR(w, auth.Unauthenticated{Reason: "credentials required"})
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := SyncRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = validation.ValidateStruct(&req,
+			validation.Field(&req.Records, validation.Required, validation.Length(1, maxBatchSize)),
+		)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		response := SyncResponse{}
+
+		// Unexpected failure aborts the batch, nothing is applied and the device retries the whole batch.
+		err = transaction.Run(*tracing.DB(r.Context(), server.DB), func(tx gorm.DB) error {
+			for _, rec := range req.Records {
+				outcome, err := apply(r.Context(), tx, rec)
+				if err != nil {
+					return err
+				}
+
+				switch outcome.Outcome {
+				case OutcomeApplied:
+					response.Applied++
+				case OutcomeDuplicate:
+					response.Duplicates++
+				case OutcomeRejected:
+					response.Rejected++
+				}
+
+				response.Records = append(response.Records, outcome)
+			}
+
+			return nil
+		})
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
+
+		responses.JSON(w, http.StatusOK, response)
+	}
+}
+
+// apply applies the single record within its own savepoint, the returned error signifies the batch must be aborted,
+// the records which can't be applied are reported as rejected.
+func apply(ctx context.Context, tx gorm.DB, rec RecordRequest) (RecordOutcome, error) {
+	outcome := RecordOutcome{ID: rec.ID}
+
+	if err := validateRecord(rec); err != nil {
+		return rejected(outcome, err), nil
+	}
+
+	checkpointID := uuid.Must(uuid.FromString(rec.CheckpointID))
+	identity, err := auth.RequireCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return rejected(outcome, err), nil
+	}
+
+	recordID := uuid.Must(uuid.FromString(rec.ID))
+	payloadHash := hashRecord(rec)
+
+	applied, err := record.GetRecord(tx, recordID)
+	if err == nil {
+		if applied.PayloadHash != payloadHash {
+			return rejected(outcome, record.Reused{}), nil
+		}
+
+		outcome.Outcome = OutcomeDuplicate
+		outcome.EntityID = applied.EntityID.String()
+		return outcome, nil
+	} else if !errors.As(err, &record.NotFound{}) {
+		return outcome, err
+	}
+
+	var entityID uuid.UUID
+	err = transaction.Run(tx, func(tx gorm.DB) error {
+		var err error
+		entityID, err = applyRecord(tx, identity, recordID, checkpointID, rec)
+		if err != nil {
+			return err
+		}
+
+		_, err = record.Create(tx, record.PendingRecord{
+			ID:           recordID,
+			Type:         rec.Type,
+			CredentialID: identity.CredentialID,
+			DeviceID:     identity.DeviceID,
+			PayloadHash:  payloadHash,
+			EntityID:     entityID,
+			AppliedAt:    utils.MakeTimestampInMilliseconds(),
+		})
+		return err
+	})
+	if err != nil {
+		if isRejection(err) {
+			return rejected(outcome, err), nil
+		}
+
+		return outcome, err
+	}
+
+	outcome.Outcome = OutcomeApplied
+	outcome.EntityID = entityID.String()
+
+	return outcome, nil
+}
+
+// applyRecord changes the domain according to the record type,
+// the ID of the changed entity is returned.
+func applyRecord(tx gorm.DB, identity auth.Identity, recordID, checkpointID uuid.UUID, rec RecordRequest) (uuid.UUID, error) {
+	sportsmenID := uuid.Must(uuid.FromString(rec.SportsmenID))
+
+	switch rec.Type {
+	case record.TypeStart:
+		_, err := result.Create(tx, result.PendingResult{
+			ID:           recordID,
+			CheckpointID: checkpointID,
+			SportsmenID:  sportsmenID,
+			TimeStart:    rec.Time,
+			DeviceID:     identity.DeviceID,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return recordID, nil
+
+	case record.TypeFinish:
+		resultUnfinished, err := result.GetUnfinishedResult(tx, checkpointID, sportsmenID, nil)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		_, err = result.AddFinishTimeByDevice(tx, rec.Time, identity.DeviceID, *resultUnfinished)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return resultUnfinished.ID, nil
+
+	case record.TypeSplit:
+		_, err := split.Create(tx, split.PendingSplit{
+			ID:           recordID,
+			CheckpointID: checkpointID,
+			SportsmenID:  sportsmenID,
+			Time:         rec.Time,
+			DeviceID:     identity.DeviceID,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return recordID, nil
+
+	case record.TypeLap:
+		_, err := lap.Record(tx, lap.PendingLap{
+			ID:           recordID,
+			CheckpointID: checkpointID,
+			SportsmenID:  sportsmenID,
+			Time:         rec.Time,
+			DeviceID:     identity.DeviceID,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return recordID, nil
+
+	case record.TypeHandover:
+		event, err := team.Handover(tx, team.PendingHandover{
+			ID:           recordID,
+			CheckpointID: checkpointID,
+			SportsmenID:  sportsmenID,
+			Time:         rec.Time,
+			DeviceID:     identity.DeviceID,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return uuid.FromStringOrNil(event.ResultID), nil
+
+	case record.TypeStatus:
+		sportsmenFetched, err := sportsmen.GetSportsmen(tx, sportsmenID, nil)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		_, err = sportsmen.SetStatus(tx, rec.Status, *sportsmenFetched)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return sportsmenID, nil
+	}
+
+	return uuid.Nil, fmt.Errorf("Unsupported record type %q", rec.Type)
+}
+
+func validateRecord(rec RecordRequest) error {
+	timeRules := []validation.Rule{validation.Required}
+	statusRules := []validation.Rule{validation.In(
+		sportsmen.StatusDidNotStart,
+		sportsmen.StatusDidNotFinish,
+		sportsmen.StatusDisqualified,
+	)}
+	if rec.Type == record.TypeStatus {
+		timeRules = nil
+	} else {
+		statusRules = []validation.Rule{validation.In(sportsmen.StatusNone)}
+	}
+
+	return validation.ValidateStruct(&rec,
+		validation.Field(&rec.ID, validation.Required, is.UUIDv4),
+		validation.Field(&rec.Type, validation.Required, validation.In(record.TypeStart, record.TypeFinish, record.TypeSplit, record.TypeStatus, record.TypeLap, record.TypeHandover)),
+		validation.Field(&rec.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&rec.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&rec.Time, timeRules...),
+		validation.Field(&rec.Status, statusRules...),
+	)
+}
+
+// hashRecord returns the hash of the record content used to tell the retried record from the reused ID.
+func hashRecord(rec RecordRequest) string {
+	b, _ := json.Marshal(rec)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// isRejection reports whether the error is caused by the record itself rather than by the failing server.
+func isRejection(err error) bool {
+	return responses.ProblemOf(err).Status < http.StatusInternalServerError
+}
+
+func rejected(outcome RecordOutcome, err error) RecordOutcome {
+	outcome.Outcome = OutcomeRejected
+	outcome.Error = err.Error()
+	outcome.Code = responses.ProblemOf(err).Code
+	return outcome
+}
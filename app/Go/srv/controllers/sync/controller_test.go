@@ -0,0 +1,342 @@
+package sync_controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/record"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/split"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/cmd/config"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Sync controller", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	// Set up the dashboard Websocket API module
+	dashboard := &dashboard_controller.Dashboard{
+		ConnHub: make(map[string]*dashboard_controller.Connection),
+		Results: make(chan dashboard_controller.UnfinishedResultMessage),
+		Finish:  make(chan dashboard_controller.FinishedResultMessage),
+		Join:    make(chan *dashboard_controller.Connection),
+		Leave:   make(chan *dashboard_controller.Connection),
+	}
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+	srv.Dashboard = dashboard
+	srv.Dispatcher = dispatcher.NewDispatcher()
+	srv.Dashboard.Subscribe(srv.Dispatcher)
+
+	go srv.Dashboard.Run(context.Background(), srv.DB)
+
+	var startCheckpoint checkpoint.PendingCheckpoint
+	var splitCheckpoint checkpoint.PendingCheckpoint
+	var pendingSportsmen sportsmen.PendingSportsmen
+	var identity auth.Identity
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+
+		startCheckpoint = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Start",
+		}
+
+		_, err := checkpoint.Create(*db, startCheckpoint)
+		Expect(err).To(BeNil())
+
+		splitCheckpoint = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "10 km",
+		}
+
+		_, err = checkpoint.Create(*db, splitCheckpoint)
+		Expect(err).To(BeNil())
+
+		pendingSportsmen = sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			StartNumber: 101,
+		}
+
+		_, err = sportsmen.Create(*db, pendingSportsmen)
+		Expect(err).To(BeNil())
+
+		identity = auth.Identity{
+			CredentialID:  uuid.Must(uuid.NewV4()),
+			Role:          credential.RoleTimekeeper,
+			CheckpointIDs: []uuid.UUID{startCheckpoint.ID, splitCheckpoint.ID},
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	// sync sends the batch on behalf of the timekeeper.
+	sync := func(records []RecordRequest) SyncResponse {
+		requestBody, err := json.Marshal(SyncRequest{Records: records})
+		Expect(err).To(BeNil())
+
+		req, err := http.NewRequest("POST", "/sync", bytes.NewBuffer(requestBody))
+		Expect(err).To(BeNil())
+		req = req.WithContext(auth.WithIdentity(req.Context(), identity))
+
+		rr := httptest.NewRecorder()
+		handler := Sync(&srv)
+		handler.ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		response := SyncResponse{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(BeNil())
+
+		return response
+	}
+
+	Describe("Synchronizing the records", func() {
+		var records []RecordRequest
+
+		BeforeEach(func() {
+			timeStart := utils.MakeTimestampInMilliseconds()
+
+			records = []RecordRequest{
+				{
+					ID:           uuid.Must(uuid.NewV4()).String(),
+					Type:         "start",
+					CheckpointID: startCheckpoint.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Time:         timeStart,
+				},
+				{
+					ID:           uuid.Must(uuid.NewV4()).String(),
+					Type:         "split",
+					CheckpointID: splitCheckpoint.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Time:         timeStart + 1000,
+				},
+				{
+					ID:           uuid.Must(uuid.NewV4()).String(),
+					Type:         "finish",
+					CheckpointID: startCheckpoint.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Time:         timeStart + 2000,
+				},
+			}
+		})
+
+		When("the batch is sent", func() {
+			Specify("the records are applied in order", func() {
+				response := sync(records)
+
+				Expect(response.Applied).To(Equal(3))
+				for _, outcome := range response.Records {
+					Expect(outcome.Outcome).To(Equal(OutcomeApplied))
+				}
+
+				finished := result.Result{}
+				err := db.Where("id = ?", records[0].ID).Take(&finished).Error
+				Expect(err).To(BeNil())
+				Expect(*finished.TimeFinish).To(Equal(records[2].Time))
+
+				splits, err := split.GetSplits(*db, pendingSportsmen.ID)
+				Expect(err).To(BeNil())
+				Expect(*splits).To(HaveLen(1))
+			})
+		})
+
+		When("the batch is retried", func() {
+			Specify("the applied records are reported as duplicates", func() {
+				sync(records)
+
+				response := sync(records)
+
+				Expect(response.Duplicates).To(Equal(3))
+				Expect(response.Records[0].EntityID).To(Equal(records[0].ID))
+			})
+		})
+
+		When("a record is rejected", func() {
+			Specify("the other records are applied", func() {
+				records[1].SportsmenID = uuid.Must(uuid.NewV4()).String()
+
+				response := sync(records)
+
+				Expect(response.Applied).To(Equal(2))
+				Expect(response.Rejected).To(Equal(1))
+				Expect(response.Records[1].Outcome).To(Equal(OutcomeRejected))
+				Expect(response.Records[1].Error).To(Equal("Sportsmen does not exist"))
+				Expect(response.Records[1].Code).To(Equal("sportsmen_not_found"))
+			})
+		})
+
+		When("the record ID is not the UUID v4", func() {
+			Specify("the record is rejected before it is applied", func() {
+				records[0].ID = uuid.Must(uuid.NewV1()).String()
+
+				response := sync(records[:1])
+
+				Expect(response.Rejected).To(Equal(1))
+				Expect(response.Records[0].Outcome).To(Equal(OutcomeRejected))
+				Expect(response.Records[0].Error).To(Equal("id: must be a valid UUID v4."))
+				Expect(response.Records[0].Code).To(Equal("validation_failed"))
+			})
+		})
+
+		When("the record ID is reused for another record", func() {
+			Specify("the record is rejected", func() {
+				sync(records[:1])
+
+				records[0].Time++
+				response := sync(records[:1])
+
+				Expect(response.Records[0].Outcome).To(Equal(OutcomeRejected))
+				Expect(response.Records[0].Error).To(Equal(record.Reused{}.Error()))
+				Expect(response.Records[0].Code).To(Equal("record_id_reused"))
+			})
+		})
+
+		When("the record of another checkpoint is sent", func() {
+			Specify("the record is rejected", func() {
+				identity.CheckpointIDs = []uuid.UUID{startCheckpoint.ID}
+
+				response := sync(records)
+
+				Expect(response.Records[1].Outcome).To(Equal(OutcomeRejected))
+				Expect(response.Applied).To(Equal(2))
+			})
+		})
+
+		When("the status is sent", func() {
+			Specify("the sportsmen status is changed", func() {
+				response := sync([]RecordRequest{{
+					ID:           uuid.Must(uuid.NewV4()).String(),
+					Type:         "status",
+					CheckpointID: splitCheckpoint.ID.String(),
+					SportsmenID:  pendingSportsmen.ID.String(),
+					Status:       sportsmen.StatusDidNotFinish,
+				}})
+
+				Expect(response.Applied).To(Equal(1))
+
+				fetched, err := sportsmen.GetSportsmen(*db, pendingSportsmen.ID, nil)
+				Expect(err).To(BeNil())
+				Expect(fetched.Status).To(Equal(sportsmen.StatusDidNotFinish))
+			})
+		})
+
+		When("the passings of the race checkpoint are sent", func() {
+			Specify("the passings are recorded as laps", func() {
+				_, err := race.Create(*db, race.PendingRace{
+					ID:           uuid.Must(uuid.NewV4()),
+					Name:         "Criterium",
+					CheckpointID: startCheckpoint.ID,
+					Format:       race.FormatLaps,
+					TargetLaps:   2,
+				})
+				Expect(err).To(BeNil())
+
+				records[1].Type = "lap"
+				records[1].CheckpointID = startCheckpoint.ID.String()
+				records[2].Type = "lap"
+
+				response := sync(records)
+
+				Expect(response.Applied).To(Equal(3))
+
+				laps, err := lap.GetLaps(*db, uuid.Must(uuid.FromString(records[0].ID)))
+				Expect(err).To(BeNil())
+				Expect(*laps).To(HaveLen(2))
+
+				finished := result.Result{}
+				err = db.Where("id = ?", records[0].ID).Take(&finished).Error
+				Expect(err).To(BeNil())
+				Expect(*finished.TimeFinish).To(Equal(records[2].Time))
+			})
+		})
+
+		When("the handovers of the relay are sent", func() {
+			Specify("the legs are handed over and the last handover finishes the team", func() {
+				nextSportsmen := sportsmen.PendingSportsmen{
+					ID:          uuid.Must(uuid.NewV4()),
+					FirstName:   "Ivan",
+					LastName:    "Petrov",
+					StartNumber: 102,
+				}
+
+				_, err := sportsmen.Create(*db, nextSportsmen)
+				Expect(err).To(BeNil())
+
+				_, err = team.Create(*db, team.PendingTeam{
+					ID:   uuid.Must(uuid.NewV4()),
+					Name: "Harriers",
+					Legs: []team.PendingLeg{
+						{SportsmenID: pendingSportsmen.ID, CheckpointID: startCheckpoint.ID},
+						{SportsmenID: nextSportsmen.ID, CheckpointID: startCheckpoint.ID},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				records[1].Type = "handover"
+				records[1].CheckpointID = startCheckpoint.ID.String()
+				records[2].Type = "handover"
+				records[2].SportsmenID = nextSportsmen.ID.String()
+
+				response := sync(records)
+
+				Expect(response.Applied).To(Equal(3))
+				Expect(response.Records[1].EntityID).To(Equal(records[0].ID))
+
+				finished := result.Result{}
+				err = db.Where("id = ?", records[1].ID).Take(&finished).Error
+				Expect(err).To(BeNil())
+				Expect(finished.SportsmenID).To(Equal(nextSportsmen.ID))
+				Expect(finished.TimeStart).To(Equal(records[1].Time))
+				Expect(*finished.TimeFinish).To(Equal(records[2].Time))
+			})
+		})
+	})
+})
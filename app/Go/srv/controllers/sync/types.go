@@ -0,0 +1,31 @@
+package sync_controller
+
+type SyncRequest struct {
+	Records []RecordRequest `json:"records"`
+}
+
+// RecordRequest is the timing record generated by the device, the ID is generated by the device
+// so that the record is applied once however many times it is sent.
+type RecordRequest struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	Time         int64  `json:"time"`
+	Status       string `json:"status,omitempty"`
+}
+
+type SyncResponse struct {
+	Records    []RecordOutcome `json:"records"`
+	Applied    int             `json:"applied"`
+	Duplicates int             `json:"duplicates"`
+	Rejected   int             `json:"rejected"`
+}
+
+type RecordOutcome struct {
+	ID       string `json:"id"`
+	Outcome  string `json:"outcome"`
+	EntityID string `json:"entity_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
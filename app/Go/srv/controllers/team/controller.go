@@ -0,0 +1,362 @@
+package team_controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/team"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"strconv"
+)
+
+// Maximum number of the teams in the rankings.
+const standingsLimit = 1000
+
+// AddTeam handles the new team request.
+func AddTeam(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := NewTeamRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newTeam := team.PendingTeam{
+			ID:       uuid.Must(uuid.NewV4()),
+			Name:     req.Name,
+			Category: req.Category,
+		}
+
+		for _, leg := range req.Legs {
+			newTeam.Legs = append(newTeam.Legs, team.PendingLeg{
+				SportsmenID:  uuid.Must(uuid.FromString(leg.SportsmenID)),
+				CheckpointID: uuid.Must(uuid.FromString(leg.CheckpointID)),
+			})
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "team.Create")
+		teamCreatedEvent, err := team.Create(db, newTeam)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, teamCreatedEvent.Version)
+		responses.JSON(w, http.StatusOK, CreatedResponse{ID: teamCreatedEvent.TeamID})
+	}
+}
+
+// GetTeam handles the team request.
+func GetTeam(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		teamID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "team.GetTeam")
+		teamFetched, err := team.GetTeam(db, teamID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, teamFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, teamFetched)
+	}
+}
+
+// GetTeams handles the teams request.
+func GetTeams(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "team.GetTeams")
+		teams, err := team.GetTeams(db)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, teams)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, teams)
+	}
+}
+
+// GetTeamResults handles the results request of the team, the individual results of the legs
+// come with the first leg first.
+func GetTeamResults(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		teamID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "team.GetStanding")
+		standing, err := team.GetStanding(db, teamID)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "team.GetLegResults")
+		legResults, err := team.GetLegResults(db, []uuid.UUID{teamID})
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		response := TeamResultsResponse{
+			Standing: *standing,
+			Results:  *legResults,
+		}
+
+		err = etag.SetCollection(w, response)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, response)
+	}
+}
+
+// GetStandings handles the team rankings request, the most legs finished come first.
+func GetStandings(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "team.GetStandings")
+		standings, err := team.GetStandings(db, standingsLimit)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, standings)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, standings)
+	}
+}
+
+// ExportStandings handles the export of the team rankings as the CSV file, every row is the team
+// followed by the individual results of its legs.
+func ExportStandings(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "team.GetStandings")
+		standings, err := team.GetStandings(db, standingsLimit)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		var teamIDs []uuid.UUID
+		for _, standing := range *standings {
+			teamIDs = append(teamIDs, standing.TeamID)
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "team.GetLegResults")
+		legResults, err := team.GetLegResults(db, teamIDs)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, []interface{}{standings, legResults})
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="teams.csv"`)
+		w.WriteHeader(http.StatusOK)
+
+		writer := csv.NewWriter(w)
+		for _, record := range standingsRecords(*standings, *legResults) {
+			// The header is sent already, the failing client is left with the partial file.
+			if err := writer.Write(record); err != nil {
+				return
+			}
+		}
+		writer.Flush()
+	}
+}
+
+// AddHandover handles the passing of the handover checkpoint, the leg of the sportsmen is finished
+// and the next leg is started at the same time.
+func AddHandover(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := HandoverRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		checkpointID := uuid.Must(uuid.FromString(req.CheckpointID))
+
+		identity, err := auth.RequireCheckpoint(r.Context(), checkpointID)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newHandover := team.PendingHandover{
+			ID:           uuid.Must(uuid.NewV4()),
+			CheckpointID: checkpointID,
+			SportsmenID:  uuid.Must(uuid.FromString(req.SportsmenID)),
+			Time:         req.Time,
+			DeviceID:     identity.DeviceID,
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "team.Handover")
+		handedOverEvent, err := team.Handover(db, newHandover)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		server.Dispatcher.Flush(*tracing.DB(r.Context(), server.DB))
+
+		responses.JSON(w, http.StatusOK, HandoverResponse{
+			TeamID:       handedOverEvent.TeamID,
+			Leg:          handedOverEvent.Leg,
+			ResultID:     handedOverEvent.ResultID,
+			NextResultID: handedOverEvent.NextResultID,
+			Finished:     handedOverEvent.Finished,
+		})
+	}
+}
+
+// standingsRecords lays out the team rankings as the CSV records, the times are in milliseconds
+// and the legs not finished yet are left empty.
+func standingsRecords(standings []team.Standing, legResults []team.LegResult) [][]string {
+	legsOfTeam := make(map[uuid.UUID][]team.LegResult)
+	maxLegs := uint32(0)
+	for _, legResult := range legResults {
+		legsOfTeam[legResult.TeamID] = append(legsOfTeam[legResult.TeamID], legResult)
+	}
+	for _, standing := range standings {
+		if standing.Legs > maxLegs {
+			maxLegs = standing.Legs
+		}
+	}
+
+	header := []string{"rank", "team", "category", "legs", "legs_finished", "total_time", "time_start", "time_finish"}
+	for number := uint32(1); number <= maxLegs; number++ {
+		header = append(header,
+			fmt.Sprintf("leg_%d_start_number", number),
+			fmt.Sprintf("leg_%d_name", number),
+			fmt.Sprintf("leg_%d_time", number),
+		)
+	}
+
+	records := [][]string{header}
+	for index, standing := range standings {
+		record := []string{
+			strconv.Itoa(index + 1),
+			standing.Name,
+			standing.Category,
+			strconv.FormatUint(uint64(standing.Legs), 10),
+			strconv.FormatUint(uint64(standing.LegsFinished), 10),
+			strconv.FormatInt(standing.TotalTime, 10),
+			formatTime(standing.TimeStart),
+			formatTime(standing.TimeFinish),
+		}
+
+		for _, legResult := range legsOfTeam[standing.TeamID] {
+			record = append(record,
+				strconv.FormatUint(uint64(legResult.StartNumber), 10),
+				fmt.Sprintf("%s %s", legResult.FirstName, legResult.LastName),
+				formatTime(legResult.LegTime),
+			)
+		}
+
+		// Pad the teams of fewer legs so that every record has the same number of fields.
+		for len(record) < len(header) {
+			record = append(record, "")
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func formatTime(time *int64) string {
+	if time == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*time, 10)
+}
@@ -0,0 +1,337 @@
+package team_controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+)
+
+// asAdmin authenticates the request as the admin the way the auth middleware of the routes does.
+func asAdmin(req *http.Request) *http.Request {
+	return req.WithContext(auth.WithIdentity(req.Context(), auth.Identity{Role: credential.RoleAdmin}))
+}
+
+var _ = Describe("Teams controller", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+	srv.Dispatcher = dispatcher.NewDispatcher()
+
+	var pendingCheckpoint checkpoint.PendingCheckpoint
+	var first, second sportsmen.PendingSportsmen
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+
+		pendingCheckpoint = checkpoint.PendingCheckpoint{
+			ID:   uuid.Must(uuid.NewV4()),
+			Name: "Stadium",
+		}
+
+		_, err := checkpoint.Create(*db, pendingCheckpoint)
+		Expect(err).To(BeNil())
+
+		first = sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			StartNumber: 101,
+		}
+
+		second = sportsmen.PendingSportsmen{
+			ID:          uuid.Must(uuid.NewV4()),
+			FirstName:   "Ivan",
+			LastName:    "Petrov",
+			StartNumber: 102,
+		}
+
+		for _, pendingSportsmen := range []sportsmen.PendingSportsmen{first, second} {
+			_, err = sportsmen.Create(*db, pendingSportsmen)
+			Expect(err).To(BeNil())
+		}
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	Describe("Creating new team", func() {
+		When("New team request is sent", func() {
+			Specify("The response returned", func() {
+				legs := []NewLegRequest{
+					{SportsmenID: first.ID.String(), CheckpointID: pendingCheckpoint.ID.String()},
+					{SportsmenID: second.ID.String(), CheckpointID: pendingCheckpoint.ID.String()},
+				}
+
+				samples := []struct {
+					request      NewTeamRequest
+					statusCode   int
+					errorMessage string
+				}{
+					{
+						request:      NewTeamRequest{Name: "Harriers", Category: "Mixed", Legs: legs},
+						statusCode:   http.StatusOK,
+						errorMessage: "",
+					},
+					{
+						request:      NewTeamRequest{Name: "Harriers", Legs: legs},
+						statusCode:   http.StatusConflict,
+						errorMessage: "Team already exists",
+					},
+					{
+						request:      NewTeamRequest{Name: "Joggers", Legs: legs},
+						statusCode:   http.StatusConflict,
+						errorMessage: "Sportsmen is assigned to the leg at the checkpoint already",
+					},
+					{
+						request:      NewTeamRequest{Legs: legs},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "name: cannot be blank.",
+					},
+					{
+						request:      NewTeamRequest{Name: "Joggers"},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "legs: cannot be blank.",
+					},
+					{
+						request:      NewTeamRequest{Name: "Joggers", Legs: []NewLegRequest{{SportsmenID: first.ID.String()}}},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "legs: (0: (checkpoint_id: cannot be blank.).).",
+					},
+				}
+
+				for _, s := range samples {
+					requestBody, err := json.Marshal(s.request)
+					Expect(err).To(gomega.BeNil())
+
+					req, err := http.NewRequest("POST", "/teams", bytes.NewBuffer(requestBody))
+					Expect(err).To(gomega.BeNil())
+					req = asAdmin(req)
+
+					rr := httptest.NewRecorder()
+					AddTeam(&srv).ServeHTTP(rr, req)
+
+					responseMap := make(map[string]interface{})
+
+					err = json.Unmarshal([]byte(rr.Body.String()), &responseMap)
+					Expect(err).To(gomega.BeNil())
+
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code == 200 {
+						Expect(responseMap["id"]).ToNot(Equal(""))
+					}
+
+					if rr.Code != 200 {
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+	})
+
+	Describe("Handing over", func() {
+		var teamID uuid.UUID
+		timeStart := utils.MakeTimestampInMilliseconds()
+
+		BeforeEach(func() {
+			teamID = uuid.Must(uuid.NewV4())
+			_, err := team.Create(*db, team.PendingTeam{
+				ID:       teamID,
+				Name:     "Harriers",
+				Category: "Mixed",
+				Legs: []team.PendingLeg{
+					{SportsmenID: first.ID, CheckpointID: pendingCheckpoint.ID},
+					{SportsmenID: second.ID, CheckpointID: pendingCheckpoint.ID},
+				},
+			})
+			Expect(err).To(BeNil())
+
+			_, err = result.Create(*db, result.PendingResult{
+				ID:           uuid.Must(uuid.NewV4()),
+				CheckpointID: pendingCheckpoint.ID,
+				SportsmenID:  first.ID,
+				TimeStart:    timeStart,
+			})
+			Expect(err).To(BeNil())
+		})
+
+		// handover sends the handover request of the sportsmen.
+		handover := func(req HandoverRequest) *httptest.ResponseRecorder {
+			requestBody, err := json.Marshal(req)
+			Expect(err).To(BeNil())
+
+			r, err := http.NewRequest("POST", "/handovers", bytes.NewBuffer(requestBody))
+			Expect(err).To(BeNil())
+			r = asAdmin(r)
+
+			rr := httptest.NewRecorder()
+			AddHandover(&srv).ServeHTTP(rr, r)
+
+			return rr
+		}
+
+		When("Handover request is sent", func() {
+			Specify("The response returned", func() {
+				samples := []struct {
+					request      HandoverRequest
+					statusCode   int
+					errorMessage string
+					response     HandoverResponse
+				}{
+					{
+						request:      HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: second.ID.String(), Time: timeStart + 300000},
+						statusCode:   http.StatusNotFound,
+						errorMessage: "Result does not exist",
+					},
+					{
+						request:    HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: first.ID.String(), Time: timeStart + 300000},
+						statusCode: http.StatusOK,
+						response:   HandoverResponse{TeamID: teamID.String(), Leg: 1},
+					},
+					{
+						request:      HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: first.ID.String(), Time: timeStart + 310000},
+						statusCode:   http.StatusConflict,
+						errorMessage: "Result has finish time already",
+					},
+					{
+						request:    HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: second.ID.String(), Time: timeStart + 700000},
+						statusCode: http.StatusOK,
+						response:   HandoverResponse{TeamID: teamID.String(), Leg: 2, Finished: true},
+					},
+					{
+						request:      HandoverRequest{CheckpointID: uuid.Must(uuid.NewV4()).String(), SportsmenID: first.ID.String(), Time: timeStart + 300000},
+						statusCode:   http.StatusNotFound,
+						errorMessage: "Leg of the sportsmen at the checkpoint does not exist",
+					},
+					{
+						request:      HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: first.ID.String()},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "time: cannot be blank.",
+					},
+				}
+
+				for _, s := range samples {
+					rr := handover(s.request)
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code == 200 {
+						res := HandoverResponse{}
+						err := json.Unmarshal(rr.Body.Bytes(), &res)
+						Expect(err).To(gomega.BeNil())
+						Expect(res.ResultID).ToNot(BeEmpty())
+
+						s.response.ResultID = res.ResultID
+						s.response.NextResultID = res.NextResultID
+						Expect(res).To(Equal(s.response))
+						Expect(res.NextResultID == "").To(Equal(res.Finished))
+					}
+
+					if rr.Code != 200 {
+						responseMap := make(map[string]interface{})
+
+						err := json.Unmarshal([]byte(rr.Body.String()), &responseMap)
+						Expect(err).To(gomega.BeNil())
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+
+		When("Team results request is sent", func() {
+			Specify("The team and the leg results are returned", func() {
+				rr := handover(HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: first.ID.String(), Time: timeStart + 300000})
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				req, err := http.NewRequest("GET", "/teams/"+teamID.String()+"/results", nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": teamID.String()})
+
+				rr = httptest.NewRecorder()
+				GetTeamResults(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				response := TeamResultsResponse{}
+				err = json.Unmarshal(rr.Body.Bytes(), &response)
+				Expect(err).To(BeNil())
+				Expect(response.TeamID).To(Equal(teamID))
+				Expect(response.LegsFinished).To(Equal(uint32(1)))
+				Expect(response.TotalTime).To(Equal(int64(300000)))
+				Expect(response.Results).To(HaveLen(2))
+				Expect(*response.Results[0].LegTime).To(Equal(int64(300000)))
+				Expect(*response.Results[1].TimeStart).To(Equal(timeStart + 300000))
+				Expect(response.Results[1].LegTime).To(BeNil())
+			})
+		})
+
+		When("Team rankings are exported", func() {
+			Specify("The CSV file is returned with the legs of the teams", func() {
+				rr := handover(HandoverRequest{CheckpointID: pendingCheckpoint.ID.String(), SportsmenID: first.ID.String(), Time: timeStart + 300000})
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				req, err := http.NewRequest("GET", "/exports/teams", nil)
+				Expect(err).To(BeNil())
+
+				rr = httptest.NewRecorder()
+				ExportStandings(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get("Content-Type")).To(Equal("text/csv; charset=utf-8"))
+
+				records, err := csv.NewReader(rr.Body).ReadAll()
+				Expect(err).To(BeNil())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0]).To(Equal([]string{
+					"rank", "team", "category", "legs", "legs_finished", "total_time", "time_start", "time_finish",
+					"leg_1_start_number", "leg_1_name", "leg_1_time",
+					"leg_2_start_number", "leg_2_name", "leg_2_time",
+				}))
+				Expect(records[1][:6]).To(Equal([]string{"1", "Harriers", "Mixed", "2", "1", "300000"}))
+				Expect(records[1][7]).To(BeEmpty())
+				Expect(records[1][8:]).To(Equal([]string{"101", "Vladimir Andrianov", "300000", "102", "Ivan Petrov", ""}))
+			})
+		})
+	})
+})
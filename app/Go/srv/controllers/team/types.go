@@ -0,0 +1,68 @@
+package team_controller
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"sports/backend/domain/models/team"
+)
+
+// NewTeamRequest is the relay team, the members are assigned to the legs in the given order.
+type NewTeamRequest struct {
+	Name     string          `json:"name"`
+	Category string          `json:"category"`
+	Legs     []NewLegRequest `json:"legs"`
+}
+
+func (req NewTeamRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Name, validation.Required),
+		validation.Field(&req.Legs, validation.Required),
+	)
+}
+
+// NewLegRequest assigns the sportsmen to the leg timed at the checkpoint.
+type NewLegRequest struct {
+	SportsmenID  string `json:"sportsmen_id"`
+	CheckpointID string `json:"checkpoint_id"`
+}
+
+func (req NewLegRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+	)
+}
+
+// HandoverRequest is the passing of the handover checkpoint ending the leg of the sportsmen.
+type HandoverRequest struct {
+	CheckpointID string `json:"checkpoint_id"`
+	SportsmenID  string `json:"sportsmen_id"`
+	Time         int64  `json:"time"`
+}
+
+func (req HandoverRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.CheckpointID, validation.Required, is.UUIDv4),
+		validation.Field(&req.SportsmenID, validation.Required, is.UUIDv4),
+		validation.Field(&req.Time, validation.Required),
+	)
+}
+
+type CreatedResponse struct {
+	ID string `json:"id"`
+}
+
+// HandoverResponse is the leg handed over, the next result is empty when the handover has finished the team.
+type HandoverResponse struct {
+	TeamID       string `json:"team_id"`
+	Leg          uint32 `json:"leg"`
+	ResultID     string `json:"result_id"`
+	NextResultID string `json:"next_result_id,omitempty"`
+	Finished     bool   `json:"finished"`
+}
+
+// TeamResultsResponse is the team result with the individual results of its legs.
+type TeamResultsResponse struct {
+	team.Standing
+	Results []team.LegResult `json:"results"`
+}
@@ -0,0 +1,224 @@
+package webhook_controller
+
+import (
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"sports/backend/srv/utils"
+)
+
+// Number of the latest deliveries returned in the delivery log.
+const deliveriesLimit = 100
+
+// AddWebhook handles the new webhook request, the secret is not returned afterwards.
+func AddWebhook(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		req := NewWebhookRequest{}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = req.Validate()
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		newSubscription := webhook.PendingSubscription{
+			ID:         uuid.Must(uuid.NewV4()),
+			URL:        req.URL,
+			Secret:     req.Secret,
+			EventTypes: req.EventTypes,
+			Category:   req.Filter.Category,
+		}
+
+		if req.Filter.CheckpointID != "" {
+			checkpointID := uuid.Must(uuid.FromString(req.Filter.CheckpointID))
+			newSubscription.CheckpointID = &checkpointID
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "webhook.Create")
+		subscriptionCreatedEvent, err := webhook.Create(db, newSubscription)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, subscriptionCreatedEvent.Version)
+		responses.JSON(w, http.StatusOK, CreatedResponse{ID: subscriptionCreatedEvent.SubscriptionID})
+	}
+}
+
+// RemoveWebhook handles the webhook removal request, its queued deliveries are not sent any more.
+func RemoveWebhook(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscriptionID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		version, err := etag.IfMatch(r)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "webhook.GetSubscription")
+		subscriptionFetched, err := webhook.GetSubscription(db, subscriptionID, version)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "webhook.Remove")
+		subscriptionRemovedEvent, err := webhook.Remove(db, utils.MakeTimestampInMilliseconds(), *subscriptionFetched)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, subscriptionRemovedEvent.Version)
+		responses.JSON(w, http.StatusOK, nil)
+	}
+}
+
+// GetWebhook handles the webhook request.
+func GetWebhook(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscriptionID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "webhook.GetSubscription")
+		subscriptionFetched, err := webhook.GetSubscription(db, subscriptionID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, subscriptionFetched.Version)
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, subscriptionFetched)
+	}
+}
+
+// GetWebhooks handles the webhooks request.
+func GetWebhooks(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		db, end := tracing.Command(r.Context(), server.DB, "webhook.GetSubscriptions")
+		subscriptions, err := webhook.GetSubscriptions(db)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		err = etag.SetCollection(w, subscriptions)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		if etag.NotModified(w, r) {
+			return
+		}
+
+		responses.JSON(w, http.StatusOK, subscriptions)
+	}
+}
+
+// GetDeliveries handles the delivery log request of the webhook, the latest deliveries come first.
+func GetDeliveries(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscriptionID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "webhook.GetSubscription")
+		_, err = webhook.GetSubscription(db, subscriptionID, nil)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "webhook.GetDeliveries")
+		deliveries, err := webhook.GetDeliveries(db, subscriptionID, deliveriesLimit)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		res := []DeliveryResponse{}
+		for _, delivery := range *deliveries {
+			res = append(res, DeliveryResponse{Delivery: delivery, Payload: delivery.Payload})
+		}
+
+		responses.JSON(w, http.StatusOK, res)
+	}
+}
+
+// Redeliver handles the manual redelivery request, the delivery is sent again with the fresh attempts
+// whatever its status is.
+func Redeliver(server *server.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscriptionID, err := uuid.FromString(mux.Vars(r)["id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		deliveryID, err := uuid.FromString(mux.Vars(r)["delivery_id"])
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+
+		db, end := tracing.Command(r.Context(), server.DB, "webhook.GetDelivery")
+		deliveryFetched, err := webhook.GetDelivery(db, subscriptionID, deliveryID)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		db, end = tracing.Command(r.Context(), server.DB, "webhook.Redeliver")
+		deliveryRedeliveredEvent, err := webhook.Redeliver(db, utils.MakeTimestampInMilliseconds(), *deliveryFetched)
+		end(err)
+		if err != nil {
+			responses.ERROR(w, err)
+			return
+		}
+
+		etag.SetVersion(w, deliveryRedeliveredEvent.Version)
+		responses.JSON(w, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,285 @@
+package webhook_controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+)
+
+var _ = Describe("Webhooks controller", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	srv := server.Server{}
+	srv.Addr = cfg.APIAddress
+	srv.DB = conn
+	srv.Router = mux.NewRouter()
+
+	BeforeEach(func() {
+		db = conn.Begin()
+		srv.DB = db
+	})
+
+	AfterEach(func() {
+		_ = db.Rollback()
+	})
+
+	// addWebhook subscribes the webhook to the finishes and returns its ID.
+	addWebhook := func() string {
+		requestBody, err := json.Marshal(NewWebhookRequest{
+			URL:        "https://example.com/hooks/finish",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{result.TopicFinished},
+		})
+		Expect(err).To(BeNil())
+
+		req, err := http.NewRequest("POST", "/webhooks", bytes.NewBuffer(requestBody))
+		Expect(err).To(BeNil())
+
+		rr := httptest.NewRecorder()
+		AddWebhook(&srv).ServeHTTP(rr, req)
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		created := CreatedResponse{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &created)).To(Succeed())
+		return created.ID
+	}
+
+	Describe("Creating new webhook", func() {
+		When("New webhook request is sent", func() {
+			Specify("The response returned", func() {
+				samples := []struct {
+					request      NewWebhookRequest
+					statusCode   int
+					errorMessage string
+				}{
+					{
+						request:    NewWebhookRequest{URL: "https://example.com/hooks", Secret: "0123456789abcdef", EventTypes: []string{result.TopicFinished}},
+						statusCode: http.StatusOK,
+					},
+					{
+						request:      NewWebhookRequest{Secret: "0123456789abcdef", EventTypes: []string{result.TopicFinished}},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "url: cannot be blank.",
+					},
+					{
+						request:      NewWebhookRequest{URL: "ftp://example.com/hooks", Secret: "0123456789abcdef", EventTypes: []string{result.TopicFinished}},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "url: must be an http or https URL.",
+					},
+					{
+						request:      NewWebhookRequest{URL: "https://example.com/hooks", Secret: "short", EventTypes: []string{result.TopicFinished}},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "secret: the length must be no less than 16.",
+					},
+					{
+						request:      NewWebhookRequest{URL: "https://example.com/hooks", Secret: "0123456789abcdef", EventTypes: []string{"result.deleted"}},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "event_types: (0: must be a valid value.).",
+					},
+					{
+						request:      NewWebhookRequest{URL: "https://example.com/hooks", Secret: "0123456789abcdef", EventTypes: []string{result.TopicFinished}, Filter: WebhookFilter{CheckpointID: "start"}},
+						statusCode:   http.StatusUnprocessableEntity,
+						errorMessage: "filter.checkpoint_id: must be a valid UUID v4.",
+					},
+				}
+
+				for _, s := range samples {
+					requestBody, err := json.Marshal(s.request)
+					Expect(err).To(BeNil())
+
+					req, err := http.NewRequest("POST", "/webhooks", bytes.NewBuffer(requestBody))
+					Expect(err).To(BeNil())
+
+					rr := httptest.NewRecorder()
+					AddWebhook(&srv).ServeHTTP(rr, req)
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					responseMap := make(map[string]interface{})
+					Expect(json.Unmarshal(rr.Body.Bytes(), &responseMap)).To(Succeed())
+
+					if rr.Code == http.StatusOK {
+						Expect(responseMap["id"]).ToNot(Equal(""))
+					} else {
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+
+		When("The webhook is fetched", func() {
+			Specify("The secret is not returned", func() {
+				id := addWebhook()
+
+				req, err := http.NewRequest("GET", "/webhooks/"+id, nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": id})
+
+				rr := httptest.NewRecorder()
+				GetWebhook(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+				Expect(rr.Header().Get("ETag")).To(Equal(`"1"`))
+
+				responseMap := make(map[string]interface{})
+				Expect(json.Unmarshal(rr.Body.Bytes(), &responseMap)).To(Succeed())
+				Expect(responseMap["url"]).To(Equal("https://example.com/hooks/finish"))
+				Expect(responseMap).ToNot(HaveKey("secret"))
+			})
+		})
+	})
+
+	Describe("Removing webhook", func() {
+		When("Remove request is sent", func() {
+			Specify("The response returned", func() {
+				id := addWebhook()
+
+				samples := []struct {
+					id           string
+					ifMatch      string
+					statusCode   int
+					errorMessage string
+				}{
+					{
+						id:           id,
+						statusCode:   http.StatusPreconditionRequired,
+						errorMessage: "If-Match: the entity tag of the updated version is required",
+					},
+					{
+						id:         id,
+						ifMatch:    `"1"`,
+						statusCode: http.StatusOK,
+					},
+					{
+						id:           id,
+						ifMatch:      "*",
+						statusCode:   http.StatusConflict,
+						errorMessage: "Webhook has been removed already",
+					},
+					{
+						id:           uuid.Must(uuid.NewV4()).String(),
+						ifMatch:      `"1"`,
+						statusCode:   http.StatusNotFound,
+						errorMessage: "Webhook not found: Webhook does not exist",
+					},
+				}
+
+				for _, s := range samples {
+					req, err := http.NewRequest("DELETE", "/webhooks/"+s.id, nil)
+					Expect(err).To(BeNil())
+					req = mux.SetURLVars(req, map[string]string{"id": s.id})
+					if s.ifMatch != "" {
+						req.Header.Set("If-Match", s.ifMatch)
+					}
+
+					rr := httptest.NewRecorder()
+					RemoveWebhook(&srv).ServeHTTP(rr, req)
+					Expect(rr.Code).To(Equal(s.statusCode))
+
+					if rr.Code != http.StatusOK {
+						responseMap := make(map[string]interface{})
+						Expect(json.Unmarshal(rr.Body.Bytes(), &responseMap)).To(Succeed())
+						Expect(responseMap["detail"]).To(Equal(s.errorMessage))
+					}
+				}
+			})
+		})
+	})
+
+	Describe("Delivery log", func() {
+		When("The failed delivery is redelivered", func() {
+			Specify("It is pending with the fresh attempts", func() {
+				id := addWebhook()
+				subscriptionID := uuid.Must(uuid.FromString(id))
+
+				queued, err := webhook.Queue(*db, webhook.PendingDelivery{
+					ID:             uuid.Must(uuid.NewV4()),
+					SubscriptionID: subscriptionID,
+					MessageID:      uuid.Must(uuid.NewV4()),
+					EventType:      result.TopicFinished,
+					Payload:        []byte(`{"type": "result.finished"}`),
+					NextAttemptAt:  utils.MakeTimestampInMilliseconds(),
+				})
+				Expect(err).To(BeNil())
+
+				delivery, err := webhook.GetDelivery(*db, subscriptionID, uuid.Must(uuid.FromString(queued.DeliveryID)))
+				Expect(err).To(BeNil())
+				_, err = webhook.Fail(*db, "503 Service Unavailable", http.StatusServiceUnavailable, *delivery)
+				Expect(err).To(BeNil())
+
+				req, err := http.NewRequest("GET", "/webhooks/"+id+"/deliveries", nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": id})
+
+				rr := httptest.NewRecorder()
+				GetDeliveries(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				deliveries := []map[string]interface{}{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &deliveries)).To(Succeed())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0]["status"]).To(Equal(webhook.StatusFailed))
+				Expect(deliveries[0]["response_status"]).To(BeEquivalentTo(http.StatusServiceUnavailable))
+				Expect(deliveries[0]["payload"]).To(Equal(map[string]interface{}{"type": "result.finished"}))
+
+				req, err = http.NewRequest("POST", "/webhooks/"+id+"/deliveries/"+queued.DeliveryID+"/redeliver", nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": id, "delivery_id": queued.DeliveryID})
+
+				rr = httptest.NewRecorder()
+				Redeliver(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusOK))
+
+				delivery, err = webhook.GetDelivery(*db, subscriptionID, uuid.Must(uuid.FromString(queued.DeliveryID)))
+				Expect(err).To(BeNil())
+				Expect(delivery.Status).To(Equal(webhook.StatusPending))
+				Expect(delivery.Attempts).To(Equal(uint32(0)))
+			})
+		})
+
+		When("The delivery of another webhook is redelivered", func() {
+			Specify("It is not found", func() {
+				id := addWebhook()
+				deliveryID := uuid.Must(uuid.NewV4()).String()
+
+				req, err := http.NewRequest("POST", "/webhooks/"+id+"/deliveries/"+deliveryID+"/redeliver", nil)
+				Expect(err).To(BeNil())
+				req = mux.SetURLVars(req, map[string]string{"id": id, "delivery_id": deliveryID})
+
+				rr := httptest.NewRecorder()
+				Redeliver(&srv).ServeHTTP(rr, req)
+				Expect(rr.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package webhook_controller
+
+import (
+	"encoding/json"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"sports/backend/domain/models/webhook"
+)
+
+type NewWebhookRequest struct {
+	URL        string        `json:"url"`
+	Secret     string        `json:"secret"`
+	EventTypes []string      `json:"event_types"`
+	Filter     WebhookFilter `json:"filter"`
+}
+
+// WebhookFilter narrows the events posted to the webhook, the empty fields pass all of them.
+type WebhookFilter struct {
+	CheckpointID string `json:"checkpoint_id"`
+	Category     string `json:"category"`
+}
+
+func (req NewWebhookRequest) Validate() error {
+	if err := validation.ValidateStruct(&req,
+		validation.Field(&req.URL, validation.Required, is.URL),
+		validation.Field(&req.Secret, validation.Required),
+		validation.Field(&req.EventTypes, validation.Required, validation.Each(validation.In(webhook.EventTypes...))),
+	); err != nil {
+		return err
+	}
+
+	if err := validation.Validate(req.Filter.CheckpointID, is.UUIDv4); err != nil {
+		return validation.Errors{"filter.checkpoint_id": err}
+	}
+
+	return nil
+}
+
+type CreatedResponse struct {
+	ID string `json:"id"`
+}
+
+// DeliveryResponse is the logged delivery with the posted JSON body.
+type DeliveryResponse struct {
+	webhook.Delivery
+	Payload json.RawMessage `json:"payload"`
+}
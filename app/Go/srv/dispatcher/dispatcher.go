@@ -0,0 +1,247 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"go.uber.org/zap"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/transaction"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/utils"
+	"sync"
+	"time"
+)
+
+// Defaults of the dispatcher settings.
+const (
+	DefaultInterval    = time.Second
+	DefaultBatchSize   = 100
+	DefaultMaxAttempts = 10
+	DefaultRetention   = 24 * time.Hour
+)
+
+// Interval the published messages older than the retention are removed at.
+const cleanUpInterval = time.Hour
+
+// Subscriber handles the outbox message within the dispatch transaction, the message is retried later when
+// the error is returned. The message may be handled again, e.g. after the crash, so the subscribers tolerate it.
+type Subscriber func(tx gorm.DB, message outbox.Message) error
+
+// Notifier prepares the notification of the outbox message within the dispatch transaction, e.g. loads the data
+// the message refers to. The notification is sent once the transaction has committed, so the message whose
+// dispatch is rolled back is not notified until it is dispatched again.
+type Notifier func(tx gorm.DB, message outbox.Message) (func(), error)
+
+type subscription struct {
+	name       string
+	subscriber Subscriber
+	notifier   Notifier
+}
+
+// Dispatcher publishes the outbox messages to the subscribers of their topics at least once. The delivery to every
+// subscriber is recorded, so the message retried for the failed subscriber is not delivered to the others again.
+// The messages of the aggregate are delivered in the order they have been appended, the later ones wait for
+// the failed one until it is delivered or given up after MaxAttempts.
+type Dispatcher struct {
+	// Interval the pending messages are polled at, e.g. the ones left behind by the crash or by the failed subscriber.
+	Interval time.Duration
+	// BatchSize is the number of the messages dispatched in one transaction.
+	BatchSize int
+	// MaxAttempts is the number of the failed attempts after which the message is given up and kept for inspection.
+	MaxAttempts uint32
+	// Retention is the time the published messages are kept for.
+	Retention time.Duration
+
+	subscriptions map[string][]subscription
+	// mutex lets one dispatch run at a time, so that the flushes and the polling keep the order of the messages.
+	mutex sync.Mutex
+}
+
+// NewDispatcher creates the dispatcher with the default settings and no subscribers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		Interval:      DefaultInterval,
+		BatchSize:     DefaultBatchSize,
+		MaxAttempts:   DefaultMaxAttempts,
+		Retention:     DefaultRetention,
+		subscriptions: make(map[string][]subscription),
+	}
+}
+
+// Subscribe the named subscriber to the messages of the topic, the name identifies its deliveries
+// so it must not change between the restarts. The subscribers are added before the dispatcher runs.
+func (d *Dispatcher) Subscribe(topic, name string, subscriber Subscriber) {
+	d.subscriptions[topic] = append(d.subscriptions[topic], subscription{name: name, subscriber: subscriber})
+}
+
+// Notify the named notifier of the messages of the topic after the dispatch has committed, the name identifies
+// its deliveries like the name of the subscriber. The notifiers are added before the dispatcher runs.
+func (d *Dispatcher) Notify(topic, name string, notifier Notifier) {
+	d.subscriptions[topic] = append(d.subscriptions[topic], subscription{name: name, notifier: notifier})
+}
+
+// Run polls the pending messages and removes the published ones after the retention until the context is done.
+func (d *Dispatcher) Run(ctx context.Context, db *gorm.DB) {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	cleanUp := time.NewTicker(cleanUpInterval)
+	defer cleanUp.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanUp.C:
+			before := utils.MakeTimestampInMilliseconds() - d.Retention.Milliseconds()
+			deleted, err := outbox.DeletePublished(*db, before)
+			if err != nil {
+				zap.S().Errorf("Error deleting published outbox messages: %v", err)
+				continue
+			}
+			zap.S().Infof("Deleted %d published outbox messages", deleted)
+		case <-ticker.C:
+			for {
+				published, err := d.Dispatch(*db)
+				if err != nil {
+					zap.S().Errorf("Error dispatching outbox messages: %v", err)
+					break
+				} else if published < d.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Flush dispatches the pending messages right away, e.g. the ones appended by the request which has just committed,
+// the failure is logged and the messages are left to the polling.
+func (d *Dispatcher) Flush(db gorm.DB) {
+	if _, err := d.Dispatch(db); err != nil {
+		zap.S().Errorf("Error flushing outbox messages: %v", err)
+	}
+}
+
+// Dispatch delivers the batch of the pending messages to the subscribers, the number of the messages
+// published to all their subscribers is returned.
+func (d *Dispatcher) Dispatch(db gorm.DB) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var published int
+	var notifications []func()
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		published = 0
+		notifications = nil
+
+		messages, err := outbox.GetPendingMessages(tx, d.BatchSize, d.MaxAttempts)
+		if err != nil {
+			return err
+		}
+
+		// Aggregates which have the earlier message failed, their later messages wait for it.
+		blocked := make(map[uuid.UUID]bool)
+
+		for _, message := range *messages {
+			if blocked[message.AggregateID] {
+				continue
+			}
+
+			if err := d.deliver(tx, message, &notifications); err != nil {
+				blocked[message.AggregateID] = true
+
+				failed, markErr := outbox.MarkFailed(tx, err.Error(), message)
+				if markErr != nil {
+					return markErr
+				}
+
+				if failed.Attempts >= d.MaxAttempts {
+					zap.S().Errorf("Outbox message %s of %s given up after %d attempts: %v", message.ID, message.Topic, failed.Attempts, err)
+				} else {
+					zap.S().Warnf("Outbox message %s of %s failed, attempt %d: %v", message.ID, message.Topic, failed.Attempts, err)
+				}
+				continue
+			}
+
+			if _, err := outbox.MarkPublished(tx, utils.MakeTimestampInMilliseconds(), message); err != nil {
+				return err
+			}
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, notify := range notifications {
+		notify()
+	}
+
+	return published, nil
+}
+
+// deliver hands the message to the subscribers of its topic it has not been delivered to yet,
+// every subscriber runs in its own savepoint so the failed one does not undo the others.
+// The notifications prepared by the notifiers are added to be sent after the commit.
+func (d *Dispatcher) deliver(tx gorm.DB, message outbox.Message, notifications *[]func()) error {
+	subscriptions := d.subscriptions[message.Topic]
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	delivered, err := outbox.GetSubscribers(tx, message.ID)
+	if err != nil {
+		return err
+	}
+
+	var failed error
+	for _, s := range subscriptions {
+		if contains(delivered, s.name) {
+			continue
+		}
+
+		var notification func()
+		err := transaction.Run(tx, func(tx gorm.DB) error {
+			var err error
+			if s.notifier != nil {
+				notification, err = s.notifier(tx, message)
+			} else {
+				err = s.subscriber(tx, message)
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = outbox.Deliver(tx, s.name, utils.MakeTimestampInMilliseconds(), message)
+			return err
+		})
+		if err != nil {
+			metrics.OutboxDeliveries.WithLabelValues(s.name, metrics.OutcomeFailed).Inc()
+			if failed == nil {
+				failed = fmt.Errorf("%s: %w", s.name, err)
+			}
+			continue
+		}
+
+		metrics.OutboxDeliveries.WithLabelValues(s.name, metrics.OutcomeDelivered).Inc()
+		if notification != nil {
+			*notifications = append(*notifications, notification)
+		}
+	}
+
+	return failed
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
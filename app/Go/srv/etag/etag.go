@@ -0,0 +1,94 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Missing signifies the update request is sent without the If-Match precondition.
+	Missing struct{}
+
+	// Malformed signifies the If-Match precondition is not the entity tag issued by the API.
+	Malformed struct {
+		Value string
+	}
+)
+
+func (err Missing) Error() string {
+	return "If-Match: the entity tag of the updated version is required"
+}
+
+func (err Malformed) Error() string {
+	return fmt.Sprintf("If-Match: invalid entity tag %s", err.Value)
+}
+
+// Format returns the entity tag of the entity version.
+func Format(version uint32) string {
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10))
+}
+
+// SetVersion writes the entity tag of the entity version to the response.
+func SetVersion(w http.ResponseWriter, version uint32) {
+	w.Header().Set("ETag", Format(version))
+}
+
+// SetCollection writes the weak entity tag of the collection to the response,
+// the tag changes whenever any entity of the collection is added, removed or updated.
+func SetCollection(w http.ResponseWriter, collection interface{}) error {
+	b, err := json.Marshal(collection)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(b)
+	w.Header().Set("ETag", fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:16])))
+	return nil
+}
+
+// NotModified answers 304 when the If-None-Match precondition matches the entity tag of the response.
+func NotModified(w http.ResponseWriter, r *http.Request) bool {
+	tag := w.Header().Get("ETag")
+	if tag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		candidate = strings.TrimSpace(candidate)
+		// Weak comparison, the weak tag matches the same strong one.
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IfMatch returns the version the update request is conditioned on, nil for "*" matching any version.
+func IfMatch(r *http.Request) (*uint32, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return nil, Missing{}
+	} else if value == "*" {
+		return nil, nil
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, Malformed{Value: value}
+	}
+
+	version, err := strconv.ParseUint(unquoted, 10, 32)
+	if err != nil {
+		return nil, Malformed{Value: value}
+	}
+
+	v := uint32(version)
+	return &v, nil
+}
@@ -0,0 +1,75 @@
+package etag_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"sports/backend/srv/etag"
+)
+
+var _ = Describe("Entity tags", func() {
+	Describe("Reading If-Match precondition", func() {
+		samples := []struct {
+			Header  string
+			Version *uint32
+			Err     error
+		}{
+			{Header: "", Err: etag.Missing{}},
+			{Header: "*"},
+			{Header: `"3"`, Version: func() *uint32 { v := uint32(3); return &v }()},
+			{Header: "3", Err: etag.Malformed{Value: "3"}},
+			{Header: `W/"3"`, Err: etag.Malformed{Value: `W/"3"`}},
+			{Header: `"abc"`, Err: etag.Malformed{Value: `"abc"`}},
+		}
+
+		Specify("the version or the error returned", func() {
+			for _, sample := range samples {
+				req := httptest.NewRequest("DELETE", "/devices/1", nil)
+				if sample.Header != "" {
+					req.Header.Set("If-Match", sample.Header)
+				}
+
+				version, err := etag.IfMatch(req)
+				if sample.Err != nil {
+					Expect(err).To(Equal(sample.Err), sample.Header)
+					continue
+				}
+
+				Expect(err).To(BeNil(), sample.Header)
+				Expect(version).To(Equal(sample.Version), sample.Header)
+			}
+		})
+	})
+
+	Describe("Answering If-None-Match precondition", func() {
+		When("the tag matches", func() {
+			Specify("304 returned", func() {
+				rr := httptest.NewRecorder()
+				etag.SetVersion(rr, 2)
+
+				req := httptest.NewRequest("GET", "/devices/1", nil)
+				req.Header.Set("If-None-Match", `"1", "2"`)
+
+				Expect(etag.NotModified(rr, req)).To(BeTrue())
+				Expect(rr.Code).To(Equal(http.StatusNotModified))
+			})
+		})
+
+		When("the collection has changed", func() {
+			Specify("the request passed through", func() {
+				rr := httptest.NewRecorder()
+				Expect(etag.SetCollection(rr, []string{"a"})).To(BeNil())
+				previous := rr.Header().Get("ETag")
+
+				rr = httptest.NewRecorder()
+				Expect(etag.SetCollection(rr, []string{"a", "b"})).To(BeNil())
+
+				req := httptest.NewRequest("GET", "/devices", nil)
+				req.Header.Set("If-None-Match", previous)
+
+				Expect(etag.NotModified(rr, req)).To(BeFalse())
+			})
+		})
+	})
+})
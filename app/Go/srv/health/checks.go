@@ -0,0 +1,141 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"strings"
+)
+
+// Database checks the database is reachable.
+func Database(db *gorm.DB) Check {
+	return func(ctx context.Context) error {
+		return db.DB().PingContext(ctx)
+	}
+}
+
+// Queries of the schema objects present in the current schema, the objects are named the way schemaOf names them.
+const (
+	columnsQuery     = `SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA()`
+	indexesQuery     = `SELECT tablename, indexname FROM pg_indexes WHERE schemaname = CURRENT_SCHEMA()`
+	primaryKeysQuery = `SELECT table_name, 'primary key' FROM information_schema.table_constraints WHERE table_schema = CURRENT_SCHEMA() AND constraint_type = 'PRIMARY KEY'`
+)
+
+// table lists the schema objects of the model the code relies on.
+type table struct {
+	name    string
+	objects []string
+}
+
+// Migrations checks the schema objects of the models exist: the tables, the columns, the primary keys
+// the idempotency keys and the outbox deliveries are unique by, and the indexes, e.g. the unique index
+// rejecting the duplicate results. The schema is read on every probe, so the server whose schema has lost
+// the object, e.g. restored from the old backup, is not routed the requests.
+func Migrations(db *gorm.DB, models ...interface{}) Check {
+	tables := make([]table, 0, len(models))
+	for _, model := range models {
+		tables = append(tables, schemaOf(db.NewScope(model)))
+	}
+
+	return func(ctx context.Context) error {
+		present := make(map[string]bool)
+		for _, query := range []string{columnsQuery, indexesQuery, primaryKeysQuery} {
+			err := readObjects(ctx, db, query, present)
+			if err != nil {
+				return err
+			}
+		}
+
+		var missing []string
+		for _, t := range tables {
+			if !present[t.name] {
+				missing = append(missing, t.name)
+				continue
+			}
+
+			for _, object := range t.objects {
+				if !present[t.name+"."+object] {
+					missing = append(missing, t.name+"."+object)
+				}
+			}
+		}
+
+		if len(missing) > 0 {
+			return fmt.Errorf("not migrated: %s", strings.Join(missing, ", "))
+		}
+
+		return nil
+	}
+}
+
+// readObjects adds the table and its object of every row of the query to the present objects.
+func readObjects(ctx context.Context, db *gorm.DB, query string, present map[string]bool) error {
+	rows, err := db.DB().QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("reading the schema: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, object string
+		if err := rows.Scan(&tableName, &object); err != nil {
+			return fmt.Errorf("reading the schema: %w", err)
+		}
+
+		present[tableName] = true
+		present[tableName+"."+object] = true
+	}
+
+	return rows.Err()
+}
+
+// schemaOf returns the columns, the primary key and the indexes declared by the model.
+func schemaOf(scope *gorm.Scope) table {
+	t := table{name: scope.TableName()}
+
+	for _, field := range scope.GetModelStruct().StructFields {
+		if field.IsIgnored || !field.IsNormal {
+			continue
+		}
+
+		t.objects = append(t.objects, field.DBName)
+	}
+
+	if len(scope.PrimaryFields()) > 0 {
+		t.objects = append(t.objects, "primary key")
+	}
+
+	t.objects = append(t.objects, indexesOf(scope)...)
+
+	return t
+}
+
+// indexesOf returns the names of the indexes declared by the index and unique_index tags of the model,
+// e.g. the unique index of the results rejecting the duplicate results.
+func indexesOf(scope *gorm.Scope) []string {
+	var indexes []string
+	seen := make(map[string]bool)
+
+	for _, field := range scope.GetModelStruct().StructFields {
+		for _, kind := range []struct{ prefix, setting string }{{"idx", "INDEX"}, {"uix", "UNIQUE_INDEX"}} {
+			names, ok := field.TagSettingsGet(kind.setting)
+			if !ok {
+				continue
+			}
+
+			for _, name := range strings.Split(names, ",") {
+				if name == kind.setting || name == "" {
+					name = scope.Dialect().BuildKeyName(kind.prefix, scope.TableName(), field.DBName)
+				}
+				name, _ = scope.Dialect().NormalizeIndexAndColumn(name, field.DBName)
+
+				if !seen[name] {
+					seen[name] = true
+					indexes = append(indexes, name)
+				}
+			}
+		}
+	}
+
+	return indexes
+}
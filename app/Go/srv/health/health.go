@@ -0,0 +1,133 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sports/backend/srv/responses"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Paths of the probes.
+const (
+	LivePath  = "/healthz"
+	ReadyPath = "/readyz"
+)
+
+// Statuses of the probes and the checks.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// checkTimeout limits the time of every check, so that the probe is answered before the caller gives up.
+const checkTimeout = 2 * time.Second
+
+// Check reports whether the dependency is ready to serve the requests.
+type Check func(ctx context.Context) error
+
+// Checker runs the readiness checks, it reports not ready as soon as the shutdown begins
+// so that no new clients are routed to the server being stopped.
+type Checker struct {
+	mu     sync.RWMutex
+	checks map[string]Check
+
+	shuttingDown int32
+}
+
+// Report is the JSON detail of the probe.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckReport `json:"checks,omitempty"`
+}
+
+// CheckReport is the result of a single check, Error tells why the check fails.
+type CheckReport struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// Add registers the readiness check under the name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = check
+}
+
+// ShutDown makes the readiness probe fail till the process exits.
+func (c *Checker) ShutDown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+}
+
+// Ready runs the checks concurrently and reports their results.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.RLock()
+	names := make([]string, 0, len(c.checks))
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		names = append(names, name)
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+	sort.Strings(names)
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckReport, len(names)+1)}
+
+	if atomic.LoadInt32(&c.shuttingDown) == 1 {
+		report.Status = StatusUnavailable
+		report.Checks["shutdown"] = CheckReport{Status: StatusUnavailable, Error: "the server is shutting down"}
+		return report
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	results := make([]CheckReport, len(names))
+
+	var wg sync.WaitGroup
+	for index, name := range names {
+		wg.Add(1)
+		go func(index int, check Check) {
+			defer wg.Done()
+
+			results[index] = CheckReport{Status: StatusOK}
+			if err := check(ctx); err != nil {
+				results[index] = CheckReport{Status: StatusUnavailable, Error: err.Error()}
+			}
+		}(index, checks[name])
+	}
+	wg.Wait()
+
+	for index, name := range names {
+		report.Checks[name] = results[index]
+		if results[index].Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+
+	return report
+}
+
+// LiveHandler answers the liveness probe, the process serving it is alive.
+func (c *Checker) LiveHandler(w http.ResponseWriter, r *http.Request) {
+	responses.JSON(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// ReadyHandler answers the readiness probe with 503 when any of the checks fails.
+func (c *Checker) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := c.Ready(r.Context())
+
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	responses.JSON(w, status, report)
+}
@@ -0,0 +1,74 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"sports/backend/srv/health"
+)
+
+var _ = Describe("Health probes", func() {
+	var checker *health.Checker
+
+	ready := func() (int, health.Report) {
+		rr := httptest.NewRecorder()
+		checker.ReadyHandler(rr, httptest.NewRequest("GET", health.ReadyPath, nil))
+
+		report := health.Report{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &report)).To(BeNil())
+
+		return rr.Code, report
+	}
+
+	BeforeEach(func() {
+		checker = health.NewChecker()
+		checker.Add("database", func(ctx context.Context) error { return nil })
+	})
+
+	Specify("The process alive", func() {
+		rr := httptest.NewRecorder()
+		checker.LiveHandler(rr, httptest.NewRequest("GET", health.LivePath, nil))
+
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
+	Specify("Ready when the checks pass", func() {
+		code, report := ready()
+
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(report.Status).To(Equal(health.StatusOK))
+		Expect(report.Checks["database"].Status).To(Equal(health.StatusOK))
+	})
+
+	Specify("Failing check reported", func() {
+		checker.Add("dashboard", func(ctx context.Context) error { return errors.New("the hub loop is not running") })
+
+		code, report := ready()
+
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(report.Status).To(Equal(health.StatusUnavailable))
+		Expect(report.Checks["database"].Status).To(Equal(health.StatusOK))
+		Expect(report.Checks["dashboard"]).To(Equal(health.CheckReport{
+			Status: health.StatusUnavailable,
+			Error:  "the hub loop is not running",
+		}))
+	})
+
+	Specify("Not ready while shutting down", func() {
+		checker.ShutDown()
+
+		code, report := ready()
+
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+		Expect(report.Checks).To(HaveKey("shutdown"))
+
+		// The process is still alive.
+		rr := httptest.NewRecorder()
+		checker.LiveHandler(rr, httptest.NewRequest("GET", health.LivePath, nil))
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+})
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/jinzhu/gorm"
+	"time"
+)
+
+const startedAt = "metrics:started_at"
+
+// InstrumentDB registers the callbacks timing the queries of the connection and its transactions.
+func InstrumentDB(db *gorm.DB) {
+	callback := db.Callback()
+
+	callback.Create().Before("gorm:begin_transaction").Register("metrics:before_create", start)
+	callback.Create().After("gorm:commit_or_rollback_transaction").Register("metrics:after_create", observe("create"))
+
+	callback.Query().Before("gorm:query").Register("metrics:before_query", start)
+	callback.Query().After("gorm:after_query").Register("metrics:after_query", observe("query"))
+
+	callback.RowQuery().Before("gorm:row_query").Register("metrics:before_row_query", start)
+	callback.RowQuery().After("gorm:row_query").Register("metrics:after_row_query", observe("row_query"))
+
+	callback.Update().Before("gorm:begin_transaction").Register("metrics:before_update", start)
+	callback.Update().After("gorm:commit_or_rollback_transaction").Register("metrics:after_update", observe("update"))
+
+	callback.Delete().Before("gorm:begin_transaction").Register("metrics:before_delete", start)
+	callback.Delete().After("gorm:commit_or_rollback_transaction").Register("metrics:after_delete", observe("delete"))
+}
+
+func start(scope *gorm.Scope) {
+	scope.Set(startedAt, time.Now())
+}
+
+func observe(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		value, ok := scope.Get(startedAt)
+		if !ok {
+			return
+		}
+
+		DBQueryDuration.WithLabelValues(operation, scope.TableName()).Observe(time.Since(value.(time.Time)).Seconds())
+	}
+}
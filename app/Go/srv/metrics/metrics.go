@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Path is the route the metrics are scraped from.
+const Path = "/metrics"
+
+// Transports of the dashboard clients.
+const (
+	TransportWebSocket = "websocket"
+	TransportSSE       = "sse"
+	TransportGRPC      = "grpc"
+)
+
+var (
+	// HTTPRequests counts the handled requests by the route template, method and status code.
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sports",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of the handled HTTP requests.",
+	}, []string{"route", "method", "code"})
+
+	// HTTPRequestDuration observes the time the requests are handled for, the dashboard
+	// routes are observed when the client disconnects.
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "sports",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time the HTTP requests are handled for.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	// Errors counts the failed requests and calls by the problem code, e.g. "result_already_exists".
+	Errors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sports",
+		Name:      "errors_total",
+		Help:      "Number of the errors returned to the clients by the problem code.",
+	}, []string{"code"})
+
+	// DBQueryDuration observes the database queries by the operation and the table.
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "sports",
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Time the database queries take.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation", "table"})
+
+	// DashboardClients is the number of the connected dashboard clients by the transport.
+	DashboardClients = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "sports",
+		Subsystem: "dashboard",
+		Name:      "clients",
+		Help:      "Number of the connected dashboard clients.",
+	}, []string{"transport"})
+
+	// DashboardBroadcastDuration observes the time a broadcast takes to be written to all the clients.
+	DashboardBroadcastDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "sports",
+		Subsystem: "dashboard",
+		Name:      "broadcast_duration_seconds",
+		Help:      "Time the broadcast fan-out to the connected clients takes.",
+		Buckets:   []float64{.0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, 1},
+	}, []string{"event"})
+
+	// DashboardDroppedMessages counts the messages not delivered to the clients, either written to
+	// the full event stream or failed to be written to the WebSocket.
+	DashboardDroppedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sports",
+		Subsystem: "dashboard",
+		Name:      "dropped_messages_total",
+		Help:      "Number of the dashboard messages not delivered to the clients.",
+	}, []string{"transport"})
+
+	// OutboxDeliveries counts the outbox messages handled by the subscribers, the outcome is "delivered" or "failed".
+	OutboxDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sports",
+		Subsystem: "outbox",
+		Name:      "deliveries_total",
+		Help:      "Number of the outbox messages handled by the subscribers.",
+	}, []string{"subscriber", "outcome"})
+
+	// WebhookDeliveries counts the attempts to post the events to the webhooks,
+	// the outcome is "delivered", "retried" or "failed" when given up.
+	WebhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "sports",
+		Subsystem: "webhook",
+		Name:      "deliveries_total",
+		Help:      "Number of the attempts to post the events to the webhooks.",
+	}, []string{"event", "outcome"})
+)
+
+// Outcomes of the outbox and the webhook deliveries.
+const (
+	OutcomeDelivered = "delivered"
+	OutcomeRetried   = "retried"
+	OutcomeFailed    = "failed"
+)
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest counts and times the handled request by the route template,
+// so that the requests to "/results/{id}" are not told apart by the id.
+func ObserveRequest(route, method string, statusCode int, duration time.Duration) {
+	HTTPRequests.WithLabelValues(route, method, strconv.Itoa(statusCode)).Inc()
+	HTTPRequestDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+}
@@ -0,0 +1,35 @@
+package metrics_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"sports/backend/srv/metrics"
+	"time"
+)
+
+var _ = Describe("Metrics", func() {
+	Specify("Requests counted by the route template", func() {
+		counter := metrics.HTTPRequests.WithLabelValues("/results/{id}", "GET", "404")
+		before := testutil.ToFloat64(counter)
+
+		metrics.ObserveRequest("/results/{id}", "GET", http.StatusNotFound, time.Millisecond)
+		metrics.ObserveRequest("/results/{id}", "GET", http.StatusNotFound, time.Millisecond)
+
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 2))
+	})
+
+	Specify("Metrics exported", func() {
+		metrics.Errors.WithLabelValues("result_not_found").Inc()
+		metrics.ObserveRequest("/checkpoints", "POST", http.StatusOK, time.Millisecond)
+
+		rr := httptest.NewRecorder()
+		metrics.Handler().ServeHTTP(rr, httptest.NewRequest("GET", metrics.Path, nil))
+
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Body.String()).To(ContainSubstring(`sports_errors_total{code="result_not_found"}`))
+		Expect(rr.Body.String()).To(ContainSubstring(`sports_http_requests_total{code="200",method="POST",route="/checkpoints"}`))
+	})
+})
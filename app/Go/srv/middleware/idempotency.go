@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/idempotency"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"sports/backend/srv/utils"
+	"time"
+)
+
+// DefaultIdempotencyKeyTTL is the time the responses are kept for when the period is not configured.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// SetMiddlewareIdempotency replays the stored response to the request repeated with the same Idempotency-Key
+// and body, so that the client may safely retry the mutating request when the first attempt outcome is unknown.
+// Keys are scoped by the credential, the failed (5xx) requests don't keep the key and may be retried.
+func SetMiddlewareIdempotency(s *server.Server, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		if len(key) > idempotency.MaxKeyLength {
+			responses.ERROR(w, responses.MalformedRequest{Err: fmt.Errorf("Idempotency-Key: the length must be no more than %d", idempotency.MaxKeyLength)})
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			responses.ERROR(w, responses.MalformedRequest{Err: err})
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		db := tracing.DB(r.Context(), s.DB)
+
+		scope := ""
+		if identity, ok := auth.FromContext(r.Context()); ok {
+			scope = identity.CredentialID.String()
+		}
+
+		requestHash := hashRequest(r, body)
+		now := utils.MakeTimestampInMilliseconds()
+
+		stored, err := idempotency.GetKey(*db, scope, key)
+		if err == nil && stored.Expired(now) {
+			_, err = idempotency.Release(*db, *stored)
+			if err != nil {
+				responses.ERROR(w, err)
+				return
+			}
+
+			err = idempotency.NotFound{}
+		}
+
+		if err == nil {
+			replay(w, requestHash, *stored)
+			return
+		} else if !errors.As(err, &idempotency.NotFound{}) {
+			responses.ERROR(w, err)
+			return
+		}
+
+		ttl := s.IdempotencyKeyTTL
+		if ttl == 0 {
+			ttl = DefaultIdempotencyKeyTTL
+		}
+
+		reserved, err := idempotency.Reserve(*db, idempotency.PendingKey{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now + ttl.Milliseconds(),
+		})
+		if err != nil {
+			if errors.As(err, &idempotency.AlreadyExists{}) {
+				// Another request has reserved the key meanwhile.
+				err = idempotency.InProgress{}
+			}
+
+			responses.ERROR(w, err)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		reservedKey := idempotency.Key{Scope: reserved.Scope, Key: reserved.Key, Version: reserved.Version}
+		handled := false
+
+		// The key is released when the handler panics as well, so that the request may be retried
+		// instead of being reported as in progress till the key expires.
+		defer func() {
+			var err error
+			if !handled || recorder.statusCode >= http.StatusInternalServerError {
+				_, err = idempotency.Release(*db, reservedKey)
+			} else {
+				_, err = idempotency.Complete(*db, recorder.statusCode, recorder.Header().Get("Content-Type"), recorder.Header().Get("ETag"), recorder.body.Bytes(), reservedKey)
+			}
+			if err != nil {
+				// The response has been sent already, the repeated request will be reported as in progress till the key expires.
+				zap.S().Errorf("Error storing idempotency key %q: %v", key, err)
+			}
+		}()
+
+		next(recorder, r)
+		handled = true
+	}
+}
+
+// replay writes the stored response, the key reused for another request is rejected.
+func replay(w http.ResponseWriter, requestHash string, stored idempotency.Key) {
+	if err := stored.Replayable(requestHash); err != nil {
+		responses.ERROR(w, err)
+		return
+	}
+
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	if stored.ETag != "" {
+		w.Header().Set("ETag", stored.ETag)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Response)
+}
+
+// hashRequest returns the hash telling the repeated request from another request sent with the same key.
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.Path)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder passes the response through and keeps a copy to store.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -1,15 +1,90 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"strings"
+	"sync/atomic"
 )
 
+// Origins allowed to call the API from the browser, "*" allows any origin.
+// The []string value is replaced as a whole when the configuration is reloaded.
+var allowedOrigins atomic.Value
+
+// SetAllowedOrigins configures the origins allowed by the CORS middleware, it is safe to call while serving.
+func SetAllowedOrigins(origins []string) {
+	allowedOrigins.Store(origins)
+}
+
 // SetMiddlewareJSON sets server response type to json.
 func SetMiddlewareJSON(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
+	return SetMiddlewareCORS(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,access-control-allow-origin, access-control-allow-headers")
 		next(w, r)
+	})
+}
+
+// SetMiddlewareCORS allows the configured origins to call the API from the browser,
+// requests from other origins are served without the CORS headers and thus blocked by the browser.
+func SetMiddlewareCORS(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+
+		if origin := r.Header.Get("Origin"); origin != "" && originAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key, Idempotency-Key, If-Match, If-None-Match, X-Request-ID, traceparent, tracestate")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Expose-Headers", "ETag, Location, Idempotent-Replayed, X-Request-ID")
+		}
+
+		next(w, r)
+	}
+}
+
+// Preflight answers the CORS preflight requests.
+func Preflight(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func originAllowed(origin string) bool {
+	origins, _ := allowedOrigins.Load().([]string)
+	for _, allowed := range origins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetMiddlewareAuth authenticates the request and lets through the identities having one of the roles,
+// the identity is passed to the handler in the request context.
+func SetMiddlewareAuth(s *server.Server, next http.HandlerFunc, roles ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity, err := auth.Authenticate(*tracing.DB(r.Context(), s.DB), s.Auth, r)
+		if err == nil {
+			err = auth.VerifyDeviceCertificate(s.Auth, *identity, r.TLS)
+		}
+		if err != nil {
+			if errors.As(err, &auth.Unauthenticated{}) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="sports"`)
+			}
+
+			responses.ERROR(w, err)
+			return
+		}
+
+		if !identity.HasRole(roles...) {
+			responses.ERROR(w, auth.Forbidden{Reason: "insufficient role"})
+			return
+		}
+
+		recordIdentity(r.Context(), *identity)
+
+		next(w, r.WithContext(auth.WithIdentity(r.Context(), *identity)))
 	}
 }
@@ -0,0 +1,37 @@
+package middleware_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"sports/backend/srv/middleware"
+)
+
+var _ = Describe("CORS middleware", func() {
+	handler := middleware.SetMiddlewareCORS(func(w http.ResponseWriter, r *http.Request) {})
+
+	allowedOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/results", nil)
+		req.Header.Set("Origin", origin)
+
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+
+		return rec.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	AfterEach(func() {
+		middleware.SetAllowedOrigins(nil)
+	})
+
+	Specify("Allowed origins replaced while serving", func() {
+		middleware.SetAllowedOrigins([]string{"http://localhost:3000"})
+		Expect(allowedOrigin("http://localhost:3000")).To(Equal("http://localhost:3000"))
+		Expect(allowedOrigin("https://screens.example")).To(BeEmpty())
+
+		middleware.SetAllowedOrigins([]string{"https://screens.example"})
+		Expect(allowedOrigin("http://localhost:3000")).To(BeEmpty())
+		Expect(allowedOrigin("https://screens.example")).To(Equal("https://screens.example"))
+	})
+})
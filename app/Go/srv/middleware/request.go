@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"regexp"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/tracing"
+	"time"
+)
+
+// requestIDPattern limits the request ids accepted from the callers, other ids are replaced.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type contextKey int
+
+const requestKey contextKey = iota
+
+// request is the state of the request shared with the inner middlewares.
+type request struct {
+	id       string
+	identity *auth.Identity
+}
+
+// RequestID returns the id of the request, empty outside of the request.
+func RequestID(ctx context.Context) string {
+	if req, ok := ctx.Value(requestKey).(*request); ok {
+		return req.id
+	}
+
+	return ""
+}
+
+// Logger returns the logger of the request, the entries carry the request and the trace ids.
+func Logger(ctx context.Context) *zap.SugaredLogger {
+	logger := zap.S()
+
+	if id := RequestID(ctx); id != "" {
+		logger = logger.With("request_id", id)
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		logger = logger.With("trace_id", traceID)
+	}
+
+	return logger
+}
+
+// SetMiddlewareRequest assigns the request id, continues the trace of the caller
+// and logs, counts and times the request by the matched route template.
+// The id sent by the caller in X-Request-ID is kept, so that the requests may be traced across the services.
+func SetMiddlewareRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		route := "unmatched"
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		req := &request{id: r.Header.Get(responses.RequestIDHeader)}
+		if !requestIDPattern.MatchString(req.id) {
+			req.id = uuid.Must(uuid.NewV4()).String()
+		}
+		w.Header().Set(responses.RequestIDHeader, req.id)
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", r.Method),
+				attribute.String("http.route", route),
+				attribute.String("http.target", r.URL.Path),
+				attribute.String("http.request_id", req.id),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(ctx, requestKey, req)))
+
+		duration := time.Since(start)
+		metrics.ObserveRequest(route, r.Method, recorder.statusCode, duration)
+
+		span.SetAttributes(attribute.Int("http.status_code", recorder.statusCode))
+		if recorder.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.statusCode))
+		}
+
+		fields := []interface{}{
+			"request_id", req.id,
+			"method", r.Method,
+			"route", route,
+			"path", r.URL.Path,
+			"status", recorder.statusCode,
+			"latency", duration,
+			"remote_addr", r.RemoteAddr,
+		}
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			fields = append(fields, "trace_id", traceID)
+		}
+		if req.identity != nil {
+			fields = append(fields, "credential_id", req.identity.CredentialID.String(), "role", req.identity.Role)
+		}
+
+		zap.S().Infow("Request handled", fields...)
+	})
+}
+
+// recordIdentity passes the authenticated identity to the request log.
+func recordIdentity(ctx context.Context, identity auth.Identity) {
+	if req, ok := ctx.Value(requestKey).(*request); ok {
+		req.identity = &identity
+	}
+}
+
+// statusRecorder keeps the status code of the response, the dashboard connections are still
+// upgraded to WebSocket and flushed as the event streams through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("hijacking is not supported")
+	}
+
+	r.statusCode = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/middleware"
+	"sports/backend/srv/responses"
+)
+
+var _ = Describe("Request middleware", func() {
+	var (
+		router    *mux.Router
+		requestID string
+	)
+
+	BeforeEach(func() {
+		router = mux.NewRouter()
+		router.Use(middleware.SetMiddlewareRequest)
+		router.HandleFunc("/results/{id}", func(w http.ResponseWriter, r *http.Request) {
+			requestID = middleware.RequestID(r.Context())
+			w.WriteHeader(http.StatusNotFound)
+		}).Methods("GET")
+	})
+
+	Specify("Request id assigned", func() {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/results/1", nil))
+
+		Expect(requestID).NotTo(BeEmpty())
+		Expect(rr.Header().Get(responses.RequestIDHeader)).To(Equal(requestID))
+	})
+
+	Specify("Request id of the caller kept", func() {
+		req := httptest.NewRequest("GET", "/results/1", nil)
+		req.Header.Set(responses.RequestIDHeader, "edge-4f2a")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		Expect(requestID).To(Equal("edge-4f2a"))
+		Expect(rr.Header().Get(responses.RequestIDHeader)).To(Equal("edge-4f2a"))
+	})
+
+	Specify("Invalid request id replaced", func() {
+		req := httptest.NewRequest("GET", "/results/1", nil)
+		req.Header.Set(responses.RequestIDHeader, "line\nbreak")
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		Expect(requestID).NotTo(Equal("line\nbreak"))
+		Expect(requestID).NotTo(BeEmpty())
+	})
+
+	Specify("Request counted by the route template", func() {
+		counter := metrics.HTTPRequests.WithLabelValues("/results/{id}", "GET", "404")
+		before := testutil.ToFloat64(counter)
+
+		for _, id := range []string{"1", "2"} {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/results/"+id, nil))
+		}
+
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 2))
+	})
+})
@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// Paths the document and its viewer are served at.
+const (
+	SpecPath = "/openapi.yaml"
+	DocsPath = "/docs"
+)
+
+// Spec is the OpenAPI document of the API, it is kept in sync with the routes by the tests.
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+//go:embed docs.html
+var docs []byte
+
+// SpecHandler serves the OpenAPI document.
+func SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(Spec)
+}
+
+// DocsHandler serves the page rendering the OpenAPI document.
+func DocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(docs)
+}
@@ -0,0 +1,117 @@
+package openapi_test
+
+import (
+	"fmt"
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/openapi"
+	"sports/backend/srv/routes"
+	"sports/backend/srv/server"
+	"strings"
+)
+
+type document struct {
+	Paths      map[string]map[string]interface{} `yaml:"paths"`
+	Components map[string]map[string]interface{} `yaml:"components"`
+}
+
+// refs collects the local references of the document node.
+func refs(node interface{}) []string {
+	var found []string
+
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		for key, value := range n {
+			if key == "$ref" {
+				found = append(found, value.(string))
+				continue
+			}
+			found = append(found, refs(value)...)
+		}
+	case []interface{}:
+		for _, value := range n {
+			found = append(found, refs(value)...)
+		}
+	}
+
+	return found
+}
+
+var _ = Describe("OpenAPI document", func() {
+	doc := document{}
+	err := yaml.Unmarshal(openapi.Spec, &doc)
+
+	It("Parsed", func() {
+		Expect(err).To(BeNil())
+		Expect(doc.Paths).NotTo(BeEmpty())
+	})
+
+	It("Describes the routes", func() {
+		srv := server.Server{
+			Router:    mux.NewRouter(),
+			Dashboard: &dashboard_controller.Dashboard{},
+		}
+		routes.InitializeRoutes(&srv)
+
+		routed := []string{}
+		err := srv.Router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+			path, err := route.GetPathTemplate()
+			if err != nil {
+				// The preflight route matches any path.
+				return nil
+			}
+
+			methods, err := route.GetMethods()
+			if err != nil {
+				// The WebSocket handshake is the GET request.
+				methods = []string{http.MethodGet}
+			}
+
+			for _, method := range methods {
+				routed = append(routed, fmt.Sprintf("%s %s", method, path))
+			}
+			return nil
+		})
+		Expect(err).To(BeNil())
+
+		described := []string{}
+		for path, operations := range doc.Paths {
+			for method := range operations {
+				described = append(described, fmt.Sprintf("%s %s", strings.ToUpper(method), path))
+			}
+		}
+
+		sort.Strings(routed)
+		sort.Strings(described)
+		Expect(described).To(Equal(routed))
+	})
+
+	It("Resolves the references", func() {
+		raw := map[interface{}]interface{}{}
+		Expect(yaml.Unmarshal(openapi.Spec, &raw)).To(Succeed())
+
+		for _, ref := range refs(raw) {
+			parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+			Expect(parts).To(HaveLen(2), ref)
+			Expect(doc.Components[parts[0]]).To(HaveKey(parts[1]), ref)
+		}
+	})
+
+	It("Served with the viewer", func() {
+		w := httptest.NewRecorder()
+		openapi.SpecHandler(w, httptest.NewRequest(http.MethodGet, openapi.SpecPath, nil))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.Bytes()).To(Equal(openapi.Spec))
+
+		w = httptest.NewRecorder()
+		openapi.DocsHandler(w, httptest.NewRequest(http.MethodGet, openapi.DocsPath, nil))
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(ContainSubstring(openapi.SpecPath))
+	})
+})
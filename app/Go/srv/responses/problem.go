@@ -0,0 +1,170 @@
+package responses
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"go.uber.org/zap"
+	"net/http"
+	"reflect"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/device"
+	"sports/backend/domain/models/idempotency"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/record"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/split"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/etag"
+	"sports/backend/srv/metrics"
+)
+
+// RequestIDHeader carries the id of the request, it is set on the response before the handler runs.
+const RequestIDHeader = "X-Request-ID"
+
+// ProblemContentType is the media type of the problem documents.
+const ProblemContentType = "application/problem+json"
+
+// Codes of the problems not caused by the domain errors.
+const (
+	CodeValidationFailed = "validation_failed"
+	CodeMalformedRequest = "malformed_request"
+	CodeInternalError    = "internal_error"
+)
+
+// Problem is the RFC 7807 problem details document, the code tells the problems apart
+// and is kept stable so that the clients may rely on it instead of the detail message.
+type Problem struct {
+	Type   string            `json:"type"`
+	Title  string            `json:"title"`
+	Status int               `json:"status"`
+	Detail string            `json:"detail,omitempty"`
+	Code   string            `json:"code"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// MalformedRequest signifies the request body or parameter cannot be read.
+type MalformedRequest struct {
+	Err error
+}
+
+func (err MalformedRequest) Error() string {
+	return err.Err.Error()
+}
+
+func (err MalformedRequest) Unwrap() error {
+	return err.Err
+}
+
+// problems maps the errors to the response status and the problem code, the first matching error wins.
+var problems = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{MalformedRequest{}, http.StatusBadRequest, CodeMalformedRequest},
+	{&json.SyntaxError{}, http.StatusBadRequest, CodeMalformedRequest},
+	{&json.UnmarshalTypeError{}, http.StatusBadRequest, CodeMalformedRequest},
+	{validation.Errors{}, http.StatusUnprocessableEntity, CodeValidationFailed},
+
+	{auth.Unauthenticated{}, http.StatusUnauthorized, "unauthenticated"},
+	{auth.Forbidden{}, http.StatusForbidden, "forbidden"},
+
+	{etag.Missing{}, http.StatusPreconditionRequired, "precondition_required"},
+	{etag.Malformed{}, http.StatusBadRequest, "invalid_etag"},
+	{domain_errors.InvalidVersion{}, http.StatusPreconditionFailed, "invalid_version"},
+	{domain_errors.StateConflict{}, http.StatusConflict, "state_conflict"},
+
+	{idempotency.RequestMismatch{}, http.StatusUnprocessableEntity, "idempotency_key_reused"},
+	{idempotency.InProgress{}, http.StatusConflict, "idempotency_key_in_progress"},
+
+	{announcement.NotFound{}, http.StatusNotFound, "announcement_not_found"},
+	{announcement.AlreadyRetracted{}, http.StatusConflict, "announcement_already_retracted"},
+	{checkpoint.NotFound{}, http.StatusNotFound, "checkpoint_not_found"},
+	{credential.NotFound{}, http.StatusNotFound, "credential_not_found"},
+	{credential.AlreadyRevoked{}, http.StatusConflict, "credential_already_revoked"},
+	{device.NotFound{}, http.StatusNotFound, "device_not_found"},
+	{device.AlreadyEnrolled{}, http.StatusConflict, "device_already_enrolled"},
+	{device.EnrollmentExpired{}, http.StatusUnprocessableEntity, "device_enrollment_expired"},
+	{device.AlreadyRevoked{}, http.StatusConflict, "device_already_revoked"},
+	{lap.AlreadyExists{}, http.StatusConflict, "lap_already_exists"},
+	{lap.TooShort{}, http.StatusUnprocessableEntity, "lap_too_short"},
+	{race.NotFound{}, http.StatusNotFound, "race_not_found"},
+	{race.AlreadyExists{}, http.StatusConflict, "race_already_exists"},
+	{record.NotFound{}, http.StatusNotFound, "record_not_found"},
+	{record.AlreadyExists{}, http.StatusConflict, "record_already_exists"},
+	{record.Reused{}, http.StatusUnprocessableEntity, "record_id_reused"},
+	{result.NotFound{}, http.StatusNotFound, "result_not_found"},
+	{result.AlreadyExists{}, http.StatusConflict, "result_already_exists"},
+	{result.AlreadyFinished{}, http.StatusConflict, "result_already_finished"},
+	{split.AlreadyExists{}, http.StatusConflict, "split_already_exists"},
+	{sportsmen.NotFound{}, http.StatusNotFound, "sportsmen_not_found"},
+	{team.NotFound{}, http.StatusNotFound, "team_not_found"},
+	{team.AlreadyExists{}, http.StatusConflict, "team_already_exists"},
+	{team.LegNotFound{}, http.StatusNotFound, "leg_not_found"},
+	{team.LegAlreadyAssigned{}, http.StatusConflict, "leg_already_assigned"},
+	{webhook.NotFound{}, http.StatusNotFound, "webhook_not_found"},
+	{webhook.AlreadyRemoved{}, http.StatusConflict, "webhook_already_removed"},
+	{webhook.DeliveryNotFound{}, http.StatusNotFound, "webhook_delivery_not_found"},
+}
+
+// ProblemOf returns the problem document of the error, the errors not known to the API are internal errors.
+func ProblemOf(err error) Problem {
+	for _, problem := range problems {
+		target := reflect.New(reflect.TypeOf(problem.err))
+		if !errors.As(err, target.Interface()) {
+			continue
+		}
+
+		p := Problem{
+			Type:   "urn:sports:problem:" + problem.code,
+			Title:  http.StatusText(problem.status),
+			Status: problem.status,
+			Detail: err.Error(),
+			Code:   problem.code,
+		}
+
+		if fields, ok := target.Elem().Interface().(validation.Errors); ok {
+			p.Errors = make(map[string]string, len(fields))
+			for field, fieldErr := range fields {
+				p.Errors[field] = fieldErr.Error()
+			}
+		}
+
+		return p
+	}
+
+	// Internal details are not disclosed to the client.
+	return Problem{
+		Type:   "urn:sports:problem:" + CodeInternalError,
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+		Detail: "The request could not be processed, try again later",
+		Code:   CodeInternalError,
+	}
+}
+
+// ERROR writes the problem document of the error to the response.
+func ERROR(w http.ResponseWriter, err error) {
+	if err == nil {
+		err = fmt.Errorf("Unknown error")
+	}
+
+	problem := ProblemOf(err)
+	if problem.Status == http.StatusInternalServerError {
+		zap.S().Errorw("Error processing the request", "error", err, "request_id", w.Header().Get(RequestIDHeader))
+	}
+
+	metrics.Errors.WithLabelValues(problem.Code).Inc()
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	JSON(w, problem.Status, problem)
+}
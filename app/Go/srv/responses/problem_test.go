@@ -0,0 +1,86 @@
+package responses_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	validation "github.com/go-ozzo/ozzo-validation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/result"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/responses"
+)
+
+var _ = Describe("Problem documents", func() {
+	Describe("Mapping the errors", func() {
+		samples := []struct {
+			err    error
+			status int
+			code   string
+		}{
+			{fmt.Errorf("Result not found: %w", result.NotFound{}), http.StatusNotFound, "result_not_found"},
+			{result.AlreadyExists{}, http.StatusConflict, "result_already_exists"},
+			{result.AlreadyFinished{}, http.StatusConflict, "result_already_finished"},
+			{fmt.Errorf("Invalid version tag: %w", domain_errors.InvalidVersion{}), http.StatusPreconditionFailed, "invalid_version"},
+			{fmt.Errorf("State conflict: %w", domain_errors.StateConflict{}), http.StatusConflict, "state_conflict"},
+			{json.Unmarshal([]byte("{"), &struct{}{}), http.StatusBadRequest, responses.CodeMalformedRequest},
+			{errors.New("connection refused"), http.StatusInternalServerError, responses.CodeInternalError},
+		}
+
+		Specify("the status and the code returned", func() {
+			for _, sample := range samples {
+				problem := responses.ProblemOf(sample.err)
+				Expect(problem.Status).To(Equal(sample.status), sample.err.Error())
+				Expect(problem.Code).To(Equal(sample.code), sample.err.Error())
+				Expect(problem.Type).To(Equal("urn:sports:problem:" + sample.code))
+			}
+		})
+	})
+
+	Describe("Writing the validation failure", func() {
+		Specify("the field details returned", func() {
+			rr := httptest.NewRecorder()
+			responses.ERROR(rr, validation.Errors{
+				"checkpoint_id": errors.New("cannot be blank"),
+				"time":          errors.New("cannot be blank"),
+			})
+
+			Expect(rr.Code).To(Equal(http.StatusUnprocessableEntity))
+			Expect(rr.Header().Get("Content-Type")).To(Equal(responses.ProblemContentType))
+
+			problem := responses.Problem{}
+			Expect(json.Unmarshal(rr.Body.Bytes(), &problem)).To(BeNil())
+			Expect(problem.Code).To(Equal(responses.CodeValidationFailed))
+			Expect(problem.Errors).To(Equal(map[string]string{
+				"checkpoint_id": "cannot be blank",
+				"time":          "cannot be blank",
+			}))
+		})
+	})
+
+	Describe("Writing the internal error", func() {
+		Specify("the error details are not disclosed", func() {
+			rr := httptest.NewRecorder()
+			responses.ERROR(rr, errors.New("pq: password authentication failed"))
+
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+			Expect(rr.Body.String()).ToNot(ContainSubstring("password"))
+		})
+	})
+
+	Describe("Counting the errors", func() {
+		Specify("the error counted by the code", func() {
+			counter := metrics.Errors.WithLabelValues("result_already_exists")
+			before := testutil.ToFloat64(counter)
+
+			responses.ERROR(httptest.NewRecorder(), result.AlreadyExists{})
+
+			Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+		})
+	})
+})
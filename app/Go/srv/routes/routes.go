@@ -1,19 +1,100 @@
 package routes
 
 import (
+	"net/http"
+	"sports/backend/domain/models/credential"
+	announcement_controller "sports/backend/srv/controllers/announcement"
+	auth_controller "sports/backend/srv/controllers/auth"
 	checkpoint_controller "sports/backend/srv/controllers/checkpoint"
+	device_controller "sports/backend/srv/controllers/device"
+	race_controller "sports/backend/srv/controllers/race"
 	result_controller "sports/backend/srv/controllers/result"
 	sportsmen_controller "sports/backend/srv/controllers/sportsmen"
+	sync_controller "sports/backend/srv/controllers/sync"
+	team_controller "sports/backend/srv/controllers/team"
+	webhook_controller "sports/backend/srv/controllers/webhook"
+	"sports/backend/srv/health"
+	"sports/backend/srv/metrics"
 	"sports/backend/srv/middleware"
+	"sports/backend/srv/openapi"
 	"sports/backend/srv/server"
 )
 
+// Roles allowed to use the routes.
+var (
+	admins      = []string{credential.RoleAdmin}
+	timekeepers = []string{credential.RoleAdmin, credential.RoleTimekeeper}
+	everyone    = []string{credential.RoleAdmin, credential.RoleTimekeeper, credential.RoleViewer}
+)
+
 func InitializeRoutes(s *server.Server) {
+	// Mutating requests are safe to retry with Idempotency-Key, except the ones issuing credentials
+	// as their responses are not stored.
+	idempotent := func(next http.HandlerFunc) http.HandlerFunc {
+		return middleware.SetMiddlewareIdempotency(s, next)
+	}
+
+	s.Router.Use(middleware.SetMiddlewareRequest)
+
+	s.Router.Methods("OPTIONS").HandlerFunc(middleware.SetMiddlewareCORS(middleware.Preflight))
+
+	// The API description is public.
+	s.Router.HandleFunc(openapi.SpecPath, middleware.SetMiddlewareCORS(openapi.SpecHandler)).Methods("GET")
+	s.Router.HandleFunc(openapi.DocsPath, openapi.DocsHandler).Methods("GET")
+
+	// Probes of the orchestrator are public.
+	s.Router.HandleFunc(health.LivePath, middleware.SetMiddlewareJSON(s.Health.LiveHandler)).Methods("GET")
+	s.Router.HandleFunc(health.ReadyPath, middleware.SetMiddlewareJSON(s.Health.ReadyHandler)).Methods("GET")
+
+	// Metrics are scraped without credentials.
+	s.Router.Handle(metrics.Path, metrics.Handler()).Methods("GET")
+
+	// Dashboards are public.
 	s.Router.HandleFunc("/dashboard", s.Dashboard.ResultsHandler)
+	s.Router.HandleFunc("/dashboard/events", middleware.SetMiddlewareCORS(s.Dashboard.EventsHandler)).Methods("GET")
+	s.Router.HandleFunc("/dashboard/snapshot", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(s.Dashboard.RefreshHandler), admins...))).Methods("POST")
+
+	s.Router.HandleFunc("/auth/token", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, auth_controller.IssueToken(s), everyone...))).Methods("POST")
+
+	// Devices are enrolled with the one-time code instead of a credential.
+	s.Router.HandleFunc("/devices/enroll", middleware.SetMiddlewareJSON(device_controller.EnrollDevice(s))).Methods("POST")
+	s.Router.HandleFunc("/devices", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, device_controller.RegisterDevice(s), admins...))).Methods("POST")
+	s.Router.HandleFunc("/devices", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, device_controller.GetDevices(s), admins...))).Methods("GET")
+	s.Router.HandleFunc("/devices/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, device_controller.GetDevice(s), admins...))).Methods("GET")
+	s.Router.HandleFunc("/devices/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(device_controller.RevokeDevice(s)), admins...))).Methods("DELETE")
+
+	s.Router.HandleFunc("/results", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(result_controller.AddResult(s)), timekeepers...))).Methods("POST")
+	s.Router.HandleFunc("/results", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, result_controller.GetLastTenResults(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/results/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, result_controller.GetResult(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/results/{id}/laps", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, race_controller.GetLaps(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/finish", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(result_controller.AddFinishTime(s)), timekeepers...))).Methods("POST")
+	s.Router.HandleFunc("/laps", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(race_controller.AddLap(s)), timekeepers...))).Methods("POST")
+	s.Router.HandleFunc("/sync", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(sync_controller.Sync(s)), timekeepers...))).Methods("POST")
+	s.Router.HandleFunc("/checkpoints", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(checkpoint_controller.AddCheckpoint(s)), admins...))).Methods("POST")
+	s.Router.HandleFunc("/checkpoints/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, checkpoint_controller.GetCheckpoint(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/races", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(race_controller.AddRace(s)), admins...))).Methods("POST")
+	s.Router.HandleFunc("/races", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, race_controller.GetRaces(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/races/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, race_controller.GetRace(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/races/{id}/standings", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, race_controller.GetStandings(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/teams", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(team_controller.AddTeam(s)), admins...))).Methods("POST")
+	s.Router.HandleFunc("/teams", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, team_controller.GetTeams(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/teams/standings", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, team_controller.GetStandings(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/teams/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, team_controller.GetTeam(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/teams/{id}/results", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, team_controller.GetTeamResults(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/handovers", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(team_controller.AddHandover(s)), timekeepers...))).Methods("POST")
+	// Exports are served as files instead of JSON.
+	s.Router.HandleFunc("/exports/teams", middleware.SetMiddlewareCORS(middleware.SetMiddlewareAuth(s, team_controller.ExportStandings(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/sportsmens", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(sportsmen_controller.AddSportsmen(s)), admins...))).Methods("POST")
+	s.Router.HandleFunc("/sportsmens/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, sportsmen_controller.GetSportsmen(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/announcements", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(announcement_controller.AddAnnouncement(s)), admins...))).Methods("POST")
+	s.Router.HandleFunc("/announcements", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, announcement_controller.GetActiveAnnouncements(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/announcements/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, announcement_controller.GetAnnouncement(s), everyone...))).Methods("GET")
+	s.Router.HandleFunc("/announcements/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(announcement_controller.RetractAnnouncement(s)), admins...))).Methods("DELETE")
 
-	s.Router.HandleFunc("/results", middleware.SetMiddlewareJSON(result_controller.AddResult(s))).Methods("POST")
-	s.Router.HandleFunc("/results", middleware.SetMiddlewareJSON(result_controller.GetLastTenResults(s))).Methods("GET")
-	s.Router.HandleFunc("/finish", middleware.SetMiddlewareJSON(result_controller.AddFinishTime(s))).Methods("POST")
-	s.Router.HandleFunc("/checkpoints", middleware.SetMiddlewareJSON(checkpoint_controller.AddCheckpoint(s))).Methods("POST")
-	s.Router.HandleFunc("/sportsmens", middleware.SetMiddlewareJSON(sportsmen_controller.AddSportsmen(s))).Methods("POST")
+	s.Router.HandleFunc("/webhooks", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(webhook_controller.AddWebhook(s)), admins...))).Methods("POST")
+	s.Router.HandleFunc("/webhooks", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, webhook_controller.GetWebhooks(s), admins...))).Methods("GET")
+	s.Router.HandleFunc("/webhooks/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, webhook_controller.GetWebhook(s), admins...))).Methods("GET")
+	s.Router.HandleFunc("/webhooks/{id}", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(webhook_controller.RemoveWebhook(s)), admins...))).Methods("DELETE")
+	s.Router.HandleFunc("/webhooks/{id}/deliveries", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, webhook_controller.GetDeliveries(s), admins...))).Methods("GET")
+	s.Router.HandleFunc("/webhooks/{id}/deliveries/{delivery_id}/redeliver", middleware.SetMiddlewareJSON(middleware.SetMiddlewareAuth(s, idempotent(webhook_controller.Redeliver(s)), admins...))).Methods("POST")
 }
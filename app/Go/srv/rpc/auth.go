@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"path"
+	"sports/backend/domain/models/credential"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/tracing"
+)
+
+// Roles allowed to call the methods, the same as of the REST routes.
+var (
+	admins      = []string{credential.RoleAdmin}
+	timekeepers = []string{credential.RoleAdmin, credential.RoleTimekeeper}
+	everyone    = []string{credential.RoleAdmin, credential.RoleTimekeeper, credential.RoleViewer}
+)
+
+// methodRoles lists the roles of the methods by name, the public methods have no roles.
+var methodRoles = map[string][]string{
+	"AddCheckpoint": admins,
+	"GetCheckpoint": everyone,
+
+	"AddSportsmen": admins,
+	"GetSportsmen": everyone,
+
+	"AddResult":      timekeepers,
+	"FinishResult":   timekeepers,
+	"GetResult":      everyone,
+	"GetLastResults": everyone,
+
+	"RegisterDevice": admins,
+	"GetDevice":      admins,
+	"GetDevices":     admins,
+
+	"AddAnnouncement":        admins,
+	"GetAnnouncement":        everyone,
+	"GetActiveAnnouncements": everyone,
+
+	// Dashboards are public.
+	"WatchResults": nil,
+}
+
+// authenticate resolves the identity of the call from the "authorization" or the "x-api-key" metadata
+// and lets through the identities having one of the roles of the method.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	roles, ok := methodRoles[path.Base(fullMethod)]
+	if !ok {
+		return nil, auth.Forbidden{Reason: "unknown method"}
+	}
+
+	if roles == nil {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	identity, err := auth.AuthenticateHeaders(*tracing.DB(ctx, s.server.DB), s.server.Auth, first(md, "authorization"), first(md, "x-api-key"))
+	if err != nil {
+		return nil, err
+	}
+
+	err = auth.VerifyDeviceCertificate(s.server.Auth, *identity, peerTLS(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if !identity.HasRole(roles...) {
+		return nil, auth.Forbidden{Reason: "insufficient role"}
+	}
+
+	return auth.WithIdentity(ctx, *identity), nil
+}
+
+// peerTLS returns the TLS state of the connection of the call, nil on the plain connections.
+func peerTLS(ctx context.Context) *tls.ConnectionState {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+
+	return &info.State
+}
+
+func first(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"github.com/gofrs/uuid"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/device"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+)
+
+// The optional values are sent as the zero values, protobuf has no null.
+
+func toCheckpoint(c *checkpoint.Checkpoint) *Checkpoint {
+	return &Checkpoint{
+		ID:        c.ID.String(),
+		Name:      c.Name,
+		Event:     c.Event,
+		CreatedAt: c.CreatedAt,
+		Version:   c.Version,
+	}
+}
+
+func toSportsmen(s *sportsmen.Sportsmen) *Sportsmen {
+	return &Sportsmen{
+		ID:          s.ID.String(),
+		StartNumber: s.StartNumber,
+		FirstName:   s.FirstName,
+		LastName:    s.LastName,
+		Category:    s.Category,
+		Status:      s.Status,
+		CreatedAt:   s.CreatedAt,
+		Version:     s.Version,
+	}
+}
+
+func toResult(r *result.Result) *Result {
+	return &Result{
+		ID:             r.ID.String(),
+		CheckpointID:   r.CheckpointID.String(),
+		SportsmenID:    r.SportsmenID.String(),
+		TimeStart:      r.TimeStart,
+		TimeFinish:     int64Of(r.TimeFinish),
+		DeviceID:       stringOf(r.DeviceID),
+		FinishDeviceID: stringOf(r.FinishDeviceID),
+		CreatedAt:      r.CreatedAt,
+		Version:        r.Version,
+	}
+}
+
+func toDevice(d *device.Device) *Device {
+	return &Device{
+		ID:                  d.ID.String(),
+		Name:                d.Name,
+		Event:               d.Event,
+		CheckpointID:        d.CheckpointID.String(),
+		EnrollmentExpiresAt: d.EnrollmentExpiresAt,
+		EnrolledAt:          int64Of(d.EnrolledAt),
+		CredentialID:        stringOf(d.CredentialID),
+		RevokedAt:           int64Of(d.RevokedAt),
+		CreatedAt:           d.CreatedAt,
+		Version:             d.Version,
+	}
+}
+
+func toAnnouncement(a *announcement.Announcement) *Announcement {
+	return &Announcement{
+		ID:          a.ID.String(),
+		Message:     a.Message,
+		Severity:    a.Severity,
+		Event:       a.Event,
+		ExpiresAt:   int64Of(a.ExpiresAt),
+		RetractedAt: int64Of(a.RetractedAt),
+		CreatedAt:   a.CreatedAt,
+		Version:     a.Version,
+	}
+}
+
+func int64Of(value *int64) int64 {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
+
+func stringOf(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
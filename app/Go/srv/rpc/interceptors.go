@@ -0,0 +1,123 @@
+package rpc
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/tracing"
+	"strings"
+	"time"
+)
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var res interface{}
+
+	err := s.call(ctx, info.FullMethod, func(ctx context.Context) error {
+		var err error
+		res, err = handler(ctx, req)
+		return err
+	})
+
+	return res, err
+}
+
+func (s *Server) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return s.call(stream.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	})
+}
+
+// call authenticates and runs the call within the span continuing the trace of the caller,
+// the error is mapped to the status and the call is logged like the HTTP requests.
+func (s *Server) call(ctx context.Context, fullMethod string, handler func(ctx context.Context) error) error {
+	start := time.Now()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := first(md, strings.ToLower(responses.RequestIDHeader))
+	if requestID == "" {
+		requestID = uuid.Must(uuid.NewV4()).String()
+	}
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	ctx, span := tracing.Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.method", fullMethod),
+			attribute.String("rpc.request_id", requestID),
+		),
+	)
+	defer span.End()
+
+	authenticated, err := s.authenticate(ctx, fullMethod)
+	if err == nil {
+		ctx = authenticated
+		err = handler(ctx)
+	}
+	if err != nil {
+		err = statusOf(err).Err()
+	}
+
+	code := status.Code(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	fields := []interface{}{
+		"request_id", requestID,
+		"method", fullMethod,
+		"code", code.String(),
+		"latency", time.Since(start),
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		fields = append(fields, "trace_id", traceID)
+	}
+	if identity, ok := auth.FromContext(ctx); ok {
+		fields = append(fields, "credential_id", identity.CredentialID.String(), "role", identity.Role)
+	}
+
+	zap.S().Infow("Call handled", fields...)
+
+	return err
+}
+
+// authenticatedStream passes the identity of the call to the stream handler.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// metadataCarrier reads the trace context propagated by the caller from the metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return first(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,403 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/device"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/srv/auth"
+	announcement_controller "sports/backend/srv/controllers/announcement"
+	checkpoint_controller "sports/backend/srv/controllers/checkpoint"
+	"sports/backend/srv/controllers/dashboard"
+	device_controller "sports/backend/srv/controllers/device"
+	result_controller "sports/backend/srv/controllers/result"
+	sportsmen_controller "sports/backend/srv/controllers/sportsmen"
+	"sports/backend/srv/responses"
+	"sports/backend/srv/server"
+	"sports/backend/srv/tracing"
+	"sports/backend/srv/utils"
+	"strings"
+)
+
+// Server implements the Timing service on top of the same domain and dashboard as the REST API,
+// the requests are validated the same way and the failures carry the same problem codes.
+type Server struct {
+	server *server.Server
+}
+
+// NewServer returns the gRPC server serving the Timing service.
+func NewServer(s *server.Server, options ...grpc.ServerOption) *grpc.Server {
+	rpcServer := &Server{server: s}
+
+	options = append(options,
+		grpc.UnaryInterceptor(rpcServer.unaryInterceptor),
+		grpc.StreamInterceptor(rpcServer.streamInterceptor),
+	)
+
+	grpcServer := grpc.NewServer(options...)
+	RegisterTimingServer(grpcServer, rpcServer)
+
+	return grpcServer
+}
+
+func (s *Server) AddCheckpoint(ctx context.Context, req *NewCheckpointRequest) (*checkpoint.CheckpointCreatedEvent, error) {
+	err := checkpoint_controller.NewCheckpointRequest{Name: req.Name}.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "checkpoint.Create")
+	checkpointCreatedEvent, err := checkpoint.Create(db, checkpoint.PendingCheckpoint{
+		ID:    uuid.Must(uuid.NewV4()),
+		Name:  req.Name,
+		Event: req.Event,
+	})
+	end(err)
+
+	return checkpointCreatedEvent, err
+}
+
+func (s *Server) GetCheckpoint(ctx context.Context, req *GetRequest) (*Checkpoint, error) {
+	id, version, err := parseGetRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "checkpoint.GetCheckpoint")
+	checkpointFetched, err := checkpoint.GetCheckpoint(db, id, version)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCheckpoint(checkpointFetched), nil
+}
+
+func (s *Server) AddSportsmen(ctx context.Context, req *NewSportsmenRequest) (*sportsmen.SportsmenCreatedEvent, error) {
+	err := sportsmen_controller.NewSportsmenRequest{
+		StartNumber: req.StartNumber,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Category:    req.Category,
+	}.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "sportsmen.Create")
+	sportsmenCreatedEvent, err := sportsmen.Create(db, sportsmen.PendingSportsmen{
+		ID:          uuid.Must(uuid.NewV4()),
+		StartNumber: req.StartNumber,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Category:    req.Category,
+	})
+	end(err)
+
+	return sportsmenCreatedEvent, err
+}
+
+func (s *Server) GetSportsmen(ctx context.Context, req *GetRequest) (*Sportsmen, error) {
+	id, version, err := parseGetRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "sportsmen.GetSportsmen")
+	sportsmenFetched, err := sportsmen.GetSportsmen(db, id, version)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSportsmen(sportsmenFetched), nil
+}
+
+func (s *Server) AddResult(ctx context.Context, req *NewResultRequest) (*result.ResultCreatedEvent, error) {
+	err := result_controller.NewResultRequest{
+		CheckpointID: req.CheckpointID,
+		SportsmenID:  req.SportsmenID,
+		Time:         req.TimeStart,
+	}.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	checkpointID := uuid.Must(uuid.FromString(req.CheckpointID))
+	identity, err := auth.RequireCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	newResult := result.PendingResult{
+		ID:           uuid.Must(uuid.NewV4()),
+		CheckpointID: checkpointID,
+		SportsmenID:  uuid.Must(uuid.FromString(req.SportsmenID)),
+		TimeStart:    req.TimeStart,
+		DeviceID:     identity.DeviceID,
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "result.Create")
+	resultCreatedEvent, err := result.Create(db, newResult)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.Dispatcher.Flush(*tracing.DB(ctx, s.server.DB))
+
+	return resultCreatedEvent, nil
+}
+
+func (s *Server) FinishResult(ctx context.Context, req *FinishRequest) (*result.ResultFinishedEvent, error) {
+	err := result_controller.FinishRequest{
+		CheckpointID: req.CheckpointID,
+		SportsmenID:  req.SportsmenID,
+		Time:         req.TimeFinish,
+	}.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// The version is the precondition of the update like If-Match of the REST API.
+	if req.Version == 0 {
+		return nil, responses.MalformedRequest{Err: fmt.Errorf("Version: the version of the started result is required")}
+	}
+
+	checkpointID := uuid.Must(uuid.FromString(req.CheckpointID))
+	identity, err := auth.RequireCheckpoint(ctx, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "result.GetUnfinishedResult")
+	resultUnfinished, err := result.GetUnfinishedResult(db, checkpointID, uuid.Must(uuid.FromString(req.SportsmenID)), &req.Version)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end = tracing.Command(ctx, s.server.DB, "result.AddFinishTimeByDevice")
+	resultFinishedEvent, err := result.AddFinishTimeByDevice(db, req.TimeFinish, identity.DeviceID, *resultUnfinished)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.Dispatcher.Flush(*tracing.DB(ctx, s.server.DB))
+
+	return resultFinishedEvent, nil
+}
+
+func (s *Server) GetResult(ctx context.Context, req *GetRequest) (*Result, error) {
+	id, version, err := parseGetRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "result.GetResult")
+	resultFetched, err := result.GetResult(db, id, version)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResult(resultFetched), nil
+}
+
+func (s *Server) GetLastResults(ctx context.Context, req *GetLastResultsRequest) (*Results, error) {
+	db, end := tracing.Command(ctx, s.server.DB, "result.GetLastTenResults")
+	results, err := result.GetLastTenResults(db)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Results{}
+	for i := range *results {
+		res.Results = append(res.Results, toResult(&(*results)[i]))
+	}
+
+	return res, nil
+}
+
+func (s *Server) RegisterDevice(ctx context.Context, req *NewDeviceRequest) (*DeviceRegistered, error) {
+	err := device_controller.NewDeviceRequest{
+		Name:         req.Name,
+		Event:        req.Event,
+		CheckpointID: req.CheckpointID,
+	}.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	code, codeHash, err := auth.GenerateEnrollmentCode()
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "device.Register")
+	deviceRegisteredEvent, err := device.Register(db, device.PendingDevice{
+		ID:                  uuid.Must(uuid.NewV4()),
+		Name:                req.Name,
+		Event:               req.Event,
+		CheckpointID:        uuid.Must(uuid.FromString(req.CheckpointID)),
+		EnrollmentCodeHash:  codeHash,
+		EnrollmentExpiresAt: utils.MakeTimestampInMilliseconds() + device.EnrollmentCodeTTL.Milliseconds(),
+	})
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceRegistered{
+		Event:          deviceRegisteredEvent,
+		EnrollmentCode: code,
+	}, nil
+}
+
+func (s *Server) GetDevice(ctx context.Context, req *GetRequest) (*Device, error) {
+	id, version, err := parseGetRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "device.GetDevice")
+	deviceFetched, err := device.GetDevice(db, id, version)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDevice(deviceFetched), nil
+}
+
+func (s *Server) GetDevices(ctx context.Context, req *GetDevicesRequest) (*Devices, error) {
+	db, end := tracing.Command(ctx, s.server.DB, "device.GetDevices")
+	devices, err := device.GetDevices(db)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Devices{}
+	for i := range *devices {
+		res.Devices = append(res.Devices, toDevice(&(*devices)[i]))
+	}
+
+	return res, nil
+}
+
+func (s *Server) AddAnnouncement(ctx context.Context, req *NewAnnouncementRequest) (*announcement.AnnouncementCreatedEvent, error) {
+	var expiresAt *int64
+	if req.ExpiresAt != 0 {
+		expiresAt = &req.ExpiresAt
+	}
+
+	err := announcement_controller.NewAnnouncementRequest{
+		Message:   req.Message,
+		Severity:  req.Severity,
+		Event:     req.Event,
+		ExpiresAt: expiresAt,
+	}.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "announcement.Create")
+	announcementCreatedEvent, err := announcement.Create(db, announcement.PendingAnnouncement{
+		ID:        uuid.Must(uuid.NewV4()),
+		Message:   req.Message,
+		Severity:  req.Severity,
+		Event:     req.Event,
+		ExpiresAt: expiresAt,
+	})
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.Dispatcher.Flush(*tracing.DB(ctx, s.server.DB))
+
+	return announcementCreatedEvent, nil
+}
+
+func (s *Server) GetAnnouncement(ctx context.Context, req *GetRequest) (*Announcement, error) {
+	id, version, err := parseGetRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	db, end := tracing.Command(ctx, s.server.DB, "announcement.GetAnnouncement")
+	announcementFetched, err := announcement.GetAnnouncement(db, id, version)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAnnouncement(announcementFetched), nil
+}
+
+func (s *Server) GetActiveAnnouncements(ctx context.Context, req *GetActiveAnnouncementsRequest) (*Announcements, error) {
+	db, end := tracing.Command(ctx, s.server.DB, "announcement.GetActiveAnnouncements")
+	announcements, err := announcement.GetActiveAnnouncements(db, utils.MakeTimestampInMilliseconds())
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Announcements{}
+	for i := range *announcements {
+		res.Announcements = append(res.Announcements, toAnnouncement(&(*announcements)[i]))
+	}
+
+	return res, nil
+}
+
+// WatchResults streams the dashboard messages the same way the dashboard WebSocket and events stream do.
+func (s *Server) WatchResults(req *WatchRequest, stream Timing_WatchResultsServer) error {
+	subscription := dashboard_controller.Subscription{
+		Event: strings.TrimSpace(req.Event),
+	}
+
+	for _, startNumber := range req.StartNumbers {
+		if subscription.StartNumbers == nil {
+			subscription.StartNumbers = make(map[uint32]bool)
+		}
+		subscription.StartNumbers[startNumber] = true
+	}
+
+	var lastEventID *uint64
+	if req.LastEventID != 0 {
+		lastEventID = &req.LastEventID
+	}
+
+	err := s.server.Dashboard.Watch(stream.Context(), subscription, lastEventID, stream.Send)
+	if errors.Is(err, dashboard_controller.ErrStopped) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return err
+}
+
+// parseGetRequest returns the entity ID and the requested version, nil for the current version.
+func parseGetRequest(req *GetRequest) (uuid.UUID, *uint32, error) {
+	id, err := uuid.FromString(req.ID)
+	if err != nil {
+		return uuid.Nil, nil, responses.MalformedRequest{Err: err}
+	}
+
+	if req.Version == 0 {
+		return id, nil, nil
+	}
+
+	return id, &req.Version, nil
+}
@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"log"
+	"net"
+	"path/filepath"
+	domain_errors "sports/backend/domain/errors"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/result"
+	"sports/backend/srv/auth"
+	"sports/backend/srv/cmd/config"
+	checkpoint_controller "sports/backend/srv/controllers/checkpoint"
+	dashboard_controller "sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/server"
+	"sports/backend/srv/utils"
+	"time"
+)
+
+// dial serves the service in memory and returns its client.
+func dial(srv *server.Server) (TimingClient, func()) {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := NewServer(srv)
+	go grpcServer.Serve(listener)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+	)
+	Expect(err).To(BeNil())
+
+	return NewTimingClient(conn), func() {
+		conn.Close()
+		grpcServer.Stop()
+	}
+}
+
+// reasonOf returns the problem code carried by the status of the failed call.
+func reasonOf(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+
+	return ""
+}
+
+var _ = Describe("RPC server", func() {
+	Describe("Problems mapped to the codes", func() {
+		expectStatus := func(err error, code codes.Code, reason string) {
+			s := statusOf(err)
+			Expect(s.Code()).To(Equal(code))
+			Expect(reasonOf(s.Err())).To(Equal(reason))
+		}
+
+		Specify("Not found", func() {
+			expectStatus(checkpoint.NotFound{}, codes.NotFound, "checkpoint_not_found")
+		})
+
+		Specify("Already exists", func() {
+			expectStatus(result.AlreadyExists{}, codes.AlreadyExists, "result_already_exists")
+		})
+
+		Specify("Already finished", func() {
+			expectStatus(result.AlreadyFinished{}, codes.FailedPrecondition, "result_already_finished")
+		})
+
+		Specify("Concurrent update", func() {
+			expectStatus(fmt.Errorf("State conflict: %w", domain_errors.StateConflict{}), codes.Aborted, "state_conflict")
+		})
+
+		Specify("Outdated version", func() {
+			expectStatus(domain_errors.InvalidVersion{}, codes.FailedPrecondition, "invalid_version")
+		})
+
+		Specify("Invalid request", func() {
+			expectStatus(checkpoint_controller.NewCheckpointRequest{}.Validate(), codes.InvalidArgument, "validation_failed")
+		})
+
+		Specify("Unauthenticated", func() {
+			expectStatus(auth.Unauthenticated{Reason: "credentials required"}, codes.Unauthenticated, "unauthenticated")
+		})
+
+		Specify("Unknown", func() {
+			expectStatus(fmt.Errorf("connection lost"), codes.Internal, "internal_error")
+		})
+
+		Specify("Invalid fields listed", func() {
+			s := statusOf(checkpoint_controller.NewCheckpointRequest{}.Validate())
+
+			info := s.Details()[0].(*errdetails.ErrorInfo)
+			Expect(info.Domain).To(Equal(ErrorDomain))
+			Expect(info.Metadata).To(HaveKey("name"))
+		})
+	})
+
+	Specify("Calls without credentials rejected", func() {
+		// The credentials are checked before the database is queried.
+		conn, err := sql.Open("postgres", "host=localhost port=1 sslmode=disable")
+		Expect(err).To(BeNil())
+		db, _ := gorm.Open("postgres", conn)
+
+		client, closeClient := dial(&server.Server{DB: db})
+		defer closeClient()
+
+		_, err = client.AddCheckpoint(context.Background(), &NewCheckpointRequest{Name: "Start"})
+		Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+		Expect(reasonOf(err)).To(Equal("unauthenticated"))
+	})
+
+	Describe("Results timed and watched", func() {
+		// To change the flags on the default logger to show the code line for better understanding.
+		log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+		// Set up database connection using configuration details.
+		absPath, _ := filepath.Abs("../cmd/config/")
+		cfg := config.Config{}
+		viper.AddConfigPath(absPath)
+		viper.SetConfigName("configuration")
+		viper.ReadInConfig()
+		viper.Unmarshal(&cfg)
+
+		var (
+			db          *gorm.DB
+			client      TimingClient
+			closeClient func()
+			ctx         context.Context
+		)
+
+		BeforeEach(func() {
+			conn, err := utils.GetDBConnection(
+				cfg.DBDriver,
+				cfg.DBUsername,
+				cfg.DBPassword,
+				cfg.DBPort,
+				cfg.DBHost,
+				cfg.DBName,
+			)
+			Expect(err).To(BeNil())
+
+			db = conn.Begin()
+
+			key := "rpc-" + uuid.Must(uuid.NewV4()).String()
+			Expect(auth.EnsureBootstrapCredential(*db, key)).To(Succeed())
+			ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+
+			// Set up the dashboard Websocket API module
+			srv := &server.Server{
+				DB:     db,
+				Router: mux.NewRouter(),
+				Dashboard: &dashboard_controller.Dashboard{
+					ConnHub: make(map[string]*dashboard_controller.Connection),
+					Results: make(chan dashboard_controller.UnfinishedResultMessage),
+					Finish:  make(chan dashboard_controller.FinishedResultMessage),
+					Join:    make(chan *dashboard_controller.Connection),
+					Leave:   make(chan *dashboard_controller.Connection),
+				},
+				Dispatcher: dispatcher.NewDispatcher(),
+			}
+			srv.Dashboard.Subscribe(srv.Dispatcher)
+
+			go srv.Dashboard.Run(context.Background(), srv.DB)
+
+			for srv.Dashboard.LastResults == nil {
+				time.Sleep(100 * time.Millisecond)
+			}
+
+			client, closeClient = dial(srv)
+		})
+
+		AfterEach(func() {
+			closeClient()
+			db.Rollback()
+		})
+
+		Specify("The finished result streamed to the watchers", func() {
+			checkpointCreated, err := client.AddCheckpoint(ctx, &NewCheckpointRequest{Name: "Start"})
+			Expect(err).To(BeNil())
+
+			sportsmenCreated, err := client.AddSportsmen(ctx, &NewSportsmenRequest{
+				StartNumber: 101,
+				FirstName:   "Vladimir",
+				LastName:    "Andrianov",
+			})
+			Expect(err).To(BeNil())
+
+			watchCtx, stopWatching := context.WithCancel(ctx)
+			defer stopWatching()
+
+			stream, err := client.WatchResults(watchCtx, &WatchRequest{StartNumbers: []uint32{101}})
+			Expect(err).To(BeNil())
+
+			// The snapshot is sent first.
+			frame, err := stream.Recv()
+			Expect(err).To(BeNil())
+			Expect(frame.GetSnapshot()).NotTo(BeNil())
+
+			resultCreated, err := client.AddResult(ctx, &NewResultRequest{
+				CheckpointID: checkpointCreated.CheckpointID,
+				SportsmenID:  sportsmenCreated.SportsmenID,
+				TimeStart:    utils.MakeTimestampInMilliseconds(),
+			})
+			Expect(err).To(BeNil())
+
+			frame, err = stream.Recv()
+			Expect(err).To(BeNil())
+			Expect(frame.GetStart().ID).To(Equal(resultCreated.ResultID))
+
+			finish := &FinishRequest{
+				CheckpointID: checkpointCreated.CheckpointID,
+				SportsmenID:  sportsmenCreated.SportsmenID,
+				TimeFinish:   utils.MakeTimestampInMilliseconds(),
+				Version:      resultCreated.Version + 1,
+			}
+
+			// The outdated version is rejected the same way as by the REST API.
+			_, err = client.FinishResult(ctx, finish)
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			Expect(reasonOf(err)).To(Equal("invalid_version"))
+
+			finish.Version = resultCreated.Version
+			_, err = client.FinishResult(ctx, finish)
+			Expect(err).To(BeNil())
+
+			frame, err = stream.Recv()
+			Expect(err).To(BeNil())
+			Expect(frame.GetFinish().TimeFinish).To(Equal(finish.TimeFinish))
+
+			resultFetched, err := client.GetResult(ctx, &GetRequest{ID: resultCreated.ResultID})
+			Expect(err).To(BeNil())
+			Expect(resultFetched.TimeFinish).To(Equal(finish.TimeFinish))
+		})
+	})
+})
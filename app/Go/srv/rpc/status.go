@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/responses"
+	"strings"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details.
+const ErrorDomain = "sports"
+
+// statusCodes maps the HTTP status of the problem to the gRPC code.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusUnauthorized:         codes.Unauthenticated,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.FailedPrecondition,
+	http.StatusPreconditionFailed:   codes.FailedPrecondition,
+	http.StatusUnprocessableEntity:  codes.InvalidArgument,
+	http.StatusPreconditionRequired: codes.FailedPrecondition,
+}
+
+// codeOf returns the gRPC code of the problem, the concurrent updates are retried by the clients
+// and the creation conflicts are told apart as the already existing entities.
+func codeOf(problem responses.Problem) codes.Code {
+	switch {
+	case problem.Code == "state_conflict":
+		return codes.Aborted
+	case strings.HasSuffix(problem.Code, "_already_exists"):
+		return codes.AlreadyExists
+	}
+
+	code, ok := statusCodes[problem.Status]
+	if !ok {
+		return codes.Internal
+	}
+
+	return code
+}
+
+// statusOf returns the gRPC status of the error with the same problem code and detail as the REST API has.
+func statusOf(err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err)
+	}
+
+	problem := responses.ProblemOf(err)
+	if problem.Status == http.StatusInternalServerError {
+		zap.S().Errorf("Error processing the call: %v", err)
+	}
+
+	metrics.Errors.WithLabelValues(problem.Code).Inc()
+
+	s := status.New(codeOf(problem), problem.Detail)
+
+	detailed, detailsErr := s.WithDetails(&errdetails.ErrorInfo{
+		Reason:   problem.Code,
+		Domain:   ErrorDomain,
+		Metadata: problem.Errors,
+	})
+	if detailsErr != nil {
+		return s
+	}
+
+	return detailed
+}
@@ -3,7 +3,11 @@ package server
 import (
 	"github.com/gorilla/mux"
 	"github.com/jinzhu/gorm"
+	"sports/backend/srv/auth"
 	"sports/backend/srv/controllers/dashboard"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/health"
+	"time"
 )
 
 // Server is a wrapper for the service context.
@@ -12,4 +16,12 @@ type Server struct {
 	DB        *gorm.DB
 	Router    *mux.Router
 	Addr      string
+	Auth      auth.Settings
+	Health    *health.Checker
+
+	// Dispatcher publishes the domain events of the outbox, e.g. to the dashboard.
+	Dispatcher *dispatcher.Dispatcher
+
+	// Time the responses of the requests sent with Idempotency-Key are kept for.
+	IdempotencyKeyTTL time.Duration
 }
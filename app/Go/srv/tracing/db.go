@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"github.com/jinzhu/gorm"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	contextKey = "tracing:context"
+	spanKey    = "tracing:span"
+)
+
+// DB returns the connection traced as a part of the context, the queries
+// of the connection and its transactions are recorded as the child spans.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	return db.Set(contextKey, ctx)
+}
+
+// Command starts the span of the domain command, the returned connection records
+// the queries of the command as its child spans and the function ends the span.
+func Command(ctx context.Context, db *gorm.DB, name string) (gorm.DB, func(err error)) {
+	ctx, span := Start(ctx, name)
+
+	return *DB(ctx, db), func(err error) {
+		End(span, err)
+	}
+}
+
+// InstrumentDB registers the callbacks recording the queries of the traced connections.
+func InstrumentDB(db *gorm.DB) {
+	callback := db.Callback()
+
+	callback.Create().Before("gorm:begin_transaction").Register("tracing:before_create", start("create"))
+	callback.Create().After("gorm:commit_or_rollback_transaction").Register("tracing:after_create", end)
+
+	callback.Query().Before("gorm:query").Register("tracing:before_query", start("query"))
+	callback.Query().After("gorm:after_query").Register("tracing:after_query", end)
+
+	callback.RowQuery().Before("gorm:row_query").Register("tracing:before_row_query", start("row_query"))
+	callback.RowQuery().After("gorm:row_query").Register("tracing:after_row_query", end)
+
+	callback.Update().Before("gorm:begin_transaction").Register("tracing:before_update", start("update"))
+	callback.Update().After("gorm:commit_or_rollback_transaction").Register("tracing:after_update", end)
+
+	callback.Delete().Before("gorm:begin_transaction").Register("tracing:before_delete", start("delete"))
+	callback.Delete().After("gorm:commit_or_rollback_transaction").Register("tracing:after_delete", end)
+}
+
+func start(operation string) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		value, ok := scope.Get(contextKey)
+		if !ok {
+			return
+		}
+
+		table := scope.TableName()
+		_, span := Start(value.(context.Context), "db."+operation+" "+table,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", scope.Dialect().GetName()),
+				attribute.String("db.operation", operation),
+				attribute.String("db.sql.table", table),
+			),
+		)
+		scope.Set(spanKey, span)
+	}
+}
+
+func end(scope *gorm.Scope) {
+	value, ok := scope.Get(spanKey)
+	if !ok {
+		return
+	}
+
+	// Missing records are told by the domain errors, they are not failures of the query.
+	err := scope.DB().Error
+	if gorm.IsRecordNotFoundError(err) {
+		err = nil
+	}
+
+	span := value.(trace.Span)
+	span.SetAttributes(attribute.String("db.statement", scope.SQL))
+	End(span, err)
+}
@@ -0,0 +1,65 @@
+package tracing_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/srv/tracing"
+)
+
+var _ = Describe("Tracing", func() {
+	var recorder *tracetest.SpanRecorder
+
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	})
+
+	Specify("Unknown exporter rejected", func() {
+		_, err := tracing.Init(context.Background(), tracing.Settings{Exporter: "zipkin"})
+		Expect(err).NotTo(BeNil())
+	})
+
+	Specify("Spans not exported by default", func() {
+		flush, err := tracing.Init(context.Background(), tracing.Settings{})
+		Expect(err).To(BeNil())
+		Expect(flush(context.Background())).To(Succeed())
+	})
+
+	Specify("Failed command and its queries recorded", func() {
+		// The database is not reachable, the query fails.
+		conn, err := sql.Open("postgres", "host=localhost port=1 sslmode=disable")
+		Expect(err).To(BeNil())
+		db, _ := gorm.Open("postgres", conn)
+		tracing.InstrumentDB(db)
+
+		ctx, span := tracing.Start(context.Background(), "GET /checkpoints/{id}")
+
+		commandDB, end := tracing.Command(ctx, db, "checkpoint.GetCheckpoint")
+		_, err = checkpoint.GetCheckpoint(commandDB, uuid.Must(uuid.NewV4()), nil)
+		end(err)
+		span.End()
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(3))
+
+		Expect(spans[0].Name()).To(Equal("db.query checkpoints"))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Parent().SpanID()).To(Equal(spans[1].SpanContext().SpanID()))
+
+		Expect(spans[1].Name()).To(Equal("checkpoint.GetCheckpoint"))
+		Expect(spans[1].Status().Code).To(Equal(codes.Error))
+		Expect(spans[1].Parent().SpanID()).To(Equal(spans[2].SpanContext().SpanID()))
+
+		Expect(tracing.TraceID(ctx)).To(Equal(spans[2].SpanContext().TraceID().String()))
+	})
+})
@@ -5,12 +5,46 @@ import (
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
 	"go.uber.org/zap"
+	"sports/backend/domain/models/announcement"
 	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/credential"
+	"sports/backend/domain/models/device"
+	"sports/backend/domain/models/idempotency"
+	"sports/backend/domain/models/lap"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/models/race"
+	"sports/backend/domain/models/record"
 	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/split"
 	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/team"
+	"sports/backend/domain/models/webhook"
 	"time"
 )
 
+// Models returns the migrated domain models.
+func Models() []interface{} {
+	return []interface{}{
+		&result.Result{},
+		&checkpoint.Checkpoint{},
+		&sportsmen.Sportsmen{},
+		&announcement.Announcement{},
+		&credential.Credential{},
+		&device.Device{},
+		&split.Split{},
+		&record.Record{},
+		&idempotency.Key{},
+		&outbox.Message{},
+		&outbox.Delivery{},
+		&webhook.Subscription{},
+		&webhook.Delivery{},
+		&race.Race{},
+		&lap.Lap{},
+		&team.Team{},
+		&team.Leg{},
+	}
+}
+
 // GetDBConnection with the given configuration details.
 func GetDBConnection(driver, username, password, port, host, database string) (*gorm.DB, error) {
 	var err error
@@ -23,15 +57,31 @@ func GetDBConnection(driver, username, password, port, host, database string) (*
 		zap.S().Infof("Connected to the %s database ", driver)
 	}
 
-	// Database migration
-	db.AutoMigrate(
-		&result.Result{},
-		&checkpoint.Checkpoint{},
-		&sportsmen.Sportsmen{},
-	)
+	// Database migration, the server does not start without the schema it relies on, e.g. the unique index
+	// of the results is not created while the duplicate results of the sportsmen at the checkpoint exist.
+	err = db.AutoMigrate(Models()...).Error
+	if err != nil {
+		return nil, fmt.Errorf("migrating the database: %w", err)
+	}
 
-	db.Model(&result.Result{}).AddForeignKey("checkpoint_id", "checkpoints(id)", "RESTRICT", "RESTRICT")
-	db.Model(&result.Result{}).AddForeignKey("sportsmen_id", "sportsmens(id)", "RESTRICT", "RESTRICT")
+	foreignKeys := []struct {
+		model            interface{}
+		field, reference string
+	}{
+		{&result.Result{}, "checkpoint_id", "checkpoints(id)"},
+		{&result.Result{}, "sportsmen_id", "sportsmens(id)"},
+		{&race.Race{}, "checkpoint_id", "checkpoints(id)"},
+		{&lap.Lap{}, "result_id", "results(id)"},
+		{&team.Leg{}, "team_id", "teams(id)"},
+		{&team.Leg{}, "sportsmen_id", "sportsmens(id)"},
+		{&team.Leg{}, "checkpoint_id", "checkpoints(id)"},
+	}
+	for _, key := range foreignKeys {
+		err = db.Model(key.model).AddForeignKey(key.field, key.reference, "RESTRICT", "RESTRICT").Error
+		if err != nil {
+			return nil, fmt.Errorf("migrating the database: %w", err)
+		}
+	}
 
 	return db, nil
 }
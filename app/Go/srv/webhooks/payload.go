@@ -0,0 +1,208 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	"sports/backend/domain/models/announcement"
+	"sports/backend/domain/models/outbox"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/utils"
+)
+
+// SubscriberName names the webhooks among the outbox subscribers.
+const SubscriberName = "webhooks"
+
+// Body is the JSON document posted to the webhook.
+type Body struct {
+	// ID of the event, the same event redelivered or retried keeps it so the receivers may drop the repeated ones.
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt int64       `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// ResultData is the data of the result.created and result.finished events.
+type ResultData struct {
+	ResultID      string `json:"result_id"`
+	CheckpointID  string `json:"checkpoint_id"`
+	SportsmenID   string `json:"sportsmen_id"`
+	StartNumber   uint32 `json:"start_number"`
+	SportsmenName string `json:"sportsmen_name"`
+	Category      string `json:"category"`
+	TimeStart     int64  `json:"time_start"`
+	TimeFinish    *int64 `json:"time_finish"`
+}
+
+// StatusData is the data of the sportsmen.status_changed event.
+type StatusData struct {
+	SportsmenID   string `json:"sportsmen_id"`
+	StartNumber   uint32 `json:"start_number"`
+	SportsmenName string `json:"sportsmen_name"`
+	Category      string `json:"category"`
+	Status        string `json:"status"`
+}
+
+// AnnouncementData is the data of the announcement.created and announcement.retracted events.
+type AnnouncementData struct {
+	AnnouncementID string `json:"announcement_id"`
+	Message        string `json:"message"`
+	Severity       string `json:"severity"`
+	Event          string `json:"event"`
+	ExpiresAt      *int64 `json:"expires_at"`
+}
+
+// Subscribe the webhooks to the events of the outbox, the event is queued for every matching webhook
+// in the dispatch transaction and posted by the sender.
+func Subscribe(outboxDispatcher *dispatcher.Dispatcher) {
+	for _, eventType := range webhook.EventTypes {
+		outboxDispatcher.Subscribe(eventType.(string), SubscriberName, queue)
+	}
+}
+
+func queue(tx gorm.DB, message outbox.Message) error {
+	subscriptions, err := webhook.GetSubscriptionsOf(tx, message.Topic)
+	if err != nil {
+		return err
+	} else if len(*subscriptions) == 0 {
+		return nil
+	}
+
+	data, attributes, err := dataOf(tx, message)
+	if err != nil {
+		return err
+	}
+
+	now := utils.MakeTimestampInMilliseconds()
+
+	payload, err := json.Marshal(Body{
+		ID:        message.ID.String(),
+		Type:      message.Topic,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range *subscriptions {
+		if !subscription.Matches(message.Topic, attributes) {
+			continue
+		}
+
+		_, err := webhook.Queue(tx, webhook.PendingDelivery{
+			ID:             uuid.Must(uuid.NewV4()),
+			SubscriptionID: subscription.ID,
+			MessageID:      message.ID,
+			EventType:      message.Topic,
+			Payload:        payload,
+			NextAttemptAt:  now,
+		})
+		if err != nil && !errors.As(err, &webhook.AlreadyQueued{}) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dataOf decodes the outbox message into the data of the webhook event and the attributes it is filtered by.
+func dataOf(tx gorm.DB, message outbox.Message) (interface{}, webhook.Attributes, error) {
+	switch message.Topic {
+	case result.TopicCreated, result.TopicFinished:
+		var resultID string
+		if message.Topic == result.TopicCreated {
+			event := result.ResultCreatedEvent{}
+			if err := event.Unmarshal(message.Payload); err != nil {
+				return nil, webhook.Attributes{}, err
+			}
+			resultID = event.ResultID
+		} else {
+			event := result.ResultFinishedEvent{}
+			if err := event.Unmarshal(message.Payload); err != nil {
+				return nil, webhook.Attributes{}, err
+			}
+			resultID = event.ResultID
+		}
+
+		resultFetched, err := result.GetResult(tx, uuid.FromStringOrNil(resultID), nil)
+		if err != nil {
+			return nil, webhook.Attributes{}, err
+		}
+
+		sportsmenFetched, err := sportsmen.GetSportsmen(tx, resultFetched.SportsmenID, nil)
+		if err != nil {
+			return nil, webhook.Attributes{}, err
+		}
+
+		data := ResultData{
+			ResultID:      resultFetched.ID.String(),
+			CheckpointID:  resultFetched.CheckpointID.String(),
+			SportsmenID:   sportsmenFetched.ID.String(),
+			StartNumber:   sportsmenFetched.StartNumber,
+			SportsmenName: fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
+			Category:      sportsmenFetched.Category,
+			TimeStart:     resultFetched.TimeStart,
+		}
+
+		// The created event carries no finish time even when it is delivered after the finish.
+		if message.Topic == result.TopicFinished {
+			data.TimeFinish = resultFetched.TimeFinish
+		}
+
+		return data, webhook.Attributes{CheckpointID: data.CheckpointID, Category: data.Category}, nil
+	case sportsmen.TopicStatusChanged:
+		event := sportsmen.SportsmenStatusChangedEvent{}
+		if err := event.Unmarshal(message.Payload); err != nil {
+			return nil, webhook.Attributes{}, err
+		}
+
+		sportsmenFetched, err := sportsmen.GetSportsmen(tx, uuid.FromStringOrNil(event.SportsmenID), nil)
+		if err != nil {
+			return nil, webhook.Attributes{}, err
+		}
+
+		return StatusData{
+			SportsmenID:   event.SportsmenID,
+			StartNumber:   sportsmenFetched.StartNumber,
+			SportsmenName: fmt.Sprintf("%s %s", sportsmenFetched.FirstName, sportsmenFetched.LastName),
+			Category:      sportsmenFetched.Category,
+			Status:        event.Status,
+		}, webhook.Attributes{Category: sportsmenFetched.Category}, nil
+	case announcement.TopicCreated, announcement.TopicRetracted:
+		var announcementID string
+		if message.Topic == announcement.TopicCreated {
+			event := announcement.AnnouncementCreatedEvent{}
+			if err := event.Unmarshal(message.Payload); err != nil {
+				return nil, webhook.Attributes{}, err
+			}
+			announcementID = event.AnnouncementID
+		} else {
+			event := announcement.AnnouncementRetractedEvent{}
+			if err := event.Unmarshal(message.Payload); err != nil {
+				return nil, webhook.Attributes{}, err
+			}
+			announcementID = event.AnnouncementID
+		}
+
+		announcementFetched, err := announcement.GetAnnouncement(tx, uuid.FromStringOrNil(announcementID), nil)
+		if err != nil {
+			return nil, webhook.Attributes{}, err
+		}
+
+		return AnnouncementData{
+			AnnouncementID: announcementFetched.ID.String(),
+			Message:        announcementFetched.Message,
+			Severity:       announcementFetched.Severity,
+			Event:          announcementFetched.Event,
+			ExpiresAt:      announcementFetched.ExpiresAt,
+		}, webhook.Attributes{}, nil
+	}
+
+	return nil, webhook.Attributes{}, fmt.Errorf("Unknown webhook event type %s", message.Topic)
+}
@@ -0,0 +1,240 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/jinzhu/gorm"
+	"go.uber.org/zap"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/domain/transaction"
+	"sports/backend/srv/metrics"
+	"sports/backend/srv/utils"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers of the webhook requests.
+const (
+	EventHeader     = "X-Sports-Event"
+	DeliveryHeader  = "X-Sports-Delivery"
+	TimestampHeader = "X-Sports-Timestamp"
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the timestamp, the dot and the body
+	// keyed with the webhook secret, see Sign.
+	SignatureHeader = "X-Sports-Signature"
+)
+
+// Defaults of the sender settings.
+const (
+	DefaultInterval    = time.Second
+	DefaultBatchSize   = 100
+	DefaultTimeout     = 10 * time.Second
+	DefaultMaxAttempts = 8
+	DefaultBackoff     = 10 * time.Second
+	DefaultMaxBackoff  = time.Hour
+)
+
+// Part of the response body kept in the delivery log of the failed attempt.
+const responseExcerptSize = 256
+
+// Sign returns the signature of the body sent at the timestamp in seconds, the receivers compute it the same way
+// with their secret, compare it in constant time and reject the old timestamps to prevent the replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender posts the queued deliveries to the webhooks, the failed attempts are retried with the exponential
+// backoff and the delivery is given up after MaxAttempts.
+type Sender struct {
+	Client *http.Client
+	// Interval the due deliveries are polled at.
+	Interval time.Duration
+	// BatchSize is the number of the deliveries sent in one poll.
+	BatchSize int
+	// MaxAttempts is the number of the attempts after which the delivery is given up until redelivered.
+	MaxAttempts uint32
+	// Backoff is the delay before the first retry, doubled for every next one up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// mutex lets one poll run at a time.
+	mutex sync.Mutex
+}
+
+// NewSender creates the sender with the default settings.
+func NewSender() *Sender {
+	return &Sender{
+		Client:      &http.Client{Timeout: DefaultTimeout},
+		Interval:    DefaultInterval,
+		BatchSize:   DefaultBatchSize,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// Run polls the due deliveries until the context is done.
+func (s *Sender) Run(ctx context.Context, db *gorm.DB) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Send(ctx, *db); err != nil {
+				zap.S().Errorf("Error sending webhook deliveries: %v", err)
+			}
+		}
+	}
+}
+
+// Send posts the batch of the due deliveries, the number of the attempted deliveries is returned.
+// Every delivery is claimed in the short transaction keeping it from the other senders, posted outside
+// of any transaction and its attempt is recorded in another transaction.
+func (s *Sender) Send(ctx context.Context, db gorm.DB) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	attempted := 0
+	for attempted < s.BatchSize {
+		subscription, delivery, err := s.claim(db)
+		if err != nil {
+			return attempted, err
+		} else if delivery == nil {
+			break
+		}
+
+		err = s.attempt(ctx, db, *subscription, *delivery)
+		if err != nil {
+			return attempted, err
+		}
+
+		attempted++
+	}
+
+	return attempted, nil
+}
+
+// claim takes the due delivery with its subscription, nil is returned when none is due. The claim outlasts
+// the request so that the delivery is not posted by another sender meanwhile.
+func (s *Sender) claim(db gorm.DB) (*webhook.Subscription, *webhook.Delivery, error) {
+	var subscription *webhook.Subscription
+	var delivery *webhook.Delivery
+
+	err := transaction.Run(db, func(tx gorm.DB) error {
+		due, err := webhook.GetDueDelivery(tx, utils.MakeTimestampInMilliseconds())
+		if err != nil || due == nil {
+			return err
+		}
+
+		subscription, err = webhook.GetSubscription(tx, due.SubscriptionID, nil)
+		if err != nil {
+			return err
+		}
+
+		claimedUntil := utils.MakeTimestampInMilliseconds() + 2*s.timeout().Milliseconds()
+		event, err := webhook.Claim(tx, claimedUntil, *due)
+		if err != nil {
+			return err
+		}
+
+		due.NextAttemptAt = event.ClaimedUntil
+		due.Version = event.Version
+		delivery = due
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return subscription, delivery, nil
+}
+
+// timeout returns the time the request of the delivery may take.
+func (s *Sender) timeout() time.Duration {
+	if s.Client.Timeout == 0 {
+		return DefaultTimeout
+	}
+
+	return s.Client.Timeout
+}
+
+// attempt posts the delivery and records the outcome in its own transaction.
+func (s *Sender) attempt(ctx context.Context, db gorm.DB, subscription webhook.Subscription, delivery webhook.Delivery) error {
+	responseStatus, err := s.post(ctx, subscription, delivery)
+	if err == nil {
+		metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, metrics.OutcomeDelivered).Inc()
+		_, err := webhook.MarkDelivered(db, utils.MakeTimestampInMilliseconds(), responseStatus, delivery)
+		return err
+	}
+
+	if delivery.Attempts+1 >= s.MaxAttempts {
+		metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, metrics.OutcomeFailed).Inc()
+		zap.S().Warnf("Webhook delivery %s to %s given up after %d attempts: %v", delivery.ID, subscription.URL, delivery.Attempts+1, err)
+		_, err := webhook.Fail(db, err.Error(), responseStatus, delivery)
+		return err
+	}
+
+	metrics.WebhookDeliveries.WithLabelValues(delivery.EventType, metrics.OutcomeRetried).Inc()
+	nextAttemptAt := utils.MakeTimestampInMilliseconds() + s.backoff(delivery.Attempts+1).Milliseconds()
+	_, err = webhook.Retry(db, err.Error(), responseStatus, nextAttemptAt, delivery)
+	return err
+}
+
+// backoff returns the delay after the given number of the failed attempts.
+func (s *Sender) backoff(attempts uint32) time.Duration {
+	delay := s.Backoff
+	for i := uint32(1); i < attempts && delay < s.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > s.MaxBackoff {
+		return s.MaxBackoff
+	}
+
+	return delay
+}
+
+// post sends the signed delivery, the 2xx response accepts it. The status of the response is returned, 0 when
+// the receiver has not responded.
+func (s *Sender) post(ctx context.Context, subscription webhook.Subscription, delivery webhook.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sports-webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		excerpt, _ := ioutil.ReadAll(io.LimitReader(res.Body, responseExcerptSize))
+		return res.StatusCode, fmt.Errorf("%s: %s", res.Status, excerpt)
+	}
+
+	return res.StatusCode, nil
+}
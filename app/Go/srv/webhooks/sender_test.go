@@ -0,0 +1,232 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sports/backend/domain/models/checkpoint"
+	"sports/backend/domain/models/result"
+	"sports/backend/domain/models/sportsmen"
+	"sports/backend/domain/models/webhook"
+	"sports/backend/srv/cmd/config"
+	"sports/backend/srv/dispatcher"
+	"sports/backend/srv/utils"
+	"sports/backend/srv/webhooks"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// receiver is the local webhook receiver answering with the given status.
+type receiver struct {
+	mutex    sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+var _ = Describe("Sending the webhooks", func() {
+	var (
+		db *gorm.DB
+	)
+
+	// Set up database connection using configuration details.
+	absPath, _ := filepath.Abs("../cmd/config/")
+	cfg := config.Config{}
+	viper.AddConfigPath(absPath)
+	viper.SetConfigName("configuration")
+	viper.ReadInConfig()
+	viper.Unmarshal(&cfg)
+	conn, err := utils.GetDBConnection(
+		cfg.DBDriver,
+		cfg.DBUsername,
+		cfg.DBPassword,
+		cfg.DBPort,
+		cfg.DBHost,
+		cfg.DBName,
+	)
+	Expect(err).To(BeNil())
+
+	outboxDispatcher := dispatcher.NewDispatcher()
+	webhooks.Subscribe(outboxDispatcher)
+
+	const secret = "0123456789abcdef"
+
+	var (
+		hooks        *receiver
+		server       *httptest.Server
+		sender       *webhooks.Sender
+		checkpointID uuid.UUID
+		sportsmenID  uuid.UUID
+		subscription webhook.PendingSubscription
+	)
+
+	BeforeEach(func() {
+		db = conn.Begin()
+
+		hooks = &receiver{status: http.StatusNoContent}
+		server = httptest.NewServer(hooks)
+
+		sender = webhooks.NewSender()
+		sender.Backoff = time.Minute
+
+		checkpointID = uuid.Must(uuid.NewV4())
+		_, err := checkpoint.Create(*db, checkpoint.PendingCheckpoint{ID: checkpointID, Name: "Start"})
+		Expect(err).To(BeNil())
+
+		sportsmenID = uuid.Must(uuid.NewV4())
+		_, err = sportsmen.Create(*db, sportsmen.PendingSportsmen{
+			ID:          sportsmenID,
+			StartNumber: 7,
+			FirstName:   "Vladimir",
+			LastName:    "Andrianov",
+			Category:    "M40",
+		})
+		Expect(err).To(BeNil())
+
+		subscription = webhook.PendingSubscription{
+			ID:         uuid.Must(uuid.NewV4()),
+			URL:        server.URL,
+			Secret:     secret,
+			EventTypes: []string{result.TopicCreated},
+			Category:   "M40",
+		}
+		_, err = webhook.Create(*db, subscription)
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		_ = db.Rollback()
+	})
+
+	// start creates the result and dispatches its event to the webhooks.
+	start := func() uuid.UUID {
+		resultID := uuid.Must(uuid.NewV4())
+		_, err := result.Create(*db, result.PendingResult{
+			ID:           resultID,
+			CheckpointID: checkpointID,
+			SportsmenID:  sportsmenID,
+			TimeStart:    utils.MakeTimestampInMilliseconds(),
+		})
+		Expect(err).To(BeNil())
+
+		_, err = outboxDispatcher.Dispatch(*db)
+		Expect(err).To(BeNil())
+
+		return resultID
+	}
+
+	deliveries := func() []webhook.Delivery {
+		fetched, err := webhook.GetDeliveries(*db, subscription.ID, 10)
+		Expect(err).To(BeNil())
+		return *fetched
+	}
+
+	When("the receiver accepts the event", func() {
+		Specify("the signed event is posted and logged delivered", func() {
+			resultID := start()
+
+			_, err := sender.Send(context.Background(), *db)
+			Expect(err).To(BeNil())
+
+			Expect(hooks.requests).To(HaveLen(1))
+			req, body := hooks.requests[0], hooks.bodies[0]
+
+			Expect(req.Header.Get(webhooks.EventHeader)).To(Equal(result.TopicCreated))
+			timestamp, err := strconv.ParseInt(req.Header.Get(webhooks.TimestampHeader), 10, 64)
+			Expect(err).To(BeNil())
+			Expect(req.Header.Get(webhooks.SignatureHeader)).To(Equal(webhooks.Sign(secret, timestamp, body)))
+
+			posted := struct {
+				Type string              `json:"type"`
+				Data webhooks.ResultData `json:"data"`
+			}{}
+			Expect(json.Unmarshal(body, &posted)).To(Succeed())
+			Expect(posted.Type).To(Equal(result.TopicCreated))
+			Expect(posted.Data.ResultID).To(Equal(resultID.String()))
+			Expect(posted.Data.StartNumber).To(Equal(uint32(7)))
+			Expect(posted.Data.SportsmenName).To(Equal("Vladimir Andrianov"))
+
+			logged := deliveries()
+			Expect(logged).To(HaveLen(1))
+			Expect(logged[0].Status).To(Equal(webhook.StatusDelivered))
+			Expect(logged[0].ResponseStatus).To(Equal(http.StatusNoContent))
+			Expect(req.Header.Get(webhooks.DeliveryHeader)).To(Equal(logged[0].ID.String()))
+		})
+	})
+
+	When("the event does not pass the filter", func() {
+		Specify("it is not queued", func() {
+			Expect(db.Model(&sportsmen.Sportsmen{}).Where("id = ?", sportsmenID).Update("category", "W40").Error).To(BeNil())
+
+			start()
+
+			Expect(deliveries()).To(BeEmpty())
+		})
+	})
+
+	When("the receiver fails", func() {
+		Specify("the delivery is retried after the backoff", func() {
+			hooks.status = http.StatusServiceUnavailable
+			start()
+
+			_, err := sender.Send(context.Background(), *db)
+			Expect(err).To(BeNil())
+
+			logged := deliveries()
+			Expect(logged).To(HaveLen(1))
+			Expect(logged[0].Status).To(Equal(webhook.StatusPending))
+			Expect(logged[0].Attempts).To(Equal(uint32(1)))
+			Expect(logged[0].ResponseStatus).To(Equal(http.StatusServiceUnavailable))
+			Expect(logged[0].NextAttemptAt).To(BeNumerically(">", utils.MakeTimestampInMilliseconds()+50*1000))
+
+			// Not due before the backoff.
+			_, err = sender.Send(context.Background(), *db)
+			Expect(err).To(BeNil())
+			Expect(hooks.requests).To(HaveLen(1))
+		})
+
+		Specify("the delivery is given up after the max attempts and sent again when redelivered", func() {
+			hooks.status = http.StatusInternalServerError
+			sender.MaxAttempts = 1
+			start()
+
+			_, err := sender.Send(context.Background(), *db)
+			Expect(err).To(BeNil())
+
+			logged := deliveries()
+			Expect(logged[0].Status).To(Equal(webhook.StatusFailed))
+
+			hooks.status = http.StatusOK
+			_, err = webhook.Redeliver(*db, utils.MakeTimestampInMilliseconds(), logged[0])
+			Expect(err).To(BeNil())
+
+			_, err = sender.Send(context.Background(), *db)
+			Expect(err).To(BeNil())
+			Expect(hooks.requests).To(HaveLen(2))
+			Expect(hooks.bodies[1]).To(Equal(hooks.bodies[0]))
+			Expect(deliveries()[0].Status).To(Equal(webhook.StatusDelivered))
+		})
+	})
+})
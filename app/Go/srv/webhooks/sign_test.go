@@ -0,0 +1,24 @@
+package webhooks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"sports/backend/srv/webhooks"
+)
+
+var _ = Describe("Signing the deliveries", func() {
+	It("Signs the timestamp and the body with the secret", func() {
+		// echo -n '1700000000.{"id":"1"}' | openssl dgst -sha256 -hmac 0123456789abcdef
+		Expect(webhooks.Sign("0123456789abcdef", 1700000000, []byte(`{"id":"1"}`))).To(Equal(
+			"sha256=" + "d5f5834972cbc6cf5590800c46ccaa0cd6c16f19c0c73dbdf9b4c56390cbc2a3",
+		))
+	})
+
+	It("Tells apart the secrets, the timestamps and the bodies", func() {
+		signature := webhooks.Sign("0123456789abcdef", 1700000000, []byte(`{"id":"1"}`))
+
+		Expect(webhooks.Sign("fedcba9876543210", 1700000000, []byte(`{"id":"1"}`))).ToNot(Equal(signature))
+		Expect(webhooks.Sign("0123456789abcdef", 1700000001, []byte(`{"id":"1"}`))).ToNot(Equal(signature))
+		Expect(webhooks.Sign("0123456789abcdef", 1700000000, []byte(`{"id":"2"}`))).ToNot(Equal(signature))
+	})
+})
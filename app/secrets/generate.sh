@@ -0,0 +1,21 @@
+#!/bin/sh
+# Generates the local development secrets of docker-compose.yml from random values,
+# the existing secrets are kept. Run it once before the first `docker-compose up`.
+set -e
+
+cd "$(dirname "$0")"
+
+for example in *.example; do
+	secret="${example%.example}"
+	if [ -f "$secret" ]; then
+		continue
+	fi
+
+	value=$(head -c 24 /dev/urandom | od -An -tx1 | tr -d ' \n')
+	if [ "$secret" = "auth_bootstrap_api_key" ]; then
+		value="sk_$value"
+	fi
+
+	printf '%s' "$value" > "$secret"
+	echo "Generated $secret"
+done